@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+func runAPIKeysList(ctx context.Context, a *app, args []string) error {
+	keys, err := a.client.ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		lastUsed := "-"
+		if key.LastUsedAt != nil {
+			lastUsed = formatTime(*key.LastUsedAt)
+		}
+		rows = append(rows, []string{key.ID, key.Name, key.Prefix + "…", joinScopes(key.Scopes), lastUsed})
+	}
+	return a.out.print(keys, []string{"ID", "NAME", "PREFIX", "SCOPES", "LAST USED"}, rows)
+}
+
+func runAPIKeysCreate(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("api-keys create", flag.ContinueOnError)
+	name := flags.String("name", "", "key name")
+	scopes := flags.String("scopes", "", "comma-separated scopes, e.g. read,chat")
+	expires := flags.Int("expires-days", 0, "days until the key expires (0 for never)")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if *name == "" {
+		fmt.Fprintln(a.stderr, "usage: copilot api-keys create -name NAME [-scopes LIST] [-expires-days N]")
+		return errUsage
+	}
+
+	req := &copilot.ApiKeyCreate{Name: *name, ExpiresInDays: *expires}
+	for _, scope := range strings.Split(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			req.Scopes = append(req.Scopes, copilot.ApiKeyScope(scope))
+		}
+	}
+
+	key, err := a.client.CreateAPIKey(ctx, req)
+	if err != nil {
+		return err
+	}
+	if !a.out.json {
+		fmt.Fprintln(a.stderr, "Store the key now; it will not be shown again.")
+	}
+	return a.out.print(key, []string{"ID", "NAME", "KEY"}, [][]string{{key.ID, key.Name, key.Key}})
+}
+
+func runAPIKeysRevoke(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintln(a.stderr, "usage: copilot api-keys revoke ID")
+		return errUsage
+	}
+	return a.client.RevokeAPIKey(ctx, args[0])
+}
+
+func joinScopes(scopes []copilot.ApiKeyScope) string {
+	s := make([]string, len(scopes))
+	for i, scope := range scopes {
+		s[i] = string(scope)
+	}
+	return orDash(strings.Join(s, ","))
+}
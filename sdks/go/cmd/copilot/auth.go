@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+)
+
+func runLogin(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("login", flag.ContinueOnError)
+	username := flags.String("u", "", "username or email")
+	password := flags.String("p", "", "password (prompted for when omitted)")
+	browser := flags.Bool("browser", false, "log in through the browser using OAuth")
+	clientID := flags.String("client-id", "copilot-cli", "OAuth client ID for -browser or -oidc-issuer")
+	issuer := flags.String("oidc-issuer", "", "log in through an external OpenID Connect provider")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+
+	openBrowser := func(authURL string) error {
+		fmt.Fprintf(a.stderr, "Opening %s\n", authURL)
+		return auth.OpenBrowser(authURL)
+	}
+
+	if *issuer != "" {
+		resp, err := a.client.LoginWithOIDC(ctx, *issuer, *clientID, openBrowser)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stderr, "Logged in as %s.\n", resp.User.Username)
+		return nil
+	}
+
+	if *browser {
+		config := a.client.OAuthConfig(*clientID)
+		if _, err := a.client.LoginWithBrowser(ctx, config, openBrowser); err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stderr, "Logged in.")
+		return nil
+	}
+
+	reader := bufio.NewReader(a.stdin)
+	if *username == "" {
+		fmt.Fprint(a.stderr, "Username: ")
+		*username = readLine(reader)
+	}
+	if *password == "" {
+		fmt.Fprint(a.stderr, "Password: ")
+		*password = readLine(reader)
+	}
+
+	resp, err := a.client.Login(ctx, *username, *password)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Logged in as %s.\n", resp.User.Username)
+	return nil
+}
+
+func runLogout(ctx context.Context, a *app, args []string) error {
+	return a.client.Logout(ctx)
+}
+
+func runWhoami(ctx context.Context, a *app, args []string) error {
+	user, err := a.client.GetCurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	return a.out.print(user,
+		[]string{"ID", "USERNAME", "EMAIL", "ROLES"},
+		[][]string{{user.ID, user.Username, user.Email, strings.Join(user.Roles, ",")}},
+	)
+}
+
+// readLine reads a line without its trailing newline.
+func readLine(r *bufio.Reader) string {
+	line, _ := r.ReadString('\n')
+	return strings.TrimRight(line, "\r\n")
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+const chatHelp = `Commands:
+  /attach FILE   Attach a file to the conversation as context
+  /context       List files attached in this session
+  /new [TITLE]   Start a new conversation
+  /id            Show the conversation ID
+  /help          Show this help
+  /quit          Exit (or press Ctrl-D)
+`
+
+// chatSession is an interactive chat in a terminal.
+type chatSession struct {
+	*app
+	conversationID copilot.ConversationID
+	attached       []*copilot.ContextItem
+	color          bool
+}
+
+// runChat starts an interactive chat, resuming the conversation used last
+// time against the same server unless -new or -conversation is given.
+func runChat(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("chat", flag.ContinueOnError)
+	newConv := flags.Bool("new", false, "start a new conversation")
+	conversationID := flags.String("conversation", "", "conversation ID to resume")
+	title := flags.String("title", "", "title for a new conversation")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+
+	s := &chatSession{app: a, color: isTerminal(a.out.w)}
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *conversationID != "":
+		s.conversationID = copilot.ConversationID(*conversationID)
+	case !*newConv:
+		s.conversationID = state.Conversations[a.client.BaseURL()]
+	}
+	if s.conversationID == "" {
+		if err := s.newConversation(ctx, *title); err != nil {
+			return err
+		}
+	} else {
+		s.note("Resuming conversation %s. Type /help for commands.", s.conversationID)
+	}
+	if err := s.remember(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(a.stdin)
+	for {
+		fmt.Fprint(a.stderr, s.style("1;34", "you> "))
+		if !scanner.Scan() {
+			fmt.Fprintln(a.stderr)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "/") {
+			quit, err := s.command(ctx, line)
+			if err != nil {
+				s.note("error: %v", err)
+			}
+			if quit {
+				return nil
+			}
+			continue
+		}
+
+		if err := s.send(ctx, line); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.note("error: %v", err)
+		}
+	}
+}
+
+// command runs a slash-command and reports whether the session should end.
+func (s *chatSession) command(ctx context.Context, line string) (bool, error) {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case "/quit", "/exit":
+		return true, nil
+	case "/help":
+		fmt.Fprint(s.stderr, chatHelp)
+	case "/id":
+		s.note("%s", s.conversationID)
+	case "/new":
+		s.attached = nil
+		if err := s.newConversation(ctx, arg); err != nil {
+			return false, err
+		}
+		return false, s.remember()
+	case "/attach":
+		if arg == "" {
+			return false, errors.New("usage: /attach FILE")
+		}
+		return false, s.attach(ctx, arg)
+	case "/context":
+		if len(s.attached) == 0 {
+			s.note("No files attached.")
+		}
+		for _, item := range s.attached {
+			s.note("%s  %s", item.ID, item.Name)
+		}
+	default:
+		return false, fmt.Errorf("unknown command %s; type /help", name)
+	}
+	return false, nil
+}
+
+// send sends a message and streams the reply token by token.
+func (s *chatSession) send(ctx context.Context, content string) error {
+	fmt.Fprint(s.out.w, s.style("1;32", "copilot> "))
+	_, err := s.client.SendMessageStreaming(ctx, s.conversationID, content, func(delta string) {
+		fmt.Fprint(s.out.w, delta)
+	})
+	fmt.Fprintln(s.out.w)
+	return err
+}
+
+// attach uploads a file as a context item linked to the conversation.
+func (s *chatSession) attach(ctx context.Context, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	item, err := s.client.CreateContextItem(ctx, &copilot.ContextItemCreate{
+		Type:     copilot.ContextTypeFile,
+		Name:     filepath.Base(path),
+		Content:  string(content),
+		Metadata: map[string]interface{}{"conversation_id": s.conversationID},
+	})
+	if err != nil {
+		return err
+	}
+	s.attached = append(s.attached, item)
+	s.note("Attached %s (%s).", item.Name, item.ID)
+	return nil
+}
+
+func (s *chatSession) newConversation(ctx context.Context, title string) error {
+	conv, err := s.client.CreateConversation(ctx, &copilot.ConversationCreate{Title: title})
+	if err != nil {
+		return err
+	}
+	s.conversationID = conv.ID
+	s.note("Started conversation %s. Type /help for commands.", conv.ID)
+	return nil
+}
+
+// remember records the conversation so the next session resumes it.
+func (s *chatSession) remember() error {
+	state, err := loadState()
+	if err != nil {
+		return err
+	}
+	state.Conversations[s.client.BaseURL()] = s.conversationID
+	return state.save()
+}
+
+// note prints an informational line on stderr.
+func (s *chatSession) note(format string, args ...interface{}) {
+	fmt.Fprintln(s.stderr, s.style("2", fmt.Sprintf(format, args...)))
+}
+
+// style wraps text in an ANSI style when writing to a terminal.
+func (s *chatSession) style(code, text string) string {
+	if !s.color {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// cliState is persisted between CLI sessions.
+type cliState struct {
+	// Conversations maps each base URL to the last conversation used.
+	Conversations map[string]copilot.ConversationID `json:"conversations"`
+}
+
+// statePath returns the CLI state file location.
+func statePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "llm-copilot", "cli-state.json"), nil
+}
+
+func loadState() (*cliState, error) {
+	state := &cliState{Conversations: make(map[string]copilot.ConversationID)}
+	path, err := statePath()
+	if err != nil {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CLI state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("malformed CLI state %s: %w", path, err)
+	}
+	if state.Conversations == nil {
+		state.Conversations = make(map[string]copilot.ConversationID)
+	}
+	return state, nil
+}
+
+func (s *cliState) save() error {
+	path, err := statePath()
+	if err != nil {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to save CLI state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save CLI state: %w", err)
+	}
+	return nil
+}
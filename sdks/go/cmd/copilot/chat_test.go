@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+func TestChat(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	var created int
+	var attached copilot.ContextItemCreate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/conversations":
+			created++
+			json.NewEncoder(w).Encode(copilot.Conversation{ID: copilot.ConversationID(fmt.Sprintf("conv-%d", created))})
+		case "/api/v1/context":
+			json.NewDecoder(r.Body).Decode(&attached)
+			json.NewEncoder(w).Encode(copilot.ContextItem{ID: "ctx-1", Name: attached.Name})
+		case "/api/v1/conversations/conv-1/messages/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"Hi \"}}\n\n")
+			fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"there\"}}\n\n")
+			fmt.Fprint(w, "data: {\"type\":\"message_end\"}\n\n")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	notes := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(notes, []byte("remember this"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	chat := func(input string) (string, string) {
+		var stdout, stderr bytes.Buffer
+		err := run(context.Background(), []string{"-base-url", server.URL, "-api-key", "key", "chat"},
+			strings.NewReader(input), &stdout, &stderr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return stdout.String(), stderr.String()
+	}
+
+	stdout, stderr := chat("/attach " + notes + "\nhello\n/quit\n")
+	if !strings.Contains(stdout, "Hi there") {
+		t.Errorf("expected streamed reply, got %q", stdout)
+	}
+	if attached.Content != "remember this" || attached.Metadata["conversation_id"] != "conv-1" {
+		t.Errorf("expected file attached to conversation, got %+v", attached)
+	}
+	if !strings.Contains(stderr, "Started conversation conv-1") {
+		t.Errorf("expected new conversation, got %q", stderr)
+	}
+
+	_, stderr = chat("/id\n")
+	if created != 1 || !strings.Contains(stderr, "Resuming conversation conv-1") {
+		t.Errorf("expected conversation to be resumed, got %d created, %q", created, stderr)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+func runContextUpload(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("context upload", flag.ContinueOnError)
+	name := flags.String("name", "", "item name (default the file name)")
+	itemType := flags.String("type", string(copilot.ContextTypeFile), "item type: file, text, code or document")
+	direct := flags.Bool("direct", false, "upload straight to storage through a presigned URL, for large files")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(a.stderr, "usage: copilot context upload [-name NAME] [-type TYPE] [-direct] FILE")
+		return errUsage
+	}
+
+	path := flags.Arg(0)
+	if *name == "" {
+		*name = filepath.Base(path)
+	}
+	var item *copilot.ContextItem
+	var err error
+	if *direct {
+		item, err = uploadDirect(ctx, a, path, *name, copilot.ContextType(*itemType))
+	} else {
+		var content []byte
+		if content, err = os.ReadFile(path); err != nil {
+			return err
+		}
+		item, err = a.client.CreateContextItem(ctx, &copilot.ContextItemCreate{
+			Type:    copilot.ContextType(*itemType),
+			Name:    *name,
+			Content: string(content),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	return a.out.print(item, []string{"ID", "NAME", "TYPE"}, [][]string{{item.ID, item.Name, string(item.Type)}})
+}
+
+// uploadDirect streams a file to storage through a presigned URL.
+func uploadDirect(ctx context.Context, a *app, path, name string, itemType copilot.ContextType) (*copilot.ContextItem, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return a.client.UploadContextItem(ctx, &copilot.UploadURLRequest{
+		Type:        itemType,
+		Name:        name,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+	}, f)
+}
+
+func runContextList(ctx context.Context, a *app, args []string) error {
+	items, err := copilot.NewPager(a.client.ListContextItems, nil).All(ctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{item.ID, item.Name, string(item.Type), formatTime(item.CreatedAt)})
+	}
+	return a.out.print(items, []string{"ID", "NAME", "TYPE", "CREATED"}, rows)
+}
+
+// runContextDownload writes a context item's content to stdout, or to a
+// file, resuming a partial one.
+func runContextDownload(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("context download", flag.ContinueOnError)
+	file := flags.String("f", "", "write to FILE, resuming it if partial (default stdout)")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(a.stderr, "usage: copilot context download [-f FILE] ID")
+		return errUsage
+	}
+
+	if *file == "" {
+		return a.client.DownloadContextItem(ctx, flags.Arg(0), a.out.w)
+	}
+	f, err := os.OpenFile(*file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := a.client.DownloadContextItemFrom(ctx, flags.Arg(0), f, info.Size()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+func runConversationsList(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("conversations list", flag.ContinueOnError)
+	limit := flags.Int("limit", 20, "maximum number of conversations")
+	offset := flags.Int("offset", 0, "number of conversations to skip")
+	cursor := flags.String("cursor", "", "cursor from a previous listing")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+
+	page, err := a.client.ListConversations(ctx, &copilot.ListOptions{Limit: *limit, Offset: *offset, Cursor: *cursor})
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(page.Items))
+	for _, conv := range page.Items {
+		rows = append(rows, []string{conv.ID.String(), orDash(conv.Title), strconv.Itoa(conv.MessageCount), formatTime(conv.UpdatedAt)})
+	}
+	if err := a.out.print(page.Items, []string{"ID", "TITLE", "MESSAGES", "UPDATED"}, rows); err != nil {
+		return err
+	}
+	if page.NextCursor != "" && !a.out.json {
+		fmt.Fprintf(a.stderr, "More results: -cursor %s\n", page.NextCursor)
+	}
+	return nil
+}
+
+func runConversationsCreate(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("conversations create", flag.ContinueOnError)
+	title := flags.String("title", "", "conversation title")
+	systemPrompt := flags.String("system-prompt", "", "system prompt")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+
+	conv, err := a.client.CreateConversation(ctx, &copilot.ConversationCreate{
+		Title:        *title,
+		SystemPrompt: *systemPrompt,
+	})
+	if err != nil {
+		return err
+	}
+	return a.out.print(conv, []string{"ID", "TITLE"}, [][]string{{conv.ID.String(), orDash(conv.Title)}})
+}
+
+// runConversationsChat sends the message given as arguments, or reads
+// messages from stdin until EOF when there are none.
+func runConversationsChat(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(a.stderr, "usage: copilot conversations chat ID [MESSAGE]")
+		return errUsage
+	}
+	conversationID := copilot.ConversationID(args[0])
+
+	if len(args) > 1 {
+		return a.chat(ctx, conversationID, strings.Join(args[1:], " "))
+	}
+
+	scanner := bufio.NewScanner(a.stdin)
+	for {
+		fmt.Fprint(a.stderr, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(a.stderr)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := a.chat(ctx, conversationID, line); err != nil {
+			return err
+		}
+	}
+}
+
+// chat sends one message, streaming the reply as it arrives in table mode.
+func (a *app) chat(ctx context.Context, conversationID copilot.ConversationID, content string) error {
+	if a.out.json {
+		msg, err := a.client.SendMessage(ctx, conversationID, content)
+		if err != nil {
+			return err
+		}
+		return a.out.print(msg, nil, nil)
+	}
+
+	if _, err := a.client.SendMessageStreaming(ctx, conversationID, content, func(delta string) {
+		fmt.Fprint(a.out.w, delta)
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintln(a.out.w)
+	return nil
+}
@@ -0,0 +1,177 @@
+// Command copilot is a command-line client for the LLM CoPilot Agent API.
+//
+// Usage:
+//
+//	copilot [global flags] <command> <subcommand> [flags] [args]
+//
+// Configuration is read from the same environment variables as
+// copilot.NewFromEnv (COPILOT_BASE_URL, COPILOT_API_KEY, ...). Tokens from
+// "copilot login" are kept in the OS keyring, or in an encrypted file when
+// COPILOT_TOKEN_PASSPHRASE is set.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+)
+
+// EnvTokenPassphrase selects the encrypted token file over the OS keyring.
+const EnvTokenPassphrase = "COPILOT_TOKEN_PASSPHRASE"
+
+const usage = `Usage: copilot [global flags] <command> [args]
+
+Commands:
+  login                       Log in with a password or the browser
+  logout                      Log out and forget stored tokens
+  whoami                      Show the current user
+  chat                        Chat interactively, resuming the last conversation
+  conversations list          List conversations
+  conversations create        Create a conversation
+  conversations chat ID [MSG] Send a message, or chat interactively
+  workflows create -f FILE    Create a workflow from a JSON definition
+  workflows run ID            Run a workflow
+  workflows watch RUN_ID      Follow a workflow run until it finishes
+  workflows simulate ID       Plan a workflow run without executing it
+  context upload FILE         Upload a file as a context item
+  context download ID         Download a context item's content
+  context list                List context items
+  prompts list                List prompt templates
+  prompts render ID           Render a prompt template with -var name=value
+  api-keys list               List API keys
+  api-keys create -name NAME  Create an API key
+  api-keys revoke ID          Revoke an API key
+
+Global flags:
+`
+
+// errUsage reports invalid command-line usage.
+var errUsage = errors.New("invalid usage")
+
+// app holds the state shared by all commands.
+type app struct {
+	client *copilot.Client
+	out    *printer
+	stdin  io.Reader
+	stderr io.Writer
+}
+
+// command runs a subcommand with its remaining arguments.
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"login":         {"": runLogin},
+	"logout":        {"": runLogout},
+	"whoami":        {"": runWhoami},
+	"chat":          {"": runChat},
+	"conversations": {"list": runConversationsList, "create": runConversationsCreate, "chat": runConversationsChat},
+	"workflows":     {"create": runWorkflowsCreate, "run": runWorkflowsRun, "watch": runWorkflowsWatch, "simulate": runWorkflowsSimulate},
+	"context":       {"upload": runContextUpload, "download": runContextDownload, "list": runContextList},
+	"prompts":       {"list": runPromptsList, "render": runPromptsRender},
+	"api-keys":      {"list": runAPIKeysList, "create": runAPIKeysCreate, "revoke": runAPIKeysRevoke},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "copilot:", err)
+			var apiErr *copilot.CoPilotError
+			if errors.As(err, &apiErr) && apiErr.RequestID != "" {
+				fmt.Fprintln(os.Stderr, "request ID:", apiErr.RequestID)
+			}
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags and dispatches to a command.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("copilot", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	baseURL := flags.String("base-url", "", "API base URL (default $"+copilot.EnvBaseURL+")")
+	apiKey := flags.String("api-key", "", "API key (default $"+copilot.EnvAPIKey+")")
+	output := flags.String("o", "table", "output format: table or json")
+	debug := flags.Bool("debug", false, "write a transcript of API requests to stderr, secrets masked")
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *output)
+		return errUsage
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+	subcommands, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", args[0])
+		flags.Usage()
+		return errUsage
+	}
+	cmd, rest := subcommands[""], args[1:]
+	if cmd == nil {
+		if len(rest) == 0 || subcommands[rest[0]] == nil {
+			fmt.Fprintf(stderr, "usage: copilot %s <subcommand>\n", args[0])
+			return errUsage
+		}
+		cmd, rest = subcommands[rest[0]], rest[1:]
+	}
+
+	opts := []copilot.Option{copilot.WithTokenStore(tokenStore()), copilot.WithUserAgent("copilot-cli/" + copilot.Version)}
+	if *baseURL != "" {
+		opts = append(opts, func(c *copilot.Config) { c.BaseURL = *baseURL })
+	}
+	if *apiKey != "" {
+		opts = append(opts, copilot.WithAPIKey(*apiKey))
+	}
+	if *debug {
+		opts = append(opts, copilot.WithDebugWriter(stderr))
+	}
+	client, err := copilot.NewFromEnv(opts...)
+	if err != nil {
+		return err
+	}
+
+	return cmd(ctx, &app{
+		client: client,
+		out:    &printer{w: stdout, json: *output == "json"},
+		stdin:  stdin,
+		stderr: stderr,
+	}, rest)
+}
+
+// tokenStore returns where login tokens are kept.
+func tokenStore() copilot.TokenStore {
+	if passphrase := os.Getenv(EnvTokenPassphrase); passphrase != "" {
+		if path, err := auth.DefaultTokenPath(); err == nil {
+			return copilot.NewFileTokenStore(path, []byte(passphrase))
+		}
+	}
+	return copilot.NewKeyringTokenStore(auth.DefaultKeyringService, "default")
+}
+
+// parseFlags parses a subcommand's flags, reporting usage errors to stderr.
+func parseFlags(a *app, flags *flag.FlagSet, args []string) error {
+	flags.SetOutput(a.stderr)
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/conversations":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"items": []copilot.Conversation{{ID: "conv-1", Title: "Planning", MessageCount: 4}},
+			})
+		case "/api/v1/prompts/prompt-1":
+			json.NewEncoder(w).Encode(copilot.PromptTemplate{
+				ID:      "prompt-1",
+				Content: "Answer in {{words}} words about {{topic}}.",
+				Version: 3,
+				Variables: []copilot.PromptVariable{
+					{Name: "topic", Required: true},
+					{Name: "words", Type: copilot.PromptVariableNumber, Default: "50"},
+				},
+			})
+		case "/api/v1/context/ctx-1/content":
+			http.ServeContent(w, r, "", time.Time{}, strings.NewReader("full document content"))
+		case "/api/v1/workflows/wf-1/simulate":
+			json.NewEncoder(w).Encode(copilot.WorkflowSimulation{
+				WorkflowID:       "wf-1",
+				Steps:            []copilot.SimulatedStep{{StepID: "draft", Type: copilot.StepTypeLLM, Order: 1, EstimatedTokens: 300, EstimatedCostUSD: 0.003}},
+				EstimatedTokens:  300,
+				EstimatedCostUSD: 0.003,
+				Warnings:         []string{`step "orphan" is unreachable`},
+			})
+		case "/api/v1/workflows/runs/run-1":
+			status := copilot.WorkflowStatusRunning
+			if atomic.AddInt32(&polls, 1) > 1 {
+				status = copilot.WorkflowStatusCompleted
+			}
+			json.NewEncoder(w).Encode(copilot.WorkflowRun{ID: "run-1", WorkflowID: "wf-1", Status: status})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func runCLI(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), args, strings.NewReader(""), &stdout, &stderr)
+	return stdout.String(), stderr.String(), err
+}
+
+func TestConversationsList(t *testing.T) {
+	server := newTestServer(t)
+
+	stdout, _, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key", "conversations", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout, "TITLE") || !strings.Contains(stdout, "Planning") {
+		t.Errorf("expected table output, got:\n%s", stdout)
+	}
+
+	stdout, _, err = runCLI(t, "-base-url", server.URL, "-api-key", "test-key", "-o", "json", "conversations", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var convs []copilot.Conversation
+	if err := json.Unmarshal([]byte(stdout), &convs); err != nil || len(convs) != 1 || convs[0].ID != "conv-1" {
+		t.Errorf("expected JSON output, got %s (%v)", stdout, err)
+	}
+}
+
+func TestDebugFlag(t *testing.T) {
+	server := newTestServer(t)
+
+	_, stderr, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key", "-debug", "conversations", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stderr, "--> GET "+server.URL) || strings.Contains(stderr, "test-key") {
+		t.Errorf("expected a masked transcript, got:\n%s", stderr)
+	}
+}
+
+func TestWorkflowsWatch(t *testing.T) {
+	server := newTestServer(t)
+
+	stdout, stderr, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key",
+		"workflows", "watch", "-interval", "1ms", "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stderr, "running") || !strings.Contains(stdout, "completed") {
+		t.Errorf("expected progress and final status, got stdout:\n%s\nstderr:\n%s", stdout, stderr)
+	}
+}
+
+func TestContextDownload(t *testing.T) {
+	server := newTestServer(t)
+
+	stdout, _, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key", "context", "download", "ctx-1")
+	if err != nil || stdout != "full document content" {
+		t.Fatalf("expected the content, got %q (%v)", stdout, err)
+	}
+
+	// A partial file is resumed.
+	path := filepath.Join(t.TempDir(), "doc.txt")
+	os.WriteFile(path, []byte("full doc"), 0o644)
+	if _, _, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key", "context", "download", "-f", path, "ctx-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "full document content" {
+		t.Errorf("expected the resumed file, got %q", data)
+	}
+}
+
+func TestWorkflowsSimulate(t *testing.T) {
+	server := newTestServer(t)
+
+	stdout, stderr, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key", "workflows", "simulate", "-input", `{"topic":"tides"}`, "wf-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout, "draft") || !strings.Contains(stdout, "$0.0030") {
+		t.Errorf("expected the plan, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "orphan") || !strings.Contains(stderr, "estimated cost: $0.0030 (300 tokens)") {
+		t.Errorf("expected warnings and the total, got:\n%s", stderr)
+	}
+
+	if _, _, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key", "workflows", "simulate", "-max-cost", "0.001", "wf-1"); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("expected the cost limit to fail, got %v", err)
+	}
+}
+
+func TestPromptsRender(t *testing.T) {
+	server := newTestServer(t)
+
+	stdout, _, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key",
+		"prompts", "render", "-var", "topic=tides", "prompt-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Answer in 50 words about tides.\n"; stdout != want {
+		t.Errorf("expected %q, got %q", want, stdout)
+	}
+
+	if _, _, err := runCLI(t, "-base-url", server.URL, "-api-key", "test-key",
+		"prompts", "render", "-var", "topic=tides", "-var", "words=many", "prompt-1"); err == nil {
+		t.Error("expected a non-numeric words to fail")
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"bogus"},
+		{"conversations"},
+		{"-o", "yaml", "whoami"},
+		{"workflows", "watch"},
+	} {
+		if _, _, err := runCLI(t, append([]string{"-api-key", "test-key"}, args...)...); !errors.Is(err, errUsage) {
+			t.Errorf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
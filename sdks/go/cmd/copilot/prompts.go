@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+	"github.com/llm-copilot-agent/sdk-go/copilot/prompts"
+)
+
+func runPromptsList(ctx context.Context, a *app, args []string) error {
+	tmpls, err := copilot.NewPager(a.client.ListPromptTemplates, nil).All(ctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(tmpls))
+	for _, tmpl := range tmpls {
+		rows = append(rows, []string{tmpl.ID, tmpl.Name, strconv.Itoa(tmpl.Version), formatTime(tmpl.UpdatedAt)})
+	}
+	return a.out.print(tmpls, []string{"ID", "NAME", "VERSION", "UPDATED"}, rows)
+}
+
+// varFlags collects repeated -var name=value flags.
+type varFlags map[string]string
+
+func (v varFlags) String() string { return "" }
+
+func (v varFlags) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", s)
+	}
+	v[name] = value
+	return nil
+}
+
+// runPromptsRender renders a prompt template locally, with the same rules
+// the server applies.
+func runPromptsRender(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("prompts render", flag.ContinueOnError)
+	version := flags.Int("version", 0, "template version (default the latest)")
+	vars := varFlags{}
+	flags.Var(vars, "var", "variable as name=value; repeatable")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(a.stderr, "usage: copilot prompts render [-version N] [-var name=value]... ID")
+		return errUsage
+	}
+
+	tmpl, err := a.client.GetPromptTemplate(ctx, flags.Arg(0), *version)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{}, len(vars))
+	for name, s := range vars {
+		values[name] = s
+	}
+	for _, decl := range tmpl.Variables {
+		if s, ok := vars[decl.Name]; ok {
+			v, err := prompts.ParseValue(decl, s)
+			if err != nil {
+				return fmt.Errorf("invalid -var %s: %w", decl.Name, err)
+			}
+			values[decl.Name] = v
+		}
+	}
+
+	text, err := prompts.RenderTemplate(tmpl, values)
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.print(map[string]interface{}{"id": tmpl.ID, "version": tmpl.Version, "text": text}, nil, nil)
+	}
+	_, err = fmt.Fprintln(a.out.w, text)
+	return err
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+func runWorkflowsCreate(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("workflows create", flag.ContinueOnError)
+	file := flags.String("f", "", "JSON workflow definition file, or - for stdin")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		fmt.Fprintln(a.stderr, "usage: copilot workflows create -f FILE")
+		return errUsage
+	}
+
+	var data []byte
+	var err error
+	if *file == "-" {
+		data, err = io.ReadAll(a.stdin)
+	} else {
+		data, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return err
+	}
+
+	var def copilot.WorkflowDefinitionCreate
+	if err := json.Unmarshal(data, &def); err != nil {
+		return fmt.Errorf("invalid workflow definition: %w", err)
+	}
+	wf, err := a.client.CreateWorkflow(ctx, &def)
+	if err != nil {
+		return err
+	}
+	return a.out.print(wf, []string{"ID", "NAME", "VERSION"}, [][]string{{wf.ID.String(), wf.Name, orDash(wf.Version)}})
+}
+
+func runWorkflowsRun(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("workflows run", flag.ContinueOnError)
+	input := flags.String("input", "", "JSON object of input data")
+	watch := flags.Bool("watch", false, "follow the run until it finishes")
+	interval := flags.Duration("interval", 2*time.Second, "polling interval for -watch")
+	timeout := flags.Duration("timeout", 0, "fail the run if it takes longer (default the server's)")
+	maxCost := flags.Float64("max-cost", 0, "fail the run if it costs more in USD (default no limit)")
+	env := flags.String("env", "", "environment whose variables the run binds")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(a.stderr, "usage: copilot workflows run [-input JSON] [-env NAME] [-timeout D] [-max-cost USD] [-watch] WORKFLOW_ID")
+		return errUsage
+	}
+
+	req := &copilot.WorkflowRunCreate{
+		WorkflowID:     copilot.WorkflowID(flags.Arg(0)),
+		TimeoutSeconds: int((*timeout + time.Second - 1) / time.Second),
+		MaxCostUSD:     *maxCost,
+		Environment:    *env,
+	}
+	if *input != "" {
+		if err := json.Unmarshal([]byte(*input), &req.InputData); err != nil {
+			return fmt.Errorf("invalid -input: %w", err)
+		}
+	}
+	run, err := a.client.RunWorkflow(ctx, req)
+	if err != nil {
+		return err
+	}
+	if *watch {
+		return a.watchRun(ctx, run.ID, *interval)
+	}
+	return a.printRun(run)
+}
+
+// runWorkflowsSimulate prints the plan of a run without executing it,
+// failing if the estimated cost exceeds -max-cost.
+func runWorkflowsSimulate(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("workflows simulate", flag.ContinueOnError)
+	input := flags.String("input", "", "JSON object of input data")
+	maxCost := flags.Float64("max-cost", 0, "fail if the estimated cost in USD exceeds this (default no limit)")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(a.stderr, "usage: copilot workflows simulate [-input JSON] [-max-cost USD] WORKFLOW_ID")
+		return errUsage
+	}
+
+	var inputData map[string]interface{}
+	if *input != "" {
+		if err := json.Unmarshal([]byte(*input), &inputData); err != nil {
+			return fmt.Errorf("invalid -input: %w", err)
+		}
+	}
+	sim, err := a.client.SimulateWorkflow(ctx, copilot.WorkflowID(flags.Arg(0)), inputData)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(sim.Steps))
+	for _, step := range sim.Steps {
+		rows = append(rows, []string{strconv.Itoa(step.Order), step.StepID, string(step.Type), strconv.FormatInt(step.EstimatedTokens, 10), fmt.Sprintf("$%.4f", step.EstimatedCostUSD)})
+	}
+	if err := a.out.print(sim, []string{"ORDER", "STEP", "TYPE", "TOKENS", "COST"}, rows); err != nil {
+		return err
+	}
+	for _, warning := range sim.Warnings {
+		fmt.Fprintf(a.stderr, "warning: %s\n", warning)
+	}
+	fmt.Fprintf(a.stderr, "estimated cost: $%.4f (%d tokens)\n", sim.EstimatedCostUSD, sim.EstimatedTokens)
+	if *maxCost > 0 && sim.EstimatedCostUSD > *maxCost {
+		return fmt.Errorf("estimated cost $%.4f exceeds -max-cost $%.4f", sim.EstimatedCostUSD, *maxCost)
+	}
+	return nil
+}
+
+func runWorkflowsWatch(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("workflows watch", flag.ContinueOnError)
+	interval := flags.Duration("interval", 2*time.Second, "polling interval")
+	if err := parseFlags(a, flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(a.stderr, "usage: copilot workflows watch [-interval D] RUN_ID")
+		return errUsage
+	}
+	return a.watchRun(ctx, copilot.RunID(flags.Arg(0)), *interval)
+}
+
+// watchRun polls a run, reporting status and step changes on stderr, and
+// prints the final run. It fails if the run does not complete.
+func (a *app) watchRun(ctx context.Context, runID copilot.RunID, interval time.Duration) error {
+	var last string
+	for {
+		run, err := a.client.GetWorkflowRun(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if state := fmt.Sprintf("%s %s", run.Status, run.CurrentStep); state != last {
+			fmt.Fprintf(a.stderr, "%s  %-9s %s\n", time.Now().Format("15:04:05"), run.Status, run.CurrentStep)
+			last = state
+		}
+
+		switch run.Status {
+		case copilot.WorkflowStatusCompleted:
+			return a.printRun(run)
+		case copilot.WorkflowStatusFailed, copilot.WorkflowStatusCancelled:
+			if err := a.printRun(run); err != nil {
+				return err
+			}
+			return fmt.Errorf("run %s %s: %s", run.ID, run.Status, orDash(run.Error))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (a *app) printRun(run *copilot.WorkflowRun) error {
+	return a.out.print(run,
+		[]string{"ID", "WORKFLOW", "STATUS", "STEP", "STARTED"},
+		[][]string{{run.ID.String(), run.WorkflowID.String(), string(run.Status), orDash(run.CurrentStep), formatTime(run.StartedAt)}},
+	)
+}
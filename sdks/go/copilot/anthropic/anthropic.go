@@ -0,0 +1,297 @@
+// Package anthropic converts between Anthropic Messages API payloads and
+// CoPilot messages, for applications bridging the two.
+//
+// Anthropic content blocks map onto models.ContentPart: text, image,
+// tool_use and tool_result blocks each become a part of the same type.
+// System prompts, which Anthropic carries outside the message list, become
+// system-role messages.
+package anthropic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// Message roles.
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Content block types.
+const (
+	BlockText       = "text"
+	BlockImage      = "image"
+	BlockToolUse    = "tool_use"
+	BlockToolResult = "tool_result"
+)
+
+// Image source types.
+const (
+	SourceBase64 = "base64"
+	SourceURL    = "url"
+)
+
+// MessageParam is a message of a Messages API request.
+type MessageParam struct {
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+}
+
+// UnmarshalJSON accepts content given as a plain string as well as a list
+// of blocks.
+func (m *MessageParam) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	content, err := unmarshalBlocks(raw.Content)
+	if err != nil {
+		return err
+	}
+	*m = MessageParam{Role: raw.Role, Content: content}
+	return nil
+}
+
+// ContentBlock is a content block of a message.
+type ContentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+
+	// Source is set on image blocks.
+	Source *ImageSource `json:"source,omitempty"`
+
+	// ID, Name and Input are set on tool_use blocks.
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+
+	// ToolUseID, Content and IsError are set on tool_result blocks.
+	ToolUseID string         `json:"tool_use_id,omitempty"`
+	Content   []ContentBlock `json:"content,omitempty"`
+	IsError   bool           `json:"is_error,omitempty"`
+}
+
+// UnmarshalJSON accepts tool result content given as a plain string as
+// well as a list of blocks.
+func (b *ContentBlock) UnmarshalJSON(data []byte) error {
+	type plain ContentBlock
+	var raw struct {
+		plain
+		Content json.RawMessage `json:"content,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	content, err := unmarshalBlocks(raw.Content)
+	if err != nil {
+		return err
+	}
+	*b = ContentBlock(raw.plain)
+	b.Content = content
+	return nil
+}
+
+// ImageSource is the source of an image block.
+type ImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// unmarshalBlocks decodes content that is either a string or a list of
+// blocks.
+func unmarshalBlocks(data json.RawMessage) ([]ContentBlock, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return []ContentBlock{{Type: BlockText, Text: text}}, nil
+	}
+	var blocks []ContentBlock
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// ToMessages converts an Anthropic system prompt and message list into
+// CoPilot messages. A non-empty system prompt becomes a leading system
+// message.
+func ToMessages(system string, params []MessageParam) ([]models.Message, error) {
+	var msgs []models.Message
+	if system != "" {
+		msgs = append(msgs, models.Message{Role: models.RoleSystem, Content: system})
+	}
+	for _, param := range params {
+		msg, err := ToMessage(param)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// ToMessage converts an Anthropic message into a CoPilot message. Content
+// holds the text of the message; Parts is set when it has more than a
+// single text block.
+func ToMessage(param MessageParam) (models.Message, error) {
+	var role models.MessageRole
+	switch param.Role {
+	case RoleUser:
+		role = models.RoleUser
+	case RoleAssistant:
+		role = models.RoleAssistant
+	default:
+		return models.Message{}, fmt.Errorf("anthropic: unsupported role %q", param.Role)
+	}
+
+	parts, err := ToContentParts(param.Content)
+	if err != nil {
+		return models.Message{}, err
+	}
+	msg := models.Message{Role: role, Content: models.TextParts(parts)}
+	if len(parts) > 1 || len(parts) == 1 && parts[0].Type != models.ContentPartText {
+		msg.Parts = parts
+	}
+	return msg, nil
+}
+
+// ToContentParts converts content blocks into content parts. Tool result
+// content is flattened to its text.
+func ToContentParts(blocks []ContentBlock) ([]models.ContentPart, error) {
+	parts := make([]models.ContentPart, 0, len(blocks))
+	for _, block := range blocks {
+		switch block.Type {
+		case BlockText:
+			parts = append(parts, models.ContentPart{Type: models.ContentPartText, Text: block.Text})
+		case BlockImage:
+			if block.Source == nil {
+				return nil, fmt.Errorf("anthropic: image block without source")
+			}
+			parts = append(parts, models.ContentPart{
+				Type:      models.ContentPartImage,
+				MediaType: block.Source.MediaType,
+				Data:      block.Source.Data,
+				URL:       block.Source.URL,
+			})
+		case BlockToolUse:
+			parts = append(parts, models.ContentPart{
+				Type:      models.ContentPartToolUse,
+				ToolUseID: block.ID,
+				ToolName:  block.Name,
+				Input:     block.Input,
+			})
+		case BlockToolResult:
+			parts = append(parts, models.ContentPart{
+				Type:      models.ContentPartToolResult,
+				ToolUseID: block.ToolUseID,
+				Text:      blockText(block.Content),
+				IsError:   block.IsError,
+			})
+		default:
+			return nil, fmt.Errorf("anthropic: unsupported content block type %q", block.Type)
+		}
+	}
+	return parts, nil
+}
+
+// FromMessages converts CoPilot messages into an Anthropic system prompt
+// and message list. System messages are joined into the system prompt, and
+// consecutive messages with the same role are merged, since the Messages
+// API requires roles to alternate.
+func FromMessages(msgs []models.Message) (string, []MessageParam, error) {
+	var system []string
+	var params []MessageParam
+	for _, msg := range msgs {
+		if msg.Role == models.RoleSystem {
+			system = append(system, msg.Content)
+			continue
+		}
+		param, err := FromMessage(msg)
+		if err != nil {
+			return "", nil, err
+		}
+		if n := len(params); n > 0 && params[n-1].Role == param.Role {
+			params[n-1].Content = append(params[n-1].Content, param.Content...)
+			continue
+		}
+		params = append(params, param)
+	}
+	return strings.Join(system, "\n\n"), params, nil
+}
+
+// FromMessage converts a user or assistant CoPilot message into an
+// Anthropic message. Messages without parts become a single text block.
+func FromMessage(msg models.Message) (MessageParam, error) {
+	var role string
+	switch msg.Role {
+	case models.RoleUser:
+		role = RoleUser
+	case models.RoleAssistant:
+		role = RoleAssistant
+	default:
+		return MessageParam{}, fmt.Errorf("anthropic: unsupported role %q", msg.Role)
+	}
+
+	if len(msg.Parts) == 0 {
+		return MessageParam{Role: role, Content: []ContentBlock{{Type: BlockText, Text: msg.Content}}}, nil
+	}
+	blocks, err := FromContentParts(msg.Parts)
+	if err != nil {
+		return MessageParam{}, err
+	}
+	return MessageParam{Role: role, Content: blocks}, nil
+}
+
+// FromContentParts converts content parts into content blocks.
+func FromContentParts(parts []models.ContentPart) ([]ContentBlock, error) {
+	blocks := make([]ContentBlock, 0, len(parts))
+	for _, part := range parts {
+		switch part.Type {
+		case models.ContentPartText:
+			blocks = append(blocks, ContentBlock{Type: BlockText, Text: part.Text})
+		case models.ContentPartImage:
+			source := &ImageSource{Type: SourceBase64, MediaType: part.MediaType, Data: part.Data}
+			if part.URL != "" {
+				source = &ImageSource{Type: SourceURL, URL: part.URL}
+			}
+			blocks = append(blocks, ContentBlock{Type: BlockImage, Source: source})
+		case models.ContentPartToolUse:
+			input := part.Input
+			if len(input) == 0 {
+				input = json.RawMessage("{}")
+			}
+			blocks = append(blocks, ContentBlock{Type: BlockToolUse, ID: part.ToolUseID, Name: part.ToolName, Input: input})
+		case models.ContentPartToolResult:
+			block := ContentBlock{Type: BlockToolResult, ToolUseID: part.ToolUseID, IsError: part.IsError}
+			if part.Text != "" {
+				block.Content = []ContentBlock{{Type: BlockText, Text: part.Text}}
+			}
+			blocks = append(blocks, block)
+		default:
+			return nil, fmt.Errorf("anthropic: unsupported content part type %q", part.Type)
+		}
+	}
+	return blocks, nil
+}
+
+// blockText returns the concatenated text of the text blocks.
+func blockText(blocks []ContentBlock) string {
+	var b strings.Builder
+	for _, block := range blocks {
+		if block.Type == BlockText {
+			b.WriteString(block.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+const payload = `[
+	{"role": "user", "content": "What's the weather in Paris?"},
+	{"role": "assistant", "content": [
+		{"type": "text", "text": "Let me check."},
+		{"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {"city": "Paris"}}
+	]},
+	{"role": "user", "content": [
+		{"type": "tool_result", "tool_use_id": "toolu_1", "content": "18C and sunny"}
+	]}
+]`
+
+func TestToMessages(t *testing.T) {
+	var params []MessageParam
+	if err := json.Unmarshal([]byte(payload), &params); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	msgs, err := ToMessages("Be helpful.", params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 4 || msgs[0].Role != models.RoleSystem || msgs[0].Content != "Be helpful." {
+		t.Fatalf("unexpected messages %+v", msgs)
+	}
+	if msgs[1].Content != "What's the weather in Paris?" || msgs[1].Parts != nil {
+		t.Errorf("expected plain text message, got %+v", msgs[1])
+	}
+
+	assistant := msgs[2]
+	if assistant.Content != "Let me check." || len(assistant.Parts) != 2 {
+		t.Fatalf("unexpected assistant message %+v", assistant)
+	}
+	toolUse := assistant.Parts[1]
+	if toolUse.Type != models.ContentPartToolUse || toolUse.ToolUseID != "toolu_1" || toolUse.ToolName != "get_weather" || string(toolUse.Input) != `{"city": "Paris"}` {
+		t.Errorf("unexpected tool use %+v", toolUse)
+	}
+
+	result := msgs[3].Parts[0]
+	if result.Type != models.ContentPartToolResult || result.ToolUseID != "toolu_1" || result.Text != "18C and sunny" {
+		t.Errorf("unexpected tool result %+v", result)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var params []MessageParam
+	if err := json.Unmarshal([]byte(payload), &params); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	msgs, err := ToMessages("Be helpful.", params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	system, back, err := FromMessages(msgs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if system != "Be helpful." {
+		t.Errorf("unexpected system prompt %q", system)
+	}
+	again, err := ToMessages(system, back)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(msgs, again) {
+		t.Errorf("round trip changed messages:\n%+v\n%+v", msgs, again)
+	}
+}
+
+func TestFromMessagesMergesRoles(t *testing.T) {
+	system, params, err := FromMessages([]models.Message{
+		{Role: models.RoleSystem, Content: "one"},
+		{Role: models.RoleUser, Content: "a"},
+		{Role: models.RoleUser, Content: "b", Parts: []models.ContentPart{
+			{Type: models.ContentPartImage, MediaType: "image/png", Data: "aGk="},
+		}},
+		{Role: models.RoleSystem, Content: "two"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if system != "one\n\ntwo" || len(params) != 1 || len(params[0].Content) != 2 {
+		t.Fatalf("unexpected conversion %q %+v", system, params)
+	}
+	if image := params[0].Content[1]; image.Type != BlockImage || image.Source.Type != SourceBase64 || image.Source.MediaType != "image/png" {
+		t.Errorf("unexpected image block %+v", image)
+	}
+
+	if _, err := FromMessage(models.Message{Role: "tool"}); err == nil {
+		t.Error("expected error for unsupported role")
+	}
+}
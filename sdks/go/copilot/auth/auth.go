@@ -0,0 +1,73 @@
+// Package auth provides credential storage for the LLM CoPilot SDK.
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// Token holds the credentials issued by a login or token refresh.
+type Token struct {
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token,omitempty"`
+	TokenType        string    `json:"token_type,omitempty"`
+	ExpiresAt        time.Time `json:"expires_at,omitempty"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at,omitempty"`
+	// IDToken is the OpenID Connect ID token, when the token endpoint
+	// issued one.
+	IDToken string `json:"id_token,omitempty"`
+}
+
+// TokenStore persists tokens between requests. Implementations must be safe
+// for concurrent use.
+type TokenStore interface {
+	// Get returns the stored token, or nil if none is stored.
+	Get() (*Token, error)
+	// Set replaces the stored token.
+	Set(token *Token) error
+	// Delete removes the stored token.
+	Delete() error
+}
+
+// MemoryStore is a TokenStore that keeps the token in memory.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	token *Token
+}
+
+// NewMemoryStore creates an in-memory token store holding token, which may be nil.
+func NewMemoryStore(token *Token) *MemoryStore {
+	return &MemoryStore{token: copyToken(token)}
+}
+
+// Get returns a copy of the stored token.
+func (m *MemoryStore) Get() (*Token, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return copyToken(m.token), nil
+}
+
+// Set replaces the stored token.
+func (m *MemoryStore) Set(token *Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = copyToken(token)
+	return nil
+}
+
+// Delete removes the stored token.
+func (m *MemoryStore) Delete() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = nil
+	return nil
+}
+
+// copyToken returns a copy of token so callers cannot mutate stored state.
+func copyToken(token *Token) *Token {
+	if token == nil {
+		return nil
+	}
+	t := *token
+	return &t
+}
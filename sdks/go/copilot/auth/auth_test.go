@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore(nil)
+
+	if token, err := store.Get(); err != nil || token != nil {
+		t.Fatalf("expected empty store, got %+v, %v", token, err)
+	}
+
+	if err := store.Set(&Token{AccessToken: "access", RefreshToken: "refresh"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token, _ := store.Get()
+	token.AccessToken = "mutated"
+	if stored, _ := store.Get(); stored.AccessToken != "access" {
+		t.Errorf("expected store to be isolated from callers, got %s", stored.AccessToken)
+	}
+
+	if err := store.Delete(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, _ := store.Get(); token != nil {
+		t.Errorf("expected token to be deleted, got %+v", token)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	pbkdf2Iterations = 1000
+	defer func() { pbkdf2Iterations = 600000 }()
+
+	path := filepath.Join(t.TempDir(), "nested", "token.json")
+	store := NewFileStore(path, []byte("passphrase"))
+
+	if token, err := store.Get(); err != nil || token != nil {
+		t.Fatalf("expected missing file to read as empty, got %+v, %v", token, err)
+	}
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	if err := store.Set(&Token{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: expires}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected token file: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	// A fresh store with the same passphrase survives a restart.
+	token, err := NewFileStore(path, []byte("passphrase")).Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.RefreshToken != "refresh" || !token.ExpiresAt.Equal(expires) {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	if _, err := NewFileStore(path, []byte("wrong")).Get(); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("expected ErrDecrypt, got %v", err)
+	}
+
+	if err := store.Delete(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected token file to be removed")
+	}
+}
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// Test vector from RFC 7914, section 11.
+	key := pbkdf2SHA256([]byte("passwd"), []byte("salt"), 1, 64)
+	expected := "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+		"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"
+	if hex.EncodeToString(key) != expected {
+		t.Errorf("unexpected key %x", key)
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	exp := time.Now().Add(30 * time.Second).Unix()
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user-1","exp":%d}`, exp)))
+	jwt := "eyJhbGciOiJIUzI1NiJ9." + payload + ".signature"
+
+	claims, err := ParseClaims(jwt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.ExpiresAt != exp {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	token := &Token{AccessToken: jwt}
+	if token.Expiry().Unix() != exp {
+		t.Errorf("expected expiry from exp claim, got %v", token.Expiry())
+	}
+	if !token.ExpiresWithin(time.Minute) {
+		t.Error("expected token to expire within a minute")
+	}
+	if token.ExpiresWithin(10 * time.Second) {
+		t.Error("expected token not to expire within 10 seconds")
+	}
+
+	opaque := &Token{AccessToken: "opaque-token"}
+	if !opaque.Expiry().IsZero() || opaque.ExpiresWithin(time.Hour) {
+		t.Error("expected opaque token to have unknown expiry")
+	}
+	if _, err := ParseClaims("opaque-token"); !errors.Is(err, ErrNotJWT) {
+		t.Errorf("expected ErrNotJWT, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNoCredentials is returned by a CredentialsProvider that has no
+// credentials to offer, letting a chain fall through to the next provider.
+var ErrNoCredentials = errors.New("auth: no credentials found")
+
+// Environment variables read by EnvProvider.
+const (
+	EnvAPIKey          = "COPILOT_API_KEY"
+	EnvAccessToken     = "COPILOT_ACCESS_TOKEN"
+	EnvCredentialsFile = "COPILOT_CREDENTIALS_FILE"
+)
+
+// Credentials are the secrets used to authenticate requests. Exactly one of
+// APIKey and AccessToken is normally set.
+type Credentials struct {
+	APIKey      string `json:"api_key,omitempty"`
+	AccessToken string `json:"access_token,omitempty"`
+	// Source names the provider that supplied the credentials.
+	Source string `json:"-"`
+}
+
+// empty reports whether no secret is set.
+func (c *Credentials) empty() bool {
+	return c == nil || (c.APIKey == "" && c.AccessToken == "")
+}
+
+// CredentialsProvider supplies credentials from some source.
+type CredentialsProvider interface {
+	// Credentials returns the credentials, or ErrNoCredentials if the
+	// source has none.
+	Credentials(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Credentials calls f(ctx).
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// StaticProvider returns fixed credentials, typically from explicit configuration.
+type StaticProvider struct {
+	APIKey      string
+	AccessToken string
+}
+
+// Credentials returns the configured credentials.
+func (p StaticProvider) Credentials(ctx context.Context) (*Credentials, error) {
+	creds := &Credentials{APIKey: p.APIKey, AccessToken: p.AccessToken, Source: "static"}
+	if creds.empty() {
+		return nil, ErrNoCredentials
+	}
+	return creds, nil
+}
+
+// EnvProvider reads COPILOT_API_KEY or COPILOT_ACCESS_TOKEN.
+type EnvProvider struct{}
+
+// Credentials returns the credentials set in the environment.
+func (EnvProvider) Credentials(ctx context.Context) (*Credentials, error) {
+	creds := &Credentials{
+		APIKey:      os.Getenv(EnvAPIKey),
+		AccessToken: os.Getenv(EnvAccessToken),
+		Source:      "env",
+	}
+	if creds.empty() {
+		return nil, ErrNoCredentials
+	}
+	return creds, nil
+}
+
+// FileProvider reads credentials from a JSON file of the form
+// {"api_key": "..."} or {"access_token": "..."}.
+type FileProvider struct {
+	// Path is the credentials file. Defaults to COPILOT_CREDENTIALS_FILE,
+	// then DefaultCredentialsPath.
+	Path string
+}
+
+// DefaultCredentialsPath returns the default credentials file location in
+// the user's configuration directory.
+func DefaultCredentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "llm-copilot", "credentials.json"), nil
+}
+
+// Credentials returns the credentials stored in the file.
+func (p FileProvider) Credentials(ctx context.Context) (*Credentials, error) {
+	path := p.Path
+	if path == "" {
+		path = os.Getenv(EnvCredentialsFile)
+	}
+	if path == "" {
+		var err error
+		if path, err = DefaultCredentialsPath(); err != nil {
+			return nil, ErrNoCredentials
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read credentials file: %w", err)
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("auth: malformed credentials file %s: %w", path, err)
+	}
+	if creds.empty() {
+		return nil, ErrNoCredentials
+	}
+	creds.Source = "file:" + path
+	return &creds, nil
+}
+
+// TokenStoreProvider returns the access token held in a TokenStore.
+type TokenStoreProvider struct {
+	Store TokenStore
+}
+
+// Credentials returns the stored access token.
+func (p TokenStoreProvider) Credentials(ctx context.Context) (*Credentials, error) {
+	if p.Store == nil {
+		return nil, ErrNoCredentials
+	}
+	token, err := p.Store.Get()
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.AccessToken == "" {
+		return nil, ErrNoCredentials
+	}
+	return &Credentials{AccessToken: token.AccessToken, Source: "token-store"}, nil
+}
+
+// ChainProvider tries each provider in order and returns the first
+// credentials found. Errors other than ErrNoCredentials stop the chain.
+type ChainProvider []CredentialsProvider
+
+// Credentials returns the credentials of the first provider that has any.
+func (c ChainProvider) Credentials(ctx context.Context) (*Credentials, error) {
+	for _, p := range c {
+		creds, err := p.Credentials(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return creds, nil
+	}
+	return nil, ErrNoCredentials
+}
+
+// DefaultChain returns the standard provider chain: explicit credentials,
+// environment variables, the credentials file, and finally the token store.
+// explicit and store may be nil.
+func DefaultChain(explicit *Credentials, store TokenStore) ChainProvider {
+	var chain ChainProvider
+	if explicit != nil {
+		chain = append(chain, StaticProvider{APIKey: explicit.APIKey, AccessToken: explicit.AccessToken})
+	}
+	return append(chain, EnvProvider{}, FileProvider{}, TokenStoreProvider{Store: store})
+}
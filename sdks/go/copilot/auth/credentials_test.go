@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChainProvider(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvAccessToken, "")
+	t.Setenv(EnvCredentialsFile, path)
+
+	store := NewMemoryStore(&Token{AccessToken: "stored"})
+	chain := DefaultChain(nil, store)
+
+	creds, err := chain.Credentials(ctx)
+	if err != nil || creds.AccessToken != "stored" || creds.Source != "token-store" {
+		t.Fatalf("expected token store credentials, got %+v, %v", creds, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"api_key": "from-file"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if creds, _ := chain.Credentials(ctx); creds.APIKey != "from-file" {
+		t.Errorf("expected file credentials, got %+v", creds)
+	}
+
+	t.Setenv(EnvAPIKey, "from-env")
+	if creds, _ := chain.Credentials(ctx); creds.APIKey != "from-env" {
+		t.Errorf("expected env credentials, got %+v", creds)
+	}
+
+	chain = DefaultChain(&Credentials{APIKey: "explicit"}, store)
+	if creds, _ := chain.Credentials(ctx); creds.APIKey != "explicit" {
+		t.Errorf("expected explicit credentials, got %+v", creds)
+	}
+}
+
+func TestChainProviderErrors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := (ChainProvider{}).Credentials(ctx); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("expected ErrNoCredentials, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	chain := ChainProvider{FileProvider{Path: path}, StaticProvider{APIKey: "unreached"}}
+	if _, err := chain.Credentials(ctx); err == nil || errors.Is(err, ErrNoCredentials) {
+		t.Errorf("expected malformed file to stop the chain, got %v", err)
+	}
+}
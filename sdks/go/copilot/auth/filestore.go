@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrDecrypt is returned when a token file cannot be decrypted, usually
+// because the passphrase is wrong.
+var ErrDecrypt = errors.New("auth: failed to decrypt token file")
+
+const (
+	fileStoreVersion = 1
+	saltSize         = 16
+	keySize          = 32
+)
+
+// pbkdf2Iterations is the PBKDF2-SHA256 work factor for file store keys.
+var pbkdf2Iterations = 600000
+
+// encryptedFile is the on-disk format of a FileStore.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// FileStore is a TokenStore that keeps the token in a file encrypted with
+// AES-256-GCM under a key derived from a passphrase. The file is only
+// readable by the current user.
+type FileStore struct {
+	path       string
+	passphrase []byte
+
+	mu   sync.Mutex
+	salt []byte
+	key  []byte
+}
+
+// NewFileStore creates a token store backed by the encrypted file at path.
+func NewFileStore(path string, passphrase []byte) *FileStore {
+	return &FileStore{
+		path:       path,
+		passphrase: append([]byte(nil), passphrase...),
+	}
+}
+
+// DefaultTokenPath returns the default token file location in the user's
+// configuration directory.
+func DefaultTokenPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "llm-copilot", "token.json"), nil
+}
+
+// Get decrypts and returns the stored token, or nil if the file does not exist.
+func (f *FileStore) Get() (*Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read token file: %w", err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("auth: malformed token file: %w", err)
+	}
+	if file.Version != fileStoreVersion {
+		return nil, fmt.Errorf("auth: unsupported token file version %d", file.Version)
+	}
+
+	gcm, err := f.cipher(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	var token Token
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return nil, fmt.Errorf("auth: malformed token: %w", err)
+	}
+	return &token, nil
+}
+
+// Set encrypts and writes the token, replacing the file atomically.
+func (f *FileStore) Set(token *Token) error {
+	if token == nil {
+		return f.Delete()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("auth: failed to encode token: %w", err)
+	}
+
+	salt := f.salt
+	if salt == nil {
+		salt = make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("auth: failed to generate salt: %w", err)
+		}
+	}
+	gcm, err := f.cipher(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("auth: failed to generate nonce: %w", err)
+	}
+
+	data, err := json.Marshal(encryptedFile{
+		Version:    fileStoreVersion,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("auth: failed to encode token file: %w", err)
+	}
+	return writeFileAtomic(f.path, data)
+}
+
+// Delete removes the token file.
+func (f *FileStore) Delete() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("auth: failed to delete token file: %w", err)
+	}
+	return nil
+}
+
+// cipher returns the AEAD for salt, reusing the derived key when the salt
+// is unchanged since key derivation is deliberately slow.
+func (f *FileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	if f.key == nil || !bytes.Equal(f.salt, salt) {
+		f.key = pbkdf2SHA256(f.passphrase, salt, pbkdf2Iterations, keySize)
+		f.salt = append([]byte(nil), salt...)
+	}
+	block, err := aes.NewCipher(f.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it over path.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("auth: failed to create token directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("auth: failed to create token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("auth: failed to restrict token file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("auth: failed to write token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("auth: failed to write token file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("auth: failed to replace token file: %w", err)
+	}
+	return nil
+}
+
+// pbkdf2SHA256 derives a key from password and salt as specified in RFC 8018.
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	key := make([]byte, 0, blocks*hashLen)
+	var counter [4]byte
+	u := make([]byte, hashLen)
+	t := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+		u = prf.Sum(u[:0])
+		copy(t, u)
+
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
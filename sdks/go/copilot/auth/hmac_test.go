@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHMACSigner(t *testing.T) {
+	signer := &HMACSigner{
+		KeyID:  "key-1",
+		Secret: []byte("secret"),
+		Now:    func() time.Time { return time.Unix(1700000000, 0) },
+	}
+	body := []byte(`{"content":"hi"}`)
+	req, _ := http.NewRequest(http.MethodPost, "https://api.example.com/api/v1/conversations/c1/messages?x=1", nil)
+	if err := signer.Sign(req, body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bodyHash := sha256.Sum256(body)
+	if got := req.Header.Get(ContentSHA256Header); got != hex.EncodeToString(bodyHash[:]) {
+		t.Errorf("unexpected body hash %s", got)
+	}
+	if req.Header.Get(KeyIDHeader) != "key-1" || req.Header.Get(TimestampHeader) != "1700000000" {
+		t.Errorf("unexpected headers: %v", req.Header)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("v1\nPOST\n/api/v1/conversations/c1/messages\nx=1\n1700000000\n" + hex.EncodeToString(bodyHash[:])))
+	if want := "v1=" + hex.EncodeToString(mac.Sum(nil)); req.Header.Get(SignatureHeader) != want {
+		t.Errorf("expected signature %s, got %s", want, req.Header.Get(SignatureHeader))
+	}
+
+	if err := (&HMACSigner{KeyID: "key-1"}).Sign(req, nil); err == nil {
+		t.Error("expected error for missing secret")
+	}
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNotJWT is returned when a token is not a JSON Web Token.
+var ErrNotJWT = errors.New("auth: token is not a JWT")
+
+// Claims holds the registered JWT claims used by the SDK.
+type Claims struct {
+	Subject   string `json:"sub,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+}
+
+// ParseClaims decodes the claims of a JWT without verifying its signature.
+// Signature verification is the server's job; the SDK only inspects the
+// claims to schedule refreshes.
+func ParseClaims(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrNotJWT
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotJWT, err)
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotJWT, err)
+	}
+	return &claims, nil
+}
+
+// Expiry returns when the access token expires: ExpiresAt if known,
+// otherwise the JWT exp claim. It returns the zero time if neither is
+// available.
+func (t *Token) Expiry() time.Time {
+	if !t.ExpiresAt.IsZero() {
+		return t.ExpiresAt
+	}
+	claims, err := ParseClaims(t.AccessToken)
+	if err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.ExpiresAt, 0)
+}
+
+// ExpiresWithin reports whether the access token expires within d. Tokens
+// with an unknown expiry never do.
+func (t *Token) ExpiresWithin(d time.Duration) bool {
+	expiry := t.Expiry()
+	return !expiry.IsZero() && time.Until(expiry) <= d
+}
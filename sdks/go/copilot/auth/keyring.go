@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrKeyringUnavailable is returned when the OS keyring cannot be used on
+// this platform or its helper is not installed.
+var ErrKeyringUnavailable = errors.New("auth: OS keyring unavailable")
+
+// errKeyringNotFound is returned by the platform backends when no secret
+// is stored for the service and account.
+var errKeyringNotFound = errors.New("auth: secret not found in keyring")
+
+// ErrSecretTooLarge is returned when a token is larger than the OS keyring
+// can store, as with long JWTs in the Windows Credential Manager. A
+// FileStore has no such limit.
+var ErrSecretTooLarge = errors.New("auth: secret too large for OS keyring")
+
+// DefaultKeyringService is the service name used by NewKeyringStore when
+// none is given.
+const DefaultKeyringService = "llm-copilot"
+
+// KeyringStore is a TokenStore backed by the OS keyring: the macOS
+// Keychain, the Windows Credential Manager, or the freedesktop Secret
+// Service (via secret-tool) on Linux and BSD.
+type KeyringStore struct {
+	service string
+	account string
+
+	mu sync.Mutex
+}
+
+// NewKeyringStore creates a keyring-backed token store for the given
+// service and account names.
+func NewKeyringStore(service, account string) *KeyringStore {
+	if service == "" {
+		service = DefaultKeyringService
+	}
+	return &KeyringStore{service: service, account: account}
+}
+
+// Get returns the stored token, or nil if the keyring has no entry.
+func (k *KeyringStore) Get() (*Token, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	secret, err := keyringGet(k.service, k.account)
+	if errors.Is(err, errKeyringNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var token Token
+	if err := json.Unmarshal([]byte(secret), &token); err != nil {
+		return nil, fmt.Errorf("auth: malformed token in keyring: %w", err)
+	}
+	return &token, nil
+}
+
+// Set stores the token in the keyring.
+func (k *KeyringStore) Set(token *Token) error {
+	if token == nil {
+		return k.Delete()
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("auth: failed to encode token: %w", err)
+	}
+	return keyringSet(k.service, k.account, string(data))
+}
+
+// Delete removes the token from the keyring.
+func (k *KeyringStore) Delete() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := keyringDelete(k.service, k.account); err != nil && !errors.Is(err, errKeyringNotFound) {
+		return err
+	}
+	return nil
+}
+
+// checkSecretSize returns ErrSecretTooLarge if secret is longer than limit
+// bytes.
+func checkSecretSize(secret string, limit int) error {
+	if len(secret) > limit {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrSecretTooLarge, len(secret), limit)
+	}
+	return nil
+}
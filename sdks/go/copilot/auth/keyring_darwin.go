@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityNotFound is the exit status of security(1) for a missing item.
+const securityNotFound = 44
+
+func keyringGet(service, account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		return "", securityError(err)
+	}
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil {
+		return "", fmt.Errorf("auth: malformed keychain item: %w", err)
+	}
+	return string(secret), nil
+}
+
+func keyringSet(service, account, secret string) error {
+	// The secret is passed on stdin in interactive mode so it does not
+	// appear in the process list. Base64 avoids quoting issues.
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		quoteSecurityArg(service), quoteSecurityArg(account),
+		base64.StdEncoding.EncodeToString([]byte(secret))))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("auth: keychain write failed: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func keyringDelete(service, account string) error {
+	if err := exec.Command("security", "delete-generic-password", "-s", service, "-a", account).Run(); err != nil {
+		return securityError(err)
+	}
+	return nil
+}
+
+// securityError maps security(1) failures to keyring errors.
+func securityError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+		return errKeyringNotFound
+	}
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrKeyringUnavailable
+	}
+	return fmt.Errorf("auth: keychain access failed: %w", err)
+}
+
+// quoteSecurityArg quotes an argument for security(1) interactive mode.
+func quoteSecurityArg(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
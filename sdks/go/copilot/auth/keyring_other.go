@@ -0,0 +1,15 @@
+//go:build !darwin && !windows && !linux && !freebsd && !openbsd && !netbsd && !dragonfly
+
+package auth
+
+func keyringGet(service, account string) (string, error) {
+	return "", ErrKeyringUnavailable
+}
+
+func keyringSet(service, account, secret string) error {
+	return ErrKeyringUnavailable
+}
+
+func keyringDelete(service, account string) error {
+	return ErrKeyringUnavailable
+}
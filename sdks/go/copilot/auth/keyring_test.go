@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckSecretSize(t *testing.T) {
+	const limit = 5 * 512
+	if err := checkSecretSize(strings.Repeat("x", limit), limit); err != nil {
+		t.Errorf("expected a secret at the limit to fit, got %v", err)
+	}
+	err := checkSecretSize(strings.Repeat("x", limit+1), limit)
+	if !errors.Is(err, ErrSecretTooLarge) {
+		t.Fatalf("expected ErrSecretTooLarge, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "2561 bytes") {
+		t.Errorf("expected the size in the error, got %v", err)
+	}
+}
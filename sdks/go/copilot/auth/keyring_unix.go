@@ -0,0 +1,52 @@
+//go:build linux || freebsd || openbsd || netbsd || dragonfly
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// The Secret Service is reached through secret-tool(1) from libsecret,
+// which avoids a D-Bus dependency.
+
+func keyringGet(service, account string) (string, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(out) == 0 && len(exitErr.Stderr) == 0 {
+			return "", errKeyringNotFound
+		}
+		return "", secretToolError(err)
+	}
+	return string(out), nil
+}
+
+func keyringSet(service, account, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label="+service+" ("+account+")", "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return ErrKeyringUnavailable
+		}
+		return fmt.Errorf("auth: secret service write failed: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func keyringDelete(service, account string) error {
+	if err := exec.Command("secret-tool", "clear", "service", service, "account", account).Run(); err != nil {
+		return secretToolError(err)
+	}
+	return nil
+}
+
+// secretToolError maps secret-tool(1) failures to keyring errors.
+func secretToolError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrKeyringUnavailable
+	}
+	return fmt.Errorf("auth: secret service access failed: %w", err)
+}
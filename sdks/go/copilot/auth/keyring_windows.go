@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+
+	// credMaxCredentialBlobSize is CRED_MAX_CREDENTIAL_BLOB_SIZE, the
+	// largest secret CredWriteW accepts.
+	credMaxCredentialBlobSize = 5 * 512
+)
+
+// credential mirrors the Win32 CREDENTIALW structure.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialTarget is the Credential Manager target name for an entry.
+func credentialTarget(service, account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(service + ":" + account)
+}
+
+func keyringGet(service, account string) (string, error) {
+	target, err := credentialTarget(service, account)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	r, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if r == 0 {
+		return "", credError(callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	secret := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	return string(secret), nil
+}
+
+func keyringSet(service, account, secret string) error {
+	if err := checkSecretSize(secret, credMaxCredentialBlobSize); err != nil {
+		return err
+	}
+	target, err := credentialTarget(service, account)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		UserName:           user,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	r, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if r == 0 {
+		return credError(callErr)
+	}
+	return nil
+}
+
+func keyringDelete(service, account string) error {
+	target, err := credentialTarget(service, account)
+	if err != nil {
+		return err
+	}
+	r, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if r == 0 {
+		return credError(callErr)
+	}
+	return nil
+}
+
+// credError maps Credential Manager failures to keyring errors.
+func credError(err error) error {
+	if errors.Is(err, errorNotFound) {
+		return errKeyringNotFound
+	}
+	return fmt.Errorf("auth: credential manager access failed: %w", err)
+}
@@ -0,0 +1,269 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrStateMismatch is returned when the OAuth callback state does not match
+// the state sent in the authorization request.
+var ErrStateMismatch = errors.New("auth: oauth state mismatch")
+
+// OAuthConfig describes an OAuth2 authorization-code client.
+type OAuthConfig struct {
+	// AuthURL is the authorization endpoint.
+	AuthURL string
+	// TokenURL is the token endpoint.
+	TokenURL string
+	// ClientID identifies the application. Public clients using PKCE do
+	// not need a secret.
+	ClientID string
+	// RedirectURL is the loopback callback, e.g. "http://127.0.0.1:8085/callback".
+	// If empty, a random free port on 127.0.0.1 is used.
+	RedirectURL string
+	// Scopes are the requested scopes.
+	Scopes []string
+	// HTTPClient is used for the token exchange. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// PKCE holds a Proof Key for Code Exchange verifier and its S256 challenge.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE generates a random PKCE verifier as specified in RFC 7636.
+func NewPKCE() (*PKCE, error) {
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return &PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}, nil
+}
+
+// AuthCodeURL returns the URL the user visits to authorize the application.
+func (c *OAuthConfig) AuthCodeURL(state string, pkce *PKCE) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.ClientID},
+		"state":                 {state},
+		"code_challenge":        {pkce.Challenge},
+		"code_challenge_method": {"S256"},
+	}
+	if c.RedirectURL != "" {
+		params.Set("redirect_uri", c.RedirectURL)
+	}
+	if len(c.Scopes) > 0 {
+		params.Set("scope", strings.Join(c.Scopes, " "))
+	}
+
+	sep := "?"
+	if strings.Contains(c.AuthURL, "?") {
+		sep = "&"
+	}
+	return c.AuthURL + sep + params.Encode()
+}
+
+// Exchange trades an authorization code for tokens.
+func (c *OAuthConfig) Exchange(ctx context.Context, code string, pkce *PKCE) (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"client_id":     {c.ClientID},
+		"code_verifier": {pkce.Verifier},
+	}
+	if c.RedirectURL != "" {
+		form.Set("redirect_uri", c.RedirectURL)
+	}
+	return c.requestToken(ctx, form)
+}
+
+// requestToken posts a token request and parses the response.
+func (c *OAuthConfig) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read token response: %w", err)
+	}
+
+	var tr struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int    `json:"expires_in"`
+		RefreshExpiresIn int    `json:"refresh_expires_in"`
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return nil, fmt.Errorf("auth: token endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if tr.Error != "" {
+		return nil, fmt.Errorf("auth: token endpoint error %s: %s", tr.Error, tr.ErrorDescription)
+	}
+	if resp.StatusCode >= 400 || tr.AccessToken == "" {
+		return nil, fmt.Errorf("auth: token endpoint returned %d without an access token", resp.StatusCode)
+	}
+
+	now := time.Now()
+	token := &Token{
+		AccessToken:  tr.AccessToken,
+		RefreshToken: tr.RefreshToken,
+		TokenType:    tr.TokenType,
+		IDToken:      tr.IDToken,
+	}
+	if tr.ExpiresIn > 0 {
+		token.ExpiresAt = now.Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+	if tr.RefreshExpiresIn > 0 {
+		token.RefreshExpiresAt = now.Add(time.Duration(tr.RefreshExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// LoginWithBrowser runs the authorization-code flow with PKCE for native
+// applications (RFC 8252): it listens on a loopback redirect URL, calls open
+// with the authorization URL, waits for the callback, and exchanges the code.
+// If open is nil, the system browser is launched.
+func (c *OAuthConfig) LoginWithBrowser(ctx context.Context, open func(authURL string) error) (*Token, error) {
+	if open == nil {
+		open = OpenBrowser
+	}
+
+	redirect := c.RedirectURL
+	if redirect == "" {
+		redirect = "http://127.0.0.1:0/callback"
+	}
+	redirectURL, err := url.Parse(redirect)
+	if err != nil {
+		return nil, fmt.Errorf("auth: invalid redirect URL: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", redirectURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to listen for callback: %w", err)
+	}
+	defer listener.Close()
+
+	// Use the actual port when a random one was requested.
+	redirectURL.Host = listener.Addr().String()
+	flow := *c
+	flow.RedirectURL = redirectURL.String()
+
+	pkce, err := NewPKCE()
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	type callback struct {
+		code string
+		err  error
+	}
+	results := make(chan callback, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirectURL.Path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var result callback
+		switch {
+		case q.Get("error") != "":
+			result.err = fmt.Errorf("auth: authorization denied: %s %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("state") != state:
+			result.err = ErrStateMismatch
+		case q.Get("code") == "":
+			result.err = errors.New("auth: callback missing authorization code")
+		default:
+			result.code = q.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, "Login failed. You can close this window.", http.StatusBadRequest)
+		} else {
+			io.WriteString(w, "Login complete. You can close this window.")
+		}
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	if err := open(flow.AuthCodeURL(state, pkce)); err != nil {
+		return nil, fmt.Errorf("auth: failed to open browser: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return flow.Exchange(ctx, result.code, pkce)
+	}
+}
+
+// OpenBrowser opens url in the system browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// randomString returns n random bytes encoded as unpadded base64url.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("auth: failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
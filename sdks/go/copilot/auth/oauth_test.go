@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestLoginWithBrowser(t *testing.T) {
+	var challenge string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("code") != "auth-code" {
+			t.Errorf("unexpected token request %v", r.Form)
+		}
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+			t.Error("code verifier does not match challenge")
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"expires_in":    3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	config := &OAuthConfig{
+		AuthURL:  "https://auth.example.com/authorize",
+		TokenURL: tokenServer.URL,
+		ClientID: "cli",
+		Scopes:   []string{"chat", "workflows"},
+	}
+
+	// Simulate the browser: the authorization server redirects back with a code.
+	open := func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		q := u.Query()
+		challenge = q.Get("code_challenge")
+		if q.Get("code_challenge_method") != "S256" || q.Get("scope") != "chat workflows" {
+			t.Errorf("unexpected authorization request %s", authURL)
+		}
+		go http.Get(q.Get("redirect_uri") + "?code=auth-code&state=" + url.QueryEscape(q.Get("state")))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := config.LoginWithBrowser(ctx, open)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" || token.ExpiresAt.IsZero() {
+		t.Errorf("unexpected token %+v", token)
+	}
+}
+
+func TestLoginWithBrowserStateMismatch(t *testing.T) {
+	config := &OAuthConfig{AuthURL: "https://auth.example.com/authorize", ClientID: "cli"}
+
+	open := func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		go http.Get(u.Query().Get("redirect_uri") + "?code=auth-code&state=forged")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := config.LoginWithBrowser(ctx, open); !errors.Is(err, ErrStateMismatch) {
+		t.Errorf("expected ErrStateMismatch, got %v", err)
+	}
+}
+
+func TestDiscoverOIDC(t *testing.T) {
+	var issuer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 issuer,
+			"authorization_endpoint": issuer + "/authorize",
+			"token_endpoint":         issuer + "/token",
+			"jwks_uri":               issuer + "/keys",
+		})
+	}))
+	defer server.Close()
+	issuer = server.URL
+
+	provider, err := DiscoverOIDC(context.Background(), nil, server.URL+"/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := provider.OAuthConfig("app", "email", "openid")
+	if config.TokenURL != server.URL+"/token" || len(config.Scopes) != 2 || config.Scopes[0] != "openid" {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	issuer = "https://evil.example.com"
+	if _, err := DiscoverOIDC(context.Background(), nil, server.URL); err == nil {
+		t.Error("expected issuer mismatch error")
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OIDCProvider holds the endpoints an OpenID Connect provider publishes
+// in its discovery document.
+type OIDCProvider struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint,omitempty"`
+	JWKSURI               string   `json:"jwks_uri"`
+	ScopesSupported       []string `json:"scopes_supported,omitempty"`
+}
+
+// DiscoverOIDC fetches the discovery document of the OpenID Connect
+// provider at issuer, e.g. "https://example.okta.com" or
+// "https://login.microsoftonline.com/<tenant>/v2.0". If httpClient is nil,
+// http.DefaultClient is used.
+func DiscoverOIDC(ctx context.Context, httpClient *http.Client, issuer string) (*OIDCProvider, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to create discovery request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: discovery request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read discovery document: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("auth: discovery endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var provider OIDCProvider
+	if err := json.Unmarshal(body, &provider); err != nil {
+		return nil, fmt.Errorf("auth: invalid discovery document: %w", err)
+	}
+	// The issuer must match exactly, so a document served from one host
+	// cannot impersonate another provider (OpenID Connect Discovery 4.3).
+	if strings.TrimSuffix(provider.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("auth: discovery document issuer %q does not match %q", provider.Issuer, issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" {
+		return nil, fmt.Errorf("auth: discovery document for %s lacks authorization or token endpoint", issuer)
+	}
+	return &provider, nil
+}
+
+// OAuthConfig returns an authorization-code configuration for the
+// provider. The "openid" scope is always requested so that the token
+// response carries an ID token.
+func (p *OIDCProvider) OAuthConfig(clientID string, scopes ...string) *OAuthConfig {
+	requested := []string{"openid"}
+	for _, scope := range scopes {
+		if scope != "openid" {
+			requested = append(requested, scope)
+		}
+	}
+	return &OAuthConfig{
+		AuthURL:  p.AuthorizationEndpoint,
+		TokenURL: p.TokenEndpoint,
+		ClientID: clientID,
+		Scopes:   requested,
+	}
+}
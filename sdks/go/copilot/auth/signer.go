@@ -0,0 +1,31 @@
+package auth
+
+import "net/http"
+
+// Signer authenticates a request by signing it rather than attaching a
+// static credential. The client calls Sign once per attempt, after every
+// other header has been set. Implementations must be safe for concurrent
+// use.
+type Signer interface {
+	// Sign adds authentication to req. body holds the exact bytes sent,
+	// after any compression, and is nil for requests without a body.
+	Sign(req *http.Request, body []byte) error
+}
+
+// SignerFunc adapts a function to a Signer.
+type SignerFunc func(req *http.Request, body []byte) error
+
+// Sign calls f.
+func (f SignerFunc) Sign(req *http.Request, body []byte) error {
+	return f(req, body)
+}
+
+// IdentifiedSigner is a Signer that names the credential it signs with,
+// so that clients can tell identities apart, e.g. to keep cached
+// responses separate, without relying on per-request signatures.
+type IdentifiedSigner interface {
+	Signer
+	// SignerIdentity returns a stable identifier of the credential, such
+	// as its key ID. It must not reveal the secret.
+	SignerIdentity() string
+}
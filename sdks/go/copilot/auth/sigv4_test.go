@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigV4Signer(t *testing.T) {
+	// The get-vanilla case of the AWS Signature Version 4 test suite.
+	signer := &SigV4Signer{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Region:          "us-east-1",
+		Service:         "service",
+		Now:             func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) },
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err := signer.Sign(req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("unexpected authorization:\n got %s\nwant %s", got, want)
+	}
+	if req.Header.Get("X-Amz-Date") != "20150830T123600Z" {
+		t.Errorf("unexpected date %s", req.Header.Get("X-Amz-Date"))
+	}
+}
+
+func TestSigV4SignerSessionToken(t *testing.T) {
+	signer := &SigV4Signer{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session", Region: "eu-west-1"}
+	req, _ := http.NewRequest(http.MethodPost, "https://abc.execute-api.eu-west-1.amazonaws.com/prod/api/v1/conversations?b=2&a=1", nil)
+	req.Header.Set("Content-Type", "application/json")
+	if err := signer.Sign(req, []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	auth := req.Header.Get("Authorization")
+	if !strings.Contains(auth, "/eu-west-1/execute-api/aws4_request") {
+		t.Errorf("expected default service in scope, got %s", auth)
+	}
+	if !strings.Contains(auth, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token,") {
+		t.Errorf("unexpected signed headers in %s", auth)
+	}
+	if req.Header.Get("X-Amz-Security-Token") != "session" {
+		t.Error("expected session token header")
+	}
+
+	if err := (&SigV4Signer{AccessKeyID: "AKID", SecretAccessKey: "secret"}).Sign(req, nil); err == nil {
+		t.Error("expected error for missing region")
+	}
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// GetSystemStats returns operational metrics such as active conversations,
+// queued workflow runs, and per-component latency and error rates.
+// It requires an admin-scoped credential.
+func (c *Client) GetSystemStats(ctx context.Context) (*models.SystemStats, error) {
+	var stats models.SystemStats
+	if err := c.get(ctx, "/api/v1/admin/stats", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
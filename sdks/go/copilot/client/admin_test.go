@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestGetSystemStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/admin/stats" {
+			t.Errorf("expected path /api/v1/admin/stats, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(models.SystemStats{
+			ActiveConversations: 42,
+			QueuedWorkflowRuns:  7,
+			Components: map[string]models.ComponentStats{
+				"llm": {Latency: models.LatencyStats{P95Ms: 850}, ErrorRate: 0.01},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "admin-key")
+	stats, err := client.GetSystemStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.ActiveConversations != 42 {
+		t.Errorf("expected 42 active conversations, got %d", stats.ActiveConversations)
+	}
+	if stats.Components["llm"].Latency.P95Ms != 850 {
+		t.Errorf("expected llm p95 of 850ms, got %v", stats.Components["llm"].Latency.P95Ms)
+	}
+}
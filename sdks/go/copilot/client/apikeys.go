@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// CreateAPIKey creates an API key. The secret is only returned here.
+func (c *Client) CreateAPIKey(ctx context.Context, req *models.ApiKeyCreate) (*models.ApiKeyWithSecret, error) {
+	var key models.ApiKeyWithSecret
+	if err := c.post(ctx, "/api/v1/api-keys", req, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListAPIKeys lists the current user's API keys.
+func (c *Client) ListAPIKeys(ctx context.Context) ([]models.ApiKey, error) {
+	var keys []models.ApiKey
+	if err := c.get(ctx, "/api/v1/api-keys", &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key.
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) error {
+	return c.delete(ctx, "/api/v1/api-keys/"+id)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestAPIKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/api-keys":
+			var req models.ApiKeyCreate
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(models.ApiKeyWithSecret{
+				ApiKey: models.ApiKey{ID: "key-1", Name: req.Name, Scopes: req.Scopes},
+				Key:    "sk-secret",
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/api-keys":
+			json.NewEncoder(w).Encode([]models.ApiKey{{ID: "key-1", Name: "ci"}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/api-keys/key-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	created, err := client.CreateAPIKey(ctx, &models.ApiKeyCreate{Name: "ci", Scopes: []models.ApiKeyScope{models.ScopeRead}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Key != "sk-secret" || created.Name != "ci" {
+		t.Errorf("unexpected key %+v", created)
+	}
+
+	keys, err := client.ListAPIKeys(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != "key-1" {
+		t.Errorf("unexpected keys %+v", keys)
+	}
+
+	if err := client.RevokeAPIKey(ctx, "key-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
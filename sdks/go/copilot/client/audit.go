@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// ListAuditEvents returns a page of audit events matching the query. Pass the
+// returned NextCursor in the next query to fetch the following page.
+func (c *Client) ListAuditEvents(ctx context.Context, query models.AuditQuery) (*models.PaginatedResponse[models.AuditEvent], error) {
+	params := url.Values{}
+	if query.Actor != "" {
+		params.Set("actor", query.Actor)
+	}
+	if query.Action != "" {
+		params.Set("action", query.Action)
+	}
+	if query.Resource != "" {
+		params.Set("resource", query.Resource)
+	}
+	if !query.TimeRange.From.IsZero() {
+		params.Set("from", query.TimeRange.From.UTC().Format(time.RFC3339))
+	}
+	if !query.TimeRange.To.IsZero() {
+		params.Set("to", query.TimeRange.To.UTC().Format(time.RFC3339))
+	}
+	if query.Cursor != "" {
+		params.Set("cursor", query.Cursor)
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	return List[models.AuditEvent](ctx, c, "/api/v1/audit/events", &ListOptions{Query: params})
+}
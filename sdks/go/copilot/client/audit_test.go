@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/audit/events" {
+			t.Errorf("expected path /api/v1/audit/events, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("action") != "api_key.create" {
+			t.Errorf("expected action filter, got %s", r.URL.RawQuery)
+		}
+
+		resp := models.PaginatedResponse[models.AuditEvent]{}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp.Items = []models.AuditEvent{{ID: "evt-1", Action: "api_key.create"}}
+			resp.HasMore = true
+			resp.NextCursor = "cursor-2"
+		case "cursor-2":
+			resp.Items = []models.AuditEvent{{ID: "evt-2", Action: "api_key.create"}}
+		default:
+			t.Errorf("unexpected cursor %s", r.URL.Query().Get("cursor"))
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	query := models.AuditQuery{Action: "api_key.create"}
+
+	var ids []string
+	for {
+		page, err := client.ListAuditEvents(context.Background(), query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, evt := range page.Items {
+			ids = append(ids, evt.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	if len(ids) != 2 || ids[1] != "evt-2" {
+		t.Errorf("expected events [evt-1 evt-2], got %v", ids)
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// MaxBulkDeleteSize is the number of IDs sent per bulk delete request.
+// Larger batches are split across several requests.
+const MaxBulkDeleteSize = 100
+
+// DeleteConversations deletes conversations in batches and reports the
+// outcome for each ID. A conversation that could not be deleted does not
+// fail the call; inspect the results or use Failed. When FeatureBatch is
+// disabled each conversation is deleted with its own request.
+func (c *Client) DeleteConversations(ctx context.Context, ids []models.ConversationID) (*models.BulkDeleteResponse, error) {
+	raw := make([]string, len(ids))
+	for i, id := range ids {
+		if err := id.Validate(); err != nil {
+			return nil, err
+		}
+		raw[i] = id.String()
+	}
+	return c.bulkDelete(ctx, "/api/v1/conversations/", raw)
+}
+
+// DeleteContextItems deletes context items in batches and reports the
+// outcome for each ID, falling back to one request per item like
+// DeleteConversations.
+func (c *Client) DeleteContextItems(ctx context.Context, ids []string) (*models.BulkDeleteResponse, error) {
+	for _, id := range ids {
+		if err := models.ValidateContextItemID(id); err != nil {
+			return nil, err
+		}
+	}
+	return c.bulkDelete(ctx, "/api/v1/context/", ids)
+}
+
+// bulkDelete posts ids to the collection's bulk-delete endpoint in batches
+// of MaxBulkDeleteSize, or deletes them one by one when FeatureBatch is
+// disabled. Results completed before an error are returned with it.
+func (c *Client) bulkDelete(ctx context.Context, collection string, ids []string) (*models.BulkDeleteResponse, error) {
+	all := &models.BulkDeleteResponse{Results: make([]models.BulkDeleteResult, 0, len(ids))}
+	if !c.features.enabled(FeatureBatch) {
+		return c.deleteEach(ctx, collection, ids, all)
+	}
+
+	path := collection + "bulk-delete"
+	for start := 0; start < len(ids); start += MaxBulkDeleteSize {
+		end := start + MaxBulkDeleteSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var resp models.BulkDeleteResponse
+		if err := c.post(ctx, path, models.BulkDeleteRequest{IDs: ids[start:end]}, &resp); err != nil {
+			return all, err
+		}
+		all.Results = append(all.Results, resp.Results...)
+	}
+	return all, nil
+}
+
+// deleteEach deletes ids one request at a time. API errors are reported in
+// the item's result, as the bulk endpoint does; any other error stops the
+// deletion.
+func (c *Client) deleteEach(ctx context.Context, collection string, ids []string, all *models.BulkDeleteResponse) (*models.BulkDeleteResponse, error) {
+	for _, id := range ids {
+		result := models.BulkDeleteResult{ID: id, Deleted: true}
+		if err := c.delete(ctx, collection+id); err != nil {
+			var apiErr *CoPilotError
+			if !errors.As(err, &apiErr) {
+				return all, err
+			}
+			result.Deleted = false
+			result.Error = &models.APIError{
+				Code:          apiErr.Code,
+				Message:       apiErr.Message,
+				Details:       apiErr.Details,
+				FieldErrors:   apiErr.FieldErrors,
+				RequestID:     apiErr.RequestID,
+				CorrelationID: apiErr.CorrelationID,
+			}
+		}
+		all.Results = append(all.Results, result)
+	}
+	return all, nil
+}
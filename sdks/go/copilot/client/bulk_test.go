@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestDeleteConversationsBatches(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/conversations/bulk-delete" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req models.BulkDeleteRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, len(req.IDs))
+
+		var resp models.BulkDeleteResponse
+		for _, id := range req.IDs {
+			result := models.BulkDeleteResult{ID: id, Deleted: id != "conv-7"}
+			if !result.Deleted {
+				result.Error = &models.APIError{Code: "not_found", Message: "conversation not found"}
+			}
+			resp.Results = append(resp.Results, result)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	ids := make([]models.ConversationID, 250)
+	for i := range ids {
+		ids[i] = models.ConversationID(fmt.Sprintf("conv-%d", i))
+	}
+
+	client := New(&Config{BaseURL: server.URL})
+	resp, err := client.DeleteConversations(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 3 || batches[0] != MaxBulkDeleteSize || batches[2] != 50 {
+		t.Errorf("unexpected batches: %v", batches)
+	}
+	if len(resp.Results) != 250 {
+		t.Errorf("expected 250 results, got %d", len(resp.Results))
+	}
+	failed := resp.Failed()
+	if len(failed) != 1 || failed[0].ID != "conv-7" || failed[0].Error.Code != "not_found" {
+		t.Errorf("unexpected failures: %+v", failed)
+	}
+
+	if _, err := client.DeleteConversations(context.Background(), []models.ConversationID{"conv-1", "bad/id"}); !errors.Is(err, models.ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID, got %v", err)
+	}
+}
+
+func TestDeleteConversationsWithoutBatch(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Path == "/api/v1/conversations/conv-2" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.APIError{Code: "not_found", Message: "conversation not found"})
+			return
+		}
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, DisabledFeatures: []Feature{FeatureBatch}})
+	resp, err := client.DeleteConversations(context.Background(), []models.ConversationID{"conv-1", "conv-2", "conv-3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 2 || deleted[0] != "/api/v1/conversations/conv-1" || deleted[1] != "/api/v1/conversations/conv-3" {
+		t.Errorf("unexpected deletes: %v", deleted)
+	}
+	if len(resp.Results) != 3 {
+		t.Errorf("expected 3 results, got %d", len(resp.Results))
+	}
+	failed := resp.Failed()
+	if len(failed) != 1 || failed[0].ID != "conv-2" || failed[0].Error.Code != "not_found" {
+		t.Errorf("unexpected failures: %+v", failed)
+	}
+}
+
+func TestDeleteContextItemsRejectsInvalidIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	for _, disabled := range [][]Feature{nil, {FeatureBatch}} {
+		client := New(&Config{BaseURL: server.URL, DisabledFeatures: disabled})
+		for _, ids := range [][]string{{"ctx-1", ""}, {"ctx-1", "ctx/../conversations/conv-1"}} {
+			if _, err := client.DeleteContextItems(context.Background(), ids); !errors.Is(err, models.ErrInvalidID) {
+				t.Errorf("DeleteContextItems(%q) with %v disabled: expected ErrInvalidID, got %v", ids, disabled, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+)
+
+// CacheEntry is a cached GET response with its validators.
+type CacheEntry struct {
+	ETag         string
+	LastModified string
+	Body         []byte
+	StoredAt     time.Time
+}
+
+// ResponseCache stores GET responses for conditional revalidation.
+// Implementations must be safe for concurrent use.
+type ResponseCache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// MemoryCache is an in-memory LRU ResponseCache.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryCache creates a MemoryCache holding up to maxEntries responses.
+// Zero means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry for key.
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores entry under key, evicting the least recently used entry if
+// the cache is full.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryCacheItem).entry = entry
+		m.order.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete removes the entry for key.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.order.Remove(elem)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of cached entries.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// cacheKey returns the cache key for a request's URL. The key includes
+// the credentials and tenant so that responses are never shared across
+// identities. Signed requests are identified by the signer's credential,
+// not the per-request signature; those of a signer that does not name its
+// credential are not cached.
+func (c *Client) cacheKey(req *http.Request) (string, bool) {
+	credential := req.Header.Get("Authorization") + "\x00" + req.Header.Get("X-API-Key")
+	if c.config.Signer != nil {
+		signer, ok := c.config.Signer.(auth.IdentifiedSigner)
+		if !ok {
+			return "", false
+		}
+		credential = "signer\x00" + signer.SignerIdentity()
+	}
+	identity := sha256.Sum256([]byte(credential + "\x00" + req.Header.Get(TenantIDHeader) + "\x00" + req.Header.Get(ScopesHeader)))
+	return req.URL.String() + "#" + hex.EncodeToString(identity[:8]), true
+}
+
+// cacheIndex records the cache keys stored for each request path, so that
+// a write can drop every query variant of the resources it changes. It is
+// shared with copies made by ForTenant and With.
+type cacheIndex struct {
+	mu   sync.Mutex
+	keys map[string]map[string]bool
+}
+
+func newCacheIndex() *cacheIndex {
+	return &cacheIndex{keys: make(map[string]map[string]bool)}
+}
+
+// add records that key caches a response for path.
+func (ix *cacheIndex) add(path, key string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if ix.keys[path] == nil {
+		ix.keys[path] = make(map[string]bool)
+	}
+	ix.keys[path][key] = true
+}
+
+// remove forgets key, e.g. once the cache has evicted it.
+func (ix *cacheIndex) remove(path, key string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	delete(ix.keys[path], key)
+	if len(ix.keys[path]) == 0 {
+		delete(ix.keys, path)
+	}
+}
+
+// take forgets and returns the keys recorded for path.
+func (ix *cacheIndex) take(path string) []string {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	keys := make([]string, 0, len(ix.keys[path]))
+	for key := range ix.keys[path] {
+		keys = append(keys, key)
+	}
+	delete(ix.keys, path)
+	return keys
+}
+
+// resourcePaths returns path and the paths of the resources enclosing it,
+// e.g. a run and the run's collection for a run's cancel endpoint.
+func resourcePaths(path string) []string {
+	var paths []string
+	for path = strings.TrimSuffix(path, "/"); path != ""; path = path[:strings.LastIndex(path, "/")] {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// cacheLookup returns the cache key for a GET request and, when a cached
+// entry exists, adds conditional headers for it.
+func (c *Client) cacheLookup(req *http.Request) (string, *CacheEntry) {
+	if c.config.Cache == nil || req.Method != http.MethodGet {
+		return "", nil
+	}
+
+	key, ok := c.cacheKey(req)
+	if !ok {
+		return "", nil
+	}
+	entry, ok := c.config.Cache.Get(key)
+	if !ok {
+		c.cacheKeys.remove(req.URL.Path, key)
+		return key, nil
+	}
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+	return key, entry
+}
+
+// fresh reports whether entry is younger than Config.CacheTTL, so that it
+// can be served without contacting the server.
+func (c *Client) fresh(entry *CacheEntry) bool {
+	return entry != nil && c.config.CacheTTL > 0 && time.Since(entry.StoredAt) < c.config.CacheTTL
+}
+
+// cacheInvalidate drops the cached GETs of the resources a successful
+// write changed, so that the next read sees the change however fresh the
+// entry. A write to a sub-resource, such as a run's cancel endpoint or a
+// conversation's messages, changes the enclosing resources too; their
+// cached responses are dropped for every query.
+func (c *Client) cacheInvalidate(req *http.Request) {
+	if c.config.Cache == nil || req.Method == http.MethodGet {
+		return
+	}
+	for _, path := range resourcePaths(req.URL.Path) {
+		for _, key := range c.cacheKeys.take(path) {
+			c.config.Cache.Delete(key)
+		}
+
+		// Responses cached by other clients sharing the cache are not
+		// indexed here; drop at least the plain URL's entry.
+		u := *req.URL
+		u.Path, u.RawPath, u.RawQuery = path, "", ""
+		plain := *req
+		plain.URL = &u
+		if key, ok := c.cacheKey(&plain); ok {
+			c.config.Cache.Delete(key)
+		}
+	}
+}
+
+// cacheStore saves a successful response that carries validators, or any
+// successful response when Config.CacheTTL is set.
+func (c *Client) cacheStore(req *http.Request, key string, resp *http.Response, body []byte) {
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" && c.config.CacheTTL <= 0 {
+		c.config.Cache.Delete(key)
+		c.cacheKeys.remove(req.URL.Path, key)
+		return
+	}
+	c.cacheKeys.add(req.URL.Path, key)
+	c.config.Cache.Set(key, &CacheEntry{
+		ETag:         etag,
+		LastModified: lastModified,
+		Body:         body,
+		StoredAt:     time.Now(),
+	})
+}
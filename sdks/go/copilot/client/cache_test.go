@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestConditionalGet(t *testing.T) {
+	var full, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(models.Conversation{ID: "conv-1", Title: "Cached"})
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	client := New(&Config{BaseURL: server.URL, APIKey: "key", Cache: cache})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		conv, err := client.GetConversation(ctx, "conv-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if conv.Title != "Cached" {
+			t.Errorf("expected cached conversation, got %+v", conv)
+		}
+	}
+	if full != 1 || notModified != 2 {
+		t.Errorf("expected 1 full response and 2 revalidations, got %d and %d", full, notModified)
+	}
+
+	other := New(&Config{BaseURL: server.URL, APIKey: "other-key", Cache: cache})
+	if _, err := other.GetConversation(ctx, "conv-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if full != 2 {
+		t.Errorf("expected cache entries to be scoped to credentials, got %d full responses", full)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		atomic.AddInt32(&gets, 1)
+		json.NewEncoder(w).Encode(models.Conversation{ID: "conv-1", Title: "Fresh"})
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, APIKey: "key", Cache: NewMemoryCache(10), CacheTTL: time.Hour})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		conv, err := client.GetConversation(ctx, "conv-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if conv.Title != "Fresh" {
+			t.Errorf("expected cached conversation, got %+v", conv)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("expected fresh responses to be served from the cache, got %d requests", gets)
+	}
+
+	// A write through the client drops the cached copy.
+	if err := client.DeleteConversation(ctx, "conv-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.GetConversation(ctx, "conv-1")
+	if gets != 2 {
+		t.Errorf("expected a request after the write, got %d requests", gets)
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CacheEntry{ETag: "a"})
+	cache.Set("b", &CacheEntry{ETag: "b"})
+	cache.Get("a")
+	cache.Set("c", &CacheEntry{ETag: "c"})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("expected recently used entry to be kept")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestCacheSignedIdentities(t *testing.T) {
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&gets, 1)
+		json.NewEncoder(w).Encode(models.Conversation{ID: "conv-1", Title: "Signed by " + r.Header.Get(auth.KeyIDHeader)})
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	ctx := context.Background()
+	signers := map[string]auth.Signer{
+		"hmac":  &auth.HMACSigner{KeyID: "key-a", Secret: []byte("secret-a")},
+		"sigv4": &auth.SigV4Signer{AccessKeyID: "AKIDA", SecretAccessKey: "secret-a", Region: "us-east-1"},
+	}
+	others := map[string]auth.Signer{
+		"hmac":  &auth.HMACSigner{KeyID: "key-b", Secret: []byte("secret-b")},
+		"sigv4": &auth.SigV4Signer{AccessKeyID: "AKIDB", SecretAccessKey: "secret-b", Region: "us-east-1"},
+	}
+	for name, signer := range signers {
+		atomic.StoreInt32(&gets, 0)
+		client := New(&Config{BaseURL: server.URL, Signer: signer, Cache: cache, CacheTTL: time.Hour})
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetConversation(ctx, "conv-1"); err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+		}
+		if n := atomic.LoadInt32(&gets); n != 1 {
+			t.Errorf("%s: expected the second read to hit the cache despite a new signature, got %d requests", name, n)
+		}
+
+		other := New(&Config{BaseURL: server.URL, Signer: others[name], Cache: cache, CacheTTL: time.Hour})
+		if _, err := other.GetConversation(ctx, "conv-1"); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if n := atomic.LoadInt32(&gets); n != 2 {
+			t.Errorf("%s: expected another key not to share the cache, got %d requests", name, n)
+		}
+	}
+
+	// A signer that does not name its credential is not cached.
+	atomic.StoreInt32(&gets, 0)
+	anonymous := New(&Config{BaseURL: server.URL, Signer: auth.SignerFunc(func(*http.Request, []byte) error { return nil }), Cache: cache, CacheTTL: time.Hour})
+	anonymous.GetConversation(ctx, "conv-1")
+	anonymous.GetConversation(ctx, "conv-1")
+	if n := atomic.LoadInt32(&gets); n != 2 {
+		t.Errorf("expected no caching for an unidentified signer, got %d requests", n)
+	}
+}
+
+func TestCacheInvalidatesEnclosingResources(t *testing.T) {
+	var runGets, convGets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/workflows/runs/run-1":
+			atomic.AddInt32(&runGets, 1)
+			json.NewEncoder(w).Encode(models.WorkflowRun{ID: "run-1", Status: models.WorkflowStatusRunning})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/workflows/runs/run-1/cancel":
+			json.NewEncoder(w).Encode(models.WorkflowRun{ID: "run-1", Status: models.WorkflowStatusCancelled})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/conversations/conv-1":
+			atomic.AddInt32(&convGets, 1)
+			json.NewEncoder(w).Encode(models.ConversationWithMessages{Conversation: models.Conversation{ID: "conv-1"}, Messages: []models.Message{}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/conversations/conv-1/messages":
+			json.NewEncoder(w).Encode(models.Message{ID: "msg-1"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, APIKey: "key", Cache: NewMemoryCache(10), CacheTTL: time.Hour})
+	ctx := context.Background()
+
+	client.GetWorkflowRun(ctx, "run-1")
+	client.GetWorkflowRun(ctx, "run-1")
+	if _, err := client.CancelWorkflowRun(ctx, "run-1", models.CancelOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.GetWorkflowRun(ctx, "run-1")
+	if n := atomic.LoadInt32(&runGets); n != 2 {
+		t.Errorf("expected the run to be refetched after cancelling it, got %d requests", n)
+	}
+
+	// Every query variant of the conversation is dropped.
+	client.GetConversation(ctx, "conv-1")
+	client.GetConversationWithMessages(ctx, "conv-1", 10)
+	if _, err := client.SendMessage(ctx, "conv-1", "hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.GetConversation(ctx, "conv-1")
+	client.GetConversationWithMessages(ctx, "conv-1", 10)
+	if n := atomic.LoadInt32(&convGets); n != 4 {
+		t.Errorf("expected both conversation reads to be refetched, got %d requests", n)
+	}
+}
+
+func TestResourcePaths(t *testing.T) {
+	got := strings.Join(resourcePaths("/api/v1/workflows/runs/run-1/cancel"), " ")
+	want := "/api/v1/workflows/runs/run-1/cancel /api/v1/workflows/runs/run-1 /api/v1/workflows/runs /api/v1/workflows /api/v1 /api"
+	if got != want {
+		t.Errorf("unexpected paths %q", got)
+	}
+}
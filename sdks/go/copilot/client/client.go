@@ -4,44 +4,185 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
 	"github.com/llm-copilot-agent/sdk-go/copilot/models"
 )
 
+// Version is the SDK version.
+const Version = "0.1.0"
+
+// DefaultUserAgent identifies the SDK in the User-Agent header.
+const DefaultUserAgent = "llm-copilot-sdk-go/" + Version
+
+// DefaultMaxResponseBytes is the default limit on response body size.
+const DefaultMaxResponseBytes = 32 << 20
+
+// TenantIDHeader is the header that scopes a request to a tenant.
+const TenantIDHeader = "X-Tenant-ID"
+
+// ErrResponseTooLarge is returned when a response body exceeds
+// Config.MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response body too large")
+
 // Config holds the client configuration.
 type Config struct {
 	// BaseURL is the API base URL.
 	BaseURL string
 	// APIKey for authentication.
 	APIKey string
-	// AccessToken for JWT authentication.
+	// AccessToken for JWT authentication. It seeds the token store.
 	AccessToken string
+	// TokenStore holds the access and refresh tokens. Defaults to an
+	// in-memory store.
+	TokenStore auth.TokenStore
+	// Signer signs every request instead of sending APIKey or a bearer
+	// token, e.g. auth.HMACSigner for deployments that forbid credentials
+	// in transit logs or auth.SigV4Signer behind API Gateway with IAM
+	// authorization. It takes precedence over other auth.
+	Signer auth.Signer
+	// Credentials resolves credentials when neither APIKey nor AccessToken
+	// is set, e.g. auth.DefaultChain. It is consulted once, on first use.
+	Credentials auth.CredentialsProvider
+	// RefreshMargin is how long before expiry the access token is
+	// refreshed using the stored refresh token. Zero disables proactive
+	// refresh.
+	RefreshMargin time.Duration
 	// Timeout for HTTP requests.
 	Timeout time.Duration
-	// HTTPClient allows using a custom HTTP client.
+	// OperationTimeouts overrides Timeout for classes of requests, e.g. a
+	// short bound on reads and a long one on writes that wait for a
+	// workflow. Streaming connections ignore Timeout, so
+	// OperationStreamConnect is the only bound on opening them.
+	OperationTimeouts map[OperationClass]time.Duration
+	// HTTPClient allows using a custom HTTP client. Transport settings
+	// such as TLSConfig are ignored when it is set.
 	HTTPClient *http.Client
+	// TLSConfig configures TLS for the default transport, e.g. client
+	// certificates for mutual TLS or a custom root CA pool.
+	TLSConfig *tls.Config
+	// Proxy selects the proxy for each request of the default transport.
+	// Nil uses the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables. Credentials in the proxy URL's user info are sent to the
+	// proxy.
+	Proxy func(*http.Request) (*url.URL, error)
+	// MaxIdleConns caps idle connections across all hosts for the default
+	// transport. Zero keeps the net/http default.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost caps idle connections kept per host. Zero keeps
+	// the net/http default of 2, which is low for high-throughput services.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept. Zero keeps
+	// the net/http default.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout bounds the TLS handshake. Zero keeps the
+	// net/http default.
+	TLSHandshakeTimeout time.Duration
+	// DisableHTTP2 pins the default transport to HTTP/1.1. HTTP/2 is
+	// otherwise negotiated over TLS, including with a custom TLSConfig.
+	DisableHTTP2 bool
 	// MaxRetries is the maximum number of retries for failed requests.
 	MaxRetries int
 	// RetryWaitMin is the minimum wait time between retries.
 	RetryWaitMin time.Duration
 	// RetryWaitMax is the maximum wait time between retries.
 	RetryWaitMax time.Duration
+	// RetryPolicy overrides MaxRetries, RetryWaitMin and RetryWaitMax per
+	// request, e.g. to never retry SendMessage but retry health checks
+	// aggressively.
+	RetryPolicy RetryPolicyFunc
+	// ShouldRetry decides which failures are retried, e.g. to retry
+	// errors of a custom transport. Defaults to DefaultRetryable.
+	ShouldRetry RetryableFunc
+	// StreamReconnectAttempts is the maximum number of times a dropped
+	// stream is resumed using Last-Event-ID. Zero disables reconnection.
+	StreamReconnectAttempts int
+	// StreamIdleTimeout fails a stream with streaming.ErrStreamStalled when
+	// nothing, not even a ping, arrives for this long. Zero disables it.
+	StreamIdleTimeout time.Duration
+	// UserAgent identifies the application. It is sent ahead of
+	// DefaultUserAgent in the User-Agent header.
+	UserAgent string
+	// Headers are added to every request. Headers set by the client, such
+	// as authentication, take precedence.
+	Headers http.Header
+	// DebugWriter receives a transcript of every request and response:
+	// method, URL, headers and the start of bodies, with credentials and
+	// tokens masked, and timing. Useful when reporting API bugs.
+	DebugWriter io.Writer
+	// MaxResponseBytes bounds the size of a response body the client will
+	// read, after decompression. Zero means no limit.
+	MaxResponseBytes int64
+	// Cache enables conditional GET requests. Responses carrying an ETag
+	// or Last-Modified header are cached and revalidated with
+	// If-None-Match/If-Modified-Since; a 304 is served from the cache.
+	Cache ResponseCache
+	// CacheTTL serves cached GET responses younger than this without
+	// contacting the server, and caches responses lacking validators
+	// too. Writes made through the client drop the cached copies of the
+	// resource they change and of its enclosing resources, whatever their
+	// query; other changes show after at most CacheTTL.
+	// Zero always revalidates.
+	CacheTTL time.Duration
+	// CoalesceRequests makes concurrent identical GETs share a single
+	// round trip, e.g. many goroutines polling the same workflow run.
+	CoalesceRequests bool
+	// OfflineQueue enables offline mode: mutating calls that cannot reach
+	// the server are queued and fail with ErrQueued, to be sent later by
+	// ReplayQueue with their original idempotency keys.
+	OfflineQueue RequestQueue
+	// OnReplay is called with the outcome of each replayed request.
+	OnReplay func(ReplayResult)
+	// CompressionThreshold is the request body size, in bytes, above which
+	// bodies are gzip-compressed. Zero disables request compression.
+	CompressionThreshold int
+	// TenantID scopes every request to a tenant of a multi-tenant
+	// deployment via the X-Tenant-ID header.
+	TenantID string
+	// DisabledFeatures lists optional features to force-disable. Features
+	// named in the COPILOT_DISABLED_FEATURES environment variable are
+	// disabled as well.
+	DisabledFeatures []Feature
+	// WireFormat selects the encoding of request and response bodies.
+	// FormatMsgpack asks for MessagePack, falling back to JSON while the
+	// server does not advertise support. Defaults to FormatJSON.
+	WireFormat WireFormat
+	// TraceContext returns the trace context of the operation a request
+	// is made for, e.g. from an OpenTelemetry span in ctx. When unset or
+	// returning false, the trace context stored by WithTraceContext is
+	// used.
+	TraceContext func(ctx context.Context) (TraceContext, bool)
+	// MemoryRetrieval recalls agent memories for every message sent
+	// without its own MessageCreate.Memory.
+	MemoryRetrieval *models.MemoryRetrieval
 }
 
+// Option modifies a Config.
+type Option func(*Config)
+
 // DefaultConfig returns a default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		BaseURL:      "http://localhost:8080",
-		Timeout:      30 * time.Second,
-		MaxRetries:   3,
-		RetryWaitMin: 1 * time.Second,
-		RetryWaitMax: 30 * time.Second,
+		BaseURL:                 "http://localhost:8080",
+		Timeout:                 30 * time.Second,
+		MaxRetries:              3,
+		RetryWaitMin:            1 * time.Second,
+		RetryWaitMax:            30 * time.Second,
+		RefreshMargin:           60 * time.Second,
+		StreamReconnectAttempts: 3,
+		StreamIdleTimeout:       60 * time.Second,
+		CompressionThreshold:    DefaultCompressionThreshold,
+		MaxResponseBytes:        DefaultMaxResponseBytes,
 	}
 }
 
@@ -49,6 +190,34 @@ func DefaultConfig() *Config {
 type Client struct {
 	config     *Config
 	httpClient *http.Client
+	features   *featureSet
+	wire       *wireState
+	cacheKeys  *cacheIndex
+
+	// session is shared with copies made by ForTenant.
+	session *session
+
+	// inflightMu guards the GETs shared by CoalesceRequests.
+	inflightMu sync.Mutex
+	inflight   map[string]*inflightCall
+}
+
+// session holds the authentication state shared by a client and its
+// tenant-scoped copies, so they refresh and store tokens as one.
+type session struct {
+	// tokenMu serializes access to the token store.
+	tokenMu sync.Mutex
+	tokens  auth.TokenStore
+	// tokenChanged is closed and replaced whenever the client stores a
+	// token, waking StartTokenRefresher.
+	tokenChanged chan struct{}
+	// refreshMu serializes proactive token refreshes.
+	refreshMu sync.Mutex
+
+	// credsMu guards the credentials resolved from Config.Credentials.
+	credsMu  sync.Mutex
+	creds    *auth.Credentials
+	resolved bool
 }
 
 // New creates a new CoPilot client with the given configuration.
@@ -59,17 +228,93 @@ func New(config *Config) *Client {
 
 	httpClient := config.HTTPClient
 	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: config.Timeout,
+		httpClient = newHTTPClient(config)
+	}
+	if config.DebugWriter != nil {
+		httpClient = withDebugTranscript(httpClient, config.DebugWriter)
+	}
+
+	tokens := config.TokenStore
+	if tokens == nil {
+		var token *auth.Token
+		if config.AccessToken != "" {
+			token = &auth.Token{AccessToken: config.AccessToken}
 		}
+		tokens = auth.NewMemoryStore(token)
 	}
 
 	return &Client{
 		config:     config,
 		httpClient: httpClient,
+		features:   newFeatureSet(config),
+		wire:       &wireState{},
+		cacheKeys:  newCacheIndex(),
+		session:    &session{tokens: tokens, tokenChanged: make(chan struct{})},
+	}
+}
+
+// ForTenant returns a copy of the client whose requests are scoped to
+// tenantID. The copy shares the connection pool, credentials and tokens,
+// so one credential can serve many tenants.
+func (c *Client) ForTenant(tenantID string) *Client {
+	config := *c.config
+	config.TenantID = tenantID
+	return &Client{
+		config:     &config,
+		httpClient: c.httpClient,
+		features:   c.features,
+		wire:       c.wire,
+		cacheKeys:  c.cacheKeys,
+		session:    c.session,
 	}
 }
 
+// With returns a copy of the client with opts applied to its
+// configuration, for variations such as a longer timeout, extra headers or
+// another tenant. The copy shares the connection pool, credentials and
+// tokens; options that configure the transport itself, such as TLS or
+// proxy settings, have no effect on it. Features the options disable are
+// disabled on the copy only, and later runtime changes to either client's
+// features do not affect the other.
+func (c *Client) With(opts ...Option) *Client {
+	config := *c.config
+	config.Headers = c.config.Headers.Clone()
+	config.DisabledFeatures = append([]Feature(nil), c.config.DisabledFeatures...)
+	if c.config.TLSConfig != nil {
+		config.TLSConfig = c.config.TLSConfig.Clone()
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	httpClient := c.httpClient
+	switch {
+	case config.HTTPClient != c.config.HTTPClient && config.HTTPClient != nil:
+		httpClient = config.HTTPClient
+	case config.Timeout != c.config.Timeout:
+		copied := *c.httpClient
+		copied.Timeout = config.Timeout
+		httpClient = &copied
+	}
+	if config.DebugWriter != nil || c.config.DebugWriter != nil {
+		httpClient = withDebugTranscript(httpClient, config.DebugWriter)
+	}
+
+	return &Client{
+		config:     &config,
+		httpClient: httpClient,
+		features:   c.features.clone(addedFeatures(c.config.DisabledFeatures, config.DisabledFeatures)),
+		wire:       c.wire,
+		cacheKeys:  c.cacheKeys,
+		session:    c.session,
+	}
+}
+
+// TenantID returns the tenant the client's requests are scoped to.
+func (c *Client) TenantID() string {
+	return c.config.TenantID
+}
+
 // NewWithAPIKey creates a new client with API key authentication.
 func NewWithAPIKey(baseURL, apiKey string) *Client {
 	config := DefaultConfig()
@@ -86,24 +331,138 @@ func NewWithToken(baseURL, accessToken string) *Client {
 	return New(config)
 }
 
-// SetAccessToken updates the access token.
-func (c *Client) SetAccessToken(token string) {
-	c.config.AccessToken = token
+// SetAccessToken updates the access token, keeping any stored refresh token.
+func (c *Client) SetAccessToken(token string) error {
+	c.session.tokenMu.Lock()
+	defer c.session.tokenMu.Unlock()
+
+	current, err := c.session.tokens.Get()
+	if err != nil {
+		return fmt.Errorf("failed to load token: %w", err)
+	}
+	if current == nil {
+		current = &auth.Token{}
+	}
+	current.AccessToken = token
+	current.ExpiresAt = time.Time{}
+	defer c.session.notifyTokenChanged()
+	return c.session.tokens.Set(current)
+}
+
+// BaseURL returns the API base URL the client talks to.
+func (c *Client) BaseURL() string {
+	return c.config.BaseURL
+}
+
+// AccessToken returns the current access token, or "" if none is stored.
+func (c *Client) AccessToken() (string, error) {
+	token, err := c.token()
+	if err != nil || token == nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// token returns the stored token.
+func (c *Client) token() (*auth.Token, error) {
+	c.session.tokenMu.Lock()
+	defer c.session.tokenMu.Unlock()
+	return c.session.tokens.Get()
+}
+
+// tokenWithChanges returns the stored token and a channel closed when
+// the client next stores one.
+func (c *Client) tokenWithChanges() (*auth.Token, <-chan struct{}, error) {
+	c.session.tokenMu.Lock()
+	defer c.session.tokenMu.Unlock()
+	token, err := c.session.tokens.Get()
+	return token, c.session.tokenChanged, err
+}
+
+// storeToken replaces the stored token.
+func (c *Client) storeToken(token *auth.Token) error {
+	c.session.tokenMu.Lock()
+	defer c.session.tokenMu.Unlock()
+	defer c.session.notifyTokenChanged()
+	return c.session.tokens.Set(token)
 }
 
-// request makes an HTTP request with retry logic.
+// clearToken removes the stored token.
+func (c *Client) clearToken() error {
+	c.session.tokenMu.Lock()
+	defer c.session.tokenMu.Unlock()
+	defer c.session.notifyTokenChanged()
+	return c.session.tokens.Delete()
+}
+
+// notifyTokenChanged wakes waiters on the token. tokenMu must be held.
+func (s *session) notifyTokenChanged() {
+	close(s.tokenChanged)
+	s.tokenChanged = make(chan struct{})
+}
+
+// tokenFromResponse converts a token response into a stored token.
+func tokenFromResponse(pair models.TokenPair) *auth.Token {
+	now := time.Now()
+	token := &auth.Token{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    pair.TokenType,
+	}
+	if pair.ExpiresIn > 0 {
+		token.ExpiresAt = now.Add(time.Duration(pair.ExpiresIn) * time.Second)
+	}
+	if pair.RefreshExpiresIn > 0 {
+		token.RefreshExpiresAt = now.Add(time.Duration(pair.RefreshExpiresIn) * time.Second)
+	}
+	return token
+}
+
+// request makes an HTTP request with retry logic, queueing mutating calls
+// made while offline when an offline queue is configured.
 func (c *Client) request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	// Retries of a call share its request ID
+	if _, ok := RequestIDFromContext(ctx); !ok {
+		ctx = WithRequestID(ctx, NewRequestID())
+	}
+	if method == http.MethodGet && c.config.CoalesceRequests {
+		return c.coalesce(ctx, path, result)
+	}
+	if !c.queueable(method, path, body) {
+		return c.send(ctx, method, path, body, result)
+	}
+
+	// Queued requests must be replayable exactly once
+	if _, ok := IdempotencyKeyFromContext(ctx); !ok {
+		ctx = WithIdempotencyKey(ctx, NewIdempotencyKey())
+	}
+	err := c.send(ctx, method, path, body, result)
+	if err != nil && isOffline(ctx, err) {
+		return c.enqueue(ctx, method, path, body, err)
+	}
+	return err
+}
+
+// send makes a request, retrying transient failures.
+func (c *Client) send(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	if err := c.ensureFreshToken(ctx); err != nil {
+		return err
+	}
+
+	policy := c.retryPolicy(method, path)
+
 	// If retries are disabled (MaxRetries < 0), just make a single request
-	if c.config.MaxRetries < 0 {
-		return c.doRequest(ctx, method, path, body, result)
+	if policy.MaxRetries < 0 {
+		_, err := c.doRequest(ctx, method, path, body, result)
+		return err
 	}
 
 	var lastErr error
 
-	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
 		if attempt > 0 {
 			// Calculate backoff delay
-			delay := c.calculateBackoff(attempt)
+			delay := policy.backoff(attempt)
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -111,7 +470,7 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 			}
 		}
 
-		err := c.doRequest(ctx, method, path, body, result)
+		resp, err := c.doRequest(ctx, method, path, body, result)
 		if err == nil {
 			return nil
 		}
@@ -119,7 +478,7 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 		lastErr = err
 
 		// Check if error is retryable
-		if !c.isRetryable(err) {
+		if !c.isRetryable(err, resp) {
 			return err
 		}
 	}
@@ -127,90 +486,369 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 	return fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-// doRequest performs a single HTTP request.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+// doRequest performs a single HTTP request. It returns the response, its
+// body consumed, or nil when none arrived or the cache answered.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) (*http.Response, error) {
+	ctx, cancel, httpClient := c.withOperationTimeout(ctx, method)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey, cached := c.cacheLookup(req)
+	if c.fresh(cached) {
+		if result != nil && len(cached.Body) > 0 {
+			if err := json.Unmarshal(cached.Body, result); err != nil {
+				return nil, fmt.Errorf("failed to parse cached response: %w", err)
+			}
+		}
+		return nil, nil
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if err := decompressResponse(resp); err != nil {
+		resp.Body.Close()
+		return resp, err
+	}
+	if c.rejectedMsgpack(req, resp) {
+		resp.Body.Close()
+		return c.doRequest(ctx, method, path, body, result)
+	}
+	if err := c.decodeWireFormat(resp); err != nil {
+		resp.Body.Close()
+		return resp, err
+	}
+	defer resp.Body.Close()
+	respReader := c.limitBody(resp.Body)
+
+	// Serve revalidated responses from the cache
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		io.Copy(io.Discard, respReader)
+		if c.config.CacheTTL > 0 {
+			revalidated := *cached
+			revalidated.StoredAt = time.Now()
+			c.config.Cache.Set(cacheKey, &revalidated)
+		}
+		if result != nil && len(cached.Body) > 0 {
+			if err := json.Unmarshal(cached.Body, result); err != nil {
+				return resp, fmt.Errorf("failed to parse cached response: %w", err)
+			}
+		}
+		return resp, nil
+	}
+
+	// Handle error responses
+	if resp.StatusCode >= 400 {
+		respBody, err := io.ReadAll(respReader)
+		if err != nil {
+			return resp, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return resp, parseErrorResponse(resp, respBody)
+	}
+	c.cacheInvalidate(req)
+
+	// Cacheable responses are buffered so they can be stored
+	if cacheKey != "" {
+		respBody, err := io.ReadAll(respReader)
+		if err != nil {
+			return resp, fmt.Errorf("failed to read response body: %w", err)
+		}
+		c.cacheStore(req, cacheKey, resp, respBody)
+		if result != nil && len(respBody) > 0 {
+			if err := json.Unmarshal(respBody, result); err != nil {
+				return resp, fmt.Errorf("failed to parse response: %w", err)
+			}
+		}
+		return resp, nil
+	}
+
+	// Decode successful response straight from the body
+	if result != nil {
+		if err := json.NewDecoder(respReader).Decode(result); err != nil && err != io.EOF {
+			if errors.Is(err, ErrResponseTooLarge) {
+				return resp, err
+			}
+			return resp, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	// Drain what is left so the connection can be reused
+	if _, err := io.Copy(io.Discard, respReader); err != nil {
+		return resp, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return resp, nil
+}
+
+// limitBody bounds r by Config.MaxResponseBytes.
+func (c *Client) limitBody(r io.Reader) io.Reader {
+	if c.config.MaxResponseBytes <= 0 {
+		return r
+	}
+	return &maxBytesReader{r: r, n: c.config.MaxResponseBytes}
+}
+
+// maxBytesReader reads at most n bytes, failing with ErrResponseTooLarge
+// if more are available.
+type maxBytesReader struct {
+	r io.Reader
+	n int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if m.n <= 0 {
+		var probe [1]byte
+		n, err := m.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > m.n {
+		p = p[:m.n]
+	}
+	n, err := m.r.Read(p)
+	m.n -= int64(n)
+	return n, err
+}
+
+// newRequest builds an HTTP request with the encoded body and authentication headers.
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
 	fullURL := c.config.BaseURL + path
 
 	var bodyReader io.Reader
+	var payload []byte
+	var compressed bool
+	contentType := c.requestFormat()
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		if contentType == ContentTypeMsgpack {
+			if payload, err = jsonToMsgpack(payload); err != nil {
+				return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			}
 		}
-		bodyReader = bytes.NewReader(jsonBody)
+		if payload, compressed, err = c.compressBody(payload); err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(payload)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	for key, values := range c.config.Headers {
+		req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", c.acceptHeader())
+	req.Header.Set("Accept-Encoding", "gzip")
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	if err := c.authorize(ctx, req); err != nil {
+		return nil, err
+	}
+	if c.config.TenantID != "" {
+		req.Header.Set(TenantIDHeader, c.config.TenantID)
+	}
+
+	requestID, ok := RequestIDFromContext(ctx)
+	if !ok {
+		requestID = NewRequestID()
+	}
+	req.Header.Set(RequestIDHeader, requestID)
+	if correlationID, ok := CorrelationIDFromContext(ctx); ok {
+		req.Header.Set(CorrelationIDHeader, correlationID)
+	}
+	if tc, ok := c.traceContext(ctx); ok {
+		req.Header.Set(TraceparentHeader, tc.Traceparent)
+		if tc.Tracestate != "" {
+			req.Header.Set(TracestateHeader, tc.Tracestate)
+		}
+	}
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if scopes := scopesHeaderValue(ctx); scopes != "" {
+		req.Header.Set(ScopesHeader, scopes)
+	}
+
+	// Sign last so that signers may cover any header set above.
+	if c.config.Signer != nil {
+		if err := c.config.Signer.Sign(req, payload); err != nil {
+			return nil, fmt.Errorf("failed to sign request: %w", err)
+		}
+	}
+	reportUpload(req)
+
+	return req, nil
+}
+
+// userAgent returns the User-Agent header value.
+func (c *Client) userAgent() string {
+	if c.config.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return c.config.UserAgent + " " + DefaultUserAgent
+}
 
-	if c.config.APIKey != "" {
-		req.Header.Set("X-API-Key", c.config.APIKey)
-	} else if c.config.AccessToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.config.AccessToken)
+// authorize sets the authentication header on req. Signed requests carry
+// no static credential.
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
+	if c.config.Signer != nil {
+		return nil
 	}
 
-	resp, err := c.httpClient.Do(req)
+	apiKey, err := c.apiKey(ctx)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
+	}
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+		return nil
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	token, err := c.token()
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return fmt.Errorf("failed to load access token: %w", err)
+	}
+	if token != nil && token.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
 	}
+	return nil
+}
 
-	// Handle error responses
-	if resp.StatusCode >= 400 {
-		var apiErr models.APIError
-		if err := json.Unmarshal(respBody, &apiErr); err != nil {
-			return &CoPilotError{
-				StatusCode: resp.StatusCode,
-				Message:    string(respBody),
+// apiKey returns the configured API key, resolving Config.Credentials on
+// first use. An access token from the provider seeds an empty token store.
+func (c *Client) apiKey(ctx context.Context) (string, error) {
+	if c.config.APIKey != "" || c.config.Credentials == nil {
+		return c.config.APIKey, nil
+	}
+
+	c.session.credsMu.Lock()
+	defer c.session.credsMu.Unlock()
+
+	if !c.session.resolved {
+		// An explicitly configured or previously stored token wins.
+		if current, err := c.token(); err == nil && current != nil && current.AccessToken != "" {
+			c.session.resolved = true
+			return "", nil
+		}
+
+		creds, err := c.config.Credentials.Credentials(ctx)
+		if err != nil && !errors.Is(err, auth.ErrNoCredentials) {
+			return "", fmt.Errorf("failed to resolve credentials: %w", err)
+		}
+		if creds != nil && creds.APIKey == "" && creds.AccessToken != "" {
+			if err := c.storeToken(&auth.Token{AccessToken: creds.AccessToken}); err != nil {
+				return "", fmt.Errorf("failed to store token: %w", err)
 			}
 		}
+		c.session.creds = creds
+		c.session.resolved = true
+	}
+
+	if c.session.creds != nil {
+		return c.session.creds.APIKey, nil
+	}
+	return "", nil
+}
+
+// parseErrorResponse converts an error response into a CoPilotError.
+func parseErrorResponse(resp *http.Response, respBody []byte) error {
+	var apiErr models.APIError
+	if err := json.Unmarshal(respBody, &apiErr); err != nil {
 		return &CoPilotError{
-			StatusCode: resp.StatusCode,
-			Code:       apiErr.Code,
-			Message:    apiErr.Message,
-			Details:    apiErr.Details,
-			RequestID:  apiErr.RequestID,
+			StatusCode:    resp.StatusCode,
+			Message:       string(respBody),
+			RequestID:     responseRequestID(resp, ""),
+			CorrelationID: resp.Header.Get(CorrelationIDHeader),
 		}
 	}
+	correlationID := apiErr.CorrelationID
+	if correlationID == "" {
+		correlationID = resp.Header.Get(CorrelationIDHeader)
+	}
+	return &CoPilotError{
+		StatusCode:    resp.StatusCode,
+		Code:          apiErr.Code,
+		Message:       apiErr.Message,
+		Details:       apiErr.Details,
+		FieldErrors:   fieldErrors(&apiErr),
+		RequestID:     responseRequestID(resp, apiErr.RequestID),
+		CorrelationID: correlationID,
+	}
+}
+
+// responseRequestID returns the request ID of a response: the one in its
+// body or headers, or else the one it was requested with.
+func responseRequestID(resp *http.Response, fromBody string) string {
+	if fromBody != "" {
+		return fromBody
+	}
+	if id := resp.Header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+	if resp.Request != nil {
+		return resp.Request.Header.Get(RequestIDHeader)
+	}
+	return ""
+}
+
+// download performs a GET request and copies the raw response body to w.
+func (c *Client) download(ctx context.Context, path string, w io.Writer) error {
+	ctx, cancel, httpClient := c.withOperationTimeout(ctx, http.MethodGet)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode < 400 {
+		reportDownload(ctx, resp)
+	}
+	if err := decompressResponse(resp); err != nil {
+		resp.Body.Close()
+		return err
+	}
+	defer resp.Body.Close()
 
-	// Parse successful response
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
+	if resp.StatusCode >= 400 {
+		respBody, err := io.ReadAll(c.limitBody(resp.Body))
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
 		}
+		return parseErrorResponse(resp, respBody)
 	}
 
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
 	return nil
 }
 
 // calculateBackoff calculates the backoff delay for the given attempt.
 func (c *Client) calculateBackoff(attempt int) time.Duration {
-	delay := c.config.RetryWaitMin * time.Duration(1<<uint(attempt-1))
-	if delay > c.config.RetryWaitMax {
-		delay = c.config.RetryWaitMax
-	}
-	return delay
-}
-
-// isRetryable checks if an error should be retried.
-func (c *Client) isRetryable(err error) bool {
-	if copilotErr, ok := err.(*CoPilotError); ok {
-		// Retry on server errors and rate limits
-		return copilotErr.StatusCode >= 500 || copilotErr.StatusCode == 429
-	}
-	return false
+	return c.defaultRetryPolicy().backoff(attempt)
 }
 
 // get performs a GET request.
@@ -223,6 +861,11 @@ func (c *Client) post(ctx context.Context, path string, body interface{}, result
 	return c.request(ctx, http.MethodPost, path, body, result)
 }
 
+// patch performs a PATCH request.
+func (c *Client) patch(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.request(ctx, http.MethodPatch, path, body, result)
+}
+
 // delete performs a DELETE request.
 func (c *Client) delete(ctx context.Context, path string) error {
 	return c.request(ctx, http.MethodDelete, path, nil, nil)
@@ -230,11 +873,13 @@ func (c *Client) delete(ctx context.Context, path string) error {
 
 // CoPilotError represents an API error.
 type CoPilotError struct {
-	StatusCode int
-	Code       string
-	Message    string
-	Details    map[string]interface{}
-	RequestID  string
+	StatusCode    int
+	Code          string
+	Message       string
+	Details       map[string]interface{}
+	FieldErrors   []models.FieldError
+	RequestID     string
+	CorrelationID string
 }
 
 // Error implements the error interface.
@@ -270,6 +915,12 @@ func (e *CoPilotError) IsServerError() bool {
 	return e.StatusCode >= 500
 }
 
+// Retryable reports whether the request may be retried: server errors and
+// rate limits are transient.
+func (e *CoPilotError) Retryable() bool {
+	return e.IsServerError() || e.IsRateLimited()
+}
+
 // ================================
 // Authentication Methods
 // ================================
@@ -285,11 +936,22 @@ func (c *Client) Login(ctx context.Context, usernameOrEmail, password string) (*
 	if err := c.post(ctx, "/api/v1/auth/login", req, &resp); err != nil {
 		return nil, err
 	}
+	return c.storeLogin(&resp)
+}
 
-	// Store the access token for subsequent requests
-	c.config.AccessToken = resp.AccessToken
-
-	return &resp, nil
+// storeLogin stores the tokens of a login response for subsequent
+// requests.
+func (c *Client) storeLogin(resp *models.LoginResponse) (*models.LoginResponse, error) {
+	if err := c.storeToken(tokenFromResponse(models.TokenPair{
+		AccessToken:      resp.AccessToken,
+		RefreshToken:     resp.RefreshToken,
+		TokenType:        resp.TokenType,
+		ExpiresIn:        resp.ExpiresIn,
+		RefreshExpiresIn: resp.RefreshExpiresIn,
+	})); err != nil {
+		return nil, fmt.Errorf("failed to store token: %w", err)
+	}
+	return resp, nil
 }
 
 // RefreshTokens refreshes the access tokens.
@@ -297,11 +959,13 @@ func (c *Client) RefreshTokens(ctx context.Context, refreshToken string) (*model
 	req := map[string]string{"refresh_token": refreshToken}
 
 	var resp models.TokenPair
-	if err := c.post(ctx, "/api/v1/auth/refresh", req, &resp); err != nil {
+	if err := c.post(withoutTokenRefresh(ctx), "/api/v1/auth/refresh", req, &resp); err != nil {
 		return nil, err
 	}
 
-	c.config.AccessToken = resp.AccessToken
+	if err := c.storeToken(tokenFromResponse(resp)); err != nil {
+		return nil, fmt.Errorf("failed to store token: %w", err)
+	}
 	return &resp, nil
 }
 
@@ -310,8 +974,7 @@ func (c *Client) Logout(ctx context.Context) error {
 	if err := c.post(ctx, "/api/v1/auth/logout", nil, nil); err != nil {
 		return err
 	}
-	c.config.AccessToken = ""
-	return nil
+	return c.clearToken()
 }
 
 // GetCurrentUser returns the current authenticated user.
@@ -341,66 +1004,216 @@ func (c *Client) CreateConversation(ctx context.Context, req *models.Conversatio
 }
 
 // GetConversation retrieves a conversation by ID.
-func (c *Client) GetConversation(ctx context.Context, id string) (*models.Conversation, error) {
+func (c *Client) GetConversation(ctx context.Context, id models.ConversationID) (*models.Conversation, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+
 	var conv models.Conversation
-	if err := c.get(ctx, "/api/v1/conversations/"+id, &conv); err != nil {
+	if err := c.get(ctx, "/api/v1/conversations/"+id.String(), &conv); err != nil {
 		return nil, err
 	}
 	return &conv, nil
 }
 
-// ListConversations lists conversations with pagination.
-func (c *Client) ListConversations(ctx context.Context, limit, offset int) ([]models.Conversation, error) {
-	path := fmt.Sprintf("/api/v1/conversations?limit=%d&offset=%d", limit, offset)
+// GetConversationWithMessages retrieves a conversation together with its
+// first messageLimit messages in a single request. Servers that do not
+// support the messages expansion are handled with a second request.
+func (c *Client) GetConversationWithMessages(ctx context.Context, id models.ConversationID, messageLimit int) (*models.ConversationWithMessages, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
 
-	var resp struct {
-		Items []models.Conversation `json:"items"`
+	params := url.Values{"include": {"messages"}}
+	if messageLimit > 0 {
+		params.Set("message_limit", strconv.Itoa(messageLimit))
 	}
-	if err := c.get(ctx, path, &resp); err != nil {
+
+	var conv models.ConversationWithMessages
+	if err := c.get(ctx, "/api/v1/conversations/"+id.String()+"?"+params.Encode(), &conv); err != nil {
 		return nil, err
 	}
-	return resp.Items, nil
+	if conv.Messages == nil {
+		page, err := c.ListMessages(ctx, id, &MessageListOptions{ListOptions: ListOptions{Limit: messageLimit}})
+		if err != nil {
+			return nil, err
+		}
+		conv.Messages = page.Items
+	}
+	return &conv, nil
+}
+
+// ListConversations returns a page of conversations. Pass the returned
+// NextCursor in opts to fetch the following page, or use a Pager.
+func (c *Client) ListConversations(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.Conversation], error) {
+	return List[models.Conversation](ctx, c, "/api/v1/conversations", opts)
+}
+
+// SetSystemPrompt replaces a conversation's system prompt. It applies to
+// replies generated from then on; an empty prompt clears it.
+func (c *Client) SetSystemPrompt(ctx context.Context, id models.ConversationID, prompt string) (*models.Conversation, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{"system_prompt": prompt}
+
+	var conv models.Conversation
+	if err := c.patch(ctx, "/api/v1/conversations/"+id.String(), req, &conv); err != nil {
+		return nil, err
+	}
+	return &conv, nil
 }
 
 // DeleteConversation deletes a conversation.
-func (c *Client) DeleteConversation(ctx context.Context, id string) error {
-	return c.delete(ctx, "/api/v1/conversations/"+id)
+func (c *Client) DeleteConversation(ctx context.Context, id models.ConversationID) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	return c.delete(ctx, "/api/v1/conversations/"+id.String())
 }
 
-// SendMessage sends a message in a conversation.
-func (c *Client) SendMessage(ctx context.Context, conversationID, content string) (*models.Message, error) {
-	req := models.MessageCreate{
+// SendMessage sends a message in a conversation. Relevant agent memories
+// are recalled as configured by Config.MemoryRetrieval.
+func (c *Client) SendMessage(ctx context.Context, conversationID models.ConversationID, content string) (*models.Message, error) {
+	return c.CreateMessage(ctx, conversationID, &models.MessageCreate{
 		Role:    models.RoleUser,
 		Content: content,
+	})
+}
+
+// CreateMessage sends a message with structured content, metadata or a
+// parent message in a conversation and returns the assistant's response.
+func (c *Client) CreateMessage(ctx context.Context, conversationID models.ConversationID, req *models.MessageCreate) (*models.Message, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
 	}
 
 	var msg models.Message
 	path := fmt.Sprintf("/api/v1/conversations/%s/messages", conversationID)
-	if err := c.post(ctx, path, req, &msg); err != nil {
+	if err := c.post(ctx, path, c.withMemory(req), &msg); err != nil {
 		return nil, err
 	}
 	return &msg, nil
 }
 
-// ListMessages lists messages in a conversation.
-func (c *Client) ListMessages(ctx context.Context, conversationID string, limit, offset int) ([]models.Message, error) {
-	path := fmt.Sprintf("/api/v1/conversations/%s/messages?limit=%d&offset=%d", conversationID, limit, offset)
+// SendMessageWithRetrieval sends a message after the server has searched
+// the context items for the passages most relevant to it and attached
+// them to the model's context. The reply lists them in RetrievedContext.
+func (c *Client) SendMessageWithRetrieval(ctx context.Context, conversationID models.ConversationID, content string, opts models.RetrievalOptions) (*models.Message, error) {
+	return c.CreateMessage(ctx, conversationID, &models.MessageCreate{
+		Role:      models.RoleUser,
+		Content:   content,
+		Retrieval: &opts,
+	})
+}
+
+// withMemory applies Config.MemoryRetrieval to a message that does not
+// choose its own.
+func (c *Client) withMemory(req *models.MessageCreate) *models.MessageCreate {
+	if req.Memory != nil || c.config.MemoryRetrieval == nil {
+		return req
+	}
+	withMemory := *req
+	withMemory.Memory = c.config.MemoryRetrieval
+	return &withMemory
+}
+
+// ReplyInThread sends a reply to a message, starting or continuing its
+// thread, and returns the assistant's response in the thread.
+func (c *Client) ReplyInThread(ctx context.Context, conversationID models.ConversationID, parentID models.MessageID, content string) (*models.Message, error) {
+	if err := parentID.Validate(); err != nil {
+		return nil, err
+	}
+	return c.CreateMessage(ctx, conversationID, &models.MessageCreate{
+		Role:            models.RoleUser,
+		Content:         content,
+		ParentMessageID: parentID,
+	})
+}
+
+// ListThread returns the replies to a message, oldest first.
+func (c *Client) ListThread(ctx context.Context, messageID models.MessageID) ([]models.Message, error) {
+	if err := messageID.Validate(); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/api/v1/messages/%s/thread", messageID)
+	fetch := func(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.Message], error) {
+		return List[models.Message](ctx, c, path, opts)
+	}
+	return NewPager(fetch, nil).All(ctx)
+}
+
+// ListMessages returns a page of messages in a conversation, leaving out
+// thread replies. Set opts.Since to fetch only messages created after a
+// sync checkpoint.
+func (c *Client) ListMessages(ctx context.Context, conversationID models.ConversationID, opts *MessageListOptions) (*models.PaginatedResponse[models.Message], error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/api/v1/conversations/%s/messages", conversationID)
+	return List[models.Message](ctx, c, path, opts.listOptions())
+}
+
+// RequestHumanHandoff escalates a conversation to the human agent queue.
+func (c *Client) RequestHumanHandoff(ctx context.Context, conversationID models.ConversationID, reason string) (*models.Conversation, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{"reason": reason}
+
+	var conv models.Conversation
+	path := fmt.Sprintf("/api/v1/conversations/%s/handoff", conversationID)
+	if err := c.post(ctx, path, req, &conv); err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
 
-	var resp struct {
-		Items []models.Message `json:"items"`
+// AssignAgent assigns a human agent to a conversation awaiting handoff.
+func (c *Client) AssignAgent(ctx context.Context, conversationID models.ConversationID, agentID string) (*models.Conversation, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
 	}
-	if err := c.get(ctx, path, &resp); err != nil {
+
+	req := map[string]string{"agent_id": agentID}
+
+	var conv models.Conversation
+	path := fmt.Sprintf("/api/v1/conversations/%s/handoff/assign", conversationID)
+	if err := c.post(ctx, path, req, &conv); err != nil {
 		return nil, err
 	}
-	return resp.Items, nil
+	return &conv, nil
+}
+
+// ResolveHandoff marks a human handoff as resolved.
+func (c *Client) ResolveHandoff(ctx context.Context, conversationID models.ConversationID, resolution string) (*models.Conversation, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{"resolution": resolution}
+
+	var conv models.Conversation
+	path := fmt.Sprintf("/api/v1/conversations/%s/handoff/resolve", conversationID)
+	if err := c.post(ctx, path, req, &conv); err != nil {
+		return nil, err
+	}
+	return &conv, nil
 }
 
 // ================================
 // Workflow Methods
 // ================================
 
-// CreateWorkflow creates a new workflow definition.
+// CreateWorkflow creates a new workflow definition. The definition is
+// validated first, failing with models.ErrInvalidWorkflow.
 func (c *Client) CreateWorkflow(ctx context.Context, req *models.WorkflowDefinitionCreate) (*models.WorkflowDefinition, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	var wf models.WorkflowDefinition
 	if err := c.post(ctx, "/api/v1/workflows", req, &wf); err != nil {
 		return nil, err
@@ -409,32 +1222,49 @@ func (c *Client) CreateWorkflow(ctx context.Context, req *models.WorkflowDefinit
 }
 
 // GetWorkflow retrieves a workflow definition.
-func (c *Client) GetWorkflow(ctx context.Context, id string) (*models.WorkflowDefinition, error) {
+func (c *Client) GetWorkflow(ctx context.Context, id models.WorkflowID) (*models.WorkflowDefinition, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+
 	var wf models.WorkflowDefinition
-	if err := c.get(ctx, "/api/v1/workflows/"+id, &wf); err != nil {
+	if err := c.get(ctx, "/api/v1/workflows/"+id.String(), &wf); err != nil {
 		return nil, err
 	}
 	return &wf, nil
 }
 
-// ListWorkflows lists workflow definitions.
-func (c *Client) ListWorkflows(ctx context.Context) ([]models.WorkflowDefinition, error) {
-	var resp struct {
-		Items []models.WorkflowDefinition `json:"items"`
-	}
-	if err := c.get(ctx, "/api/v1/workflows", &resp); err != nil {
-		return nil, err
-	}
-	return resp.Items, nil
+// ListWorkflows returns a page of workflow definitions.
+func (c *Client) ListWorkflows(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.WorkflowDefinition], error) {
+	return List[models.WorkflowDefinition](ctx, c, "/api/v1/workflows", opts)
 }
 
 // DeleteWorkflow deletes a workflow definition.
-func (c *Client) DeleteWorkflow(ctx context.Context, id string) error {
-	return c.delete(ctx, "/api/v1/workflows/"+id)
+func (c *Client) DeleteWorkflow(ctx context.Context, id models.WorkflowID) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	return c.delete(ctx, "/api/v1/workflows/"+id.String())
 }
 
-// RunWorkflow starts a workflow run.
+// RunWorkflow starts a workflow run. The request is validated first,
+// failing with models.ErrInvalidRun. A request without a ClientToken is
+// sent with one, the context's idempotency key if it has one, so that
+// retries never start the run twice.
 func (c *Client) RunWorkflow(ctx context.Context, req *models.WorkflowRunCreate) (*models.WorkflowRun, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.ClientToken == "" {
+		token, ok := IdempotencyKeyFromContext(ctx)
+		if !ok {
+			token = NewIdempotencyKey()
+		}
+		withToken := *req
+		withToken.ClientToken = token
+		req = &withToken
+	}
+
 	var run models.WorkflowRun
 	if err := c.post(ctx, "/api/v1/workflows/runs", req, &run); err != nil {
 		return nil, err
@@ -442,40 +1272,99 @@ func (c *Client) RunWorkflow(ctx context.Context, req *models.WorkflowRunCreate)
 	return &run, nil
 }
 
+// ValidateRun checks the input data of req against the input schema of its
+// workflow, which it fetches, so that a bad run is caught before it is
+// queued. It returns a *models.SchemaError listing the mismatches.
+func (c *Client) ValidateRun(ctx context.Context, req *models.WorkflowRunCreate) error {
+	wf, err := c.GetWorkflow(ctx, req.WorkflowID)
+	if err != nil {
+		return err
+	}
+	return wf.ValidateInput(req.InputData)
+}
+
 // GetWorkflowRun retrieves a workflow run.
-func (c *Client) GetWorkflowRun(ctx context.Context, id string) (*models.WorkflowRun, error) {
+func (c *Client) GetWorkflowRun(ctx context.Context, id models.RunID) (*models.WorkflowRun, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+
 	var run models.WorkflowRun
-	if err := c.get(ctx, "/api/v1/workflows/runs/"+id, &run); err != nil {
+	if err := c.get(ctx, "/api/v1/workflows/runs/"+id.String(), &run); err != nil {
 		return nil, err
 	}
 	return &run, nil
 }
 
-// ListWorkflowRuns lists workflow runs.
-func (c *Client) ListWorkflowRuns(ctx context.Context, workflowID string) ([]models.WorkflowRun, error) {
-	path := "/api/v1/workflows/runs"
+// ListWorkflowRuns returns a page of workflow runs, limited to one workflow
+// when workflowID is set.
+func (c *Client) ListWorkflowRuns(ctx context.Context, workflowID models.WorkflowID, opts *ListOptions) (*models.PaginatedResponse[models.WorkflowRun], error) {
 	if workflowID != "" {
-		path += "?workflow_id=" + url.QueryEscape(workflowID)
+		opts = opts.withFilter("workflow_id", workflowID.String())
 	}
+	return List[models.WorkflowRun](ctx, c, "/api/v1/workflows/runs", opts)
+}
 
-	var resp struct {
-		Items []models.WorkflowRun `json:"items"`
+// CancelWorkflowRun cancels a workflow run. Unless opts.Force is set,
+// running steps are given the grace period to finish cleanly.
+func (c *Client) CancelWorkflowRun(ctx context.Context, id models.RunID, opts models.CancelOptions) (*models.WorkflowRun, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.GraceSeconds < 0 {
+		return nil, errors.New("grace period is negative")
 	}
-	if err := c.get(ctx, path, &resp); err != nil {
+
+	var run models.WorkflowRun
+	if err := c.post(ctx, "/api/v1/workflows/runs/"+id.String()+"/cancel", opts, &run); err != nil {
 		return nil, err
 	}
-	return resp.Items, nil
+	return &run, nil
 }
 
-// CancelWorkflowRun cancels a workflow run.
-func (c *Client) CancelWorkflowRun(ctx context.Context, id string) (*models.WorkflowRun, error) {
+// ReplayRun re-executes a workflow run against its original inputs.
+func (c *Client) ReplayRun(ctx context.Context, runID models.RunID, opts models.ReplayOptions) (*models.WorkflowRun, error) {
+	if err := runID.Validate(); err != nil {
+		return nil, err
+	}
+
 	var run models.WorkflowRun
-	if err := c.post(ctx, "/api/v1/workflows/runs/"+id+"/cancel", nil, &run); err != nil {
+	if err := c.post(ctx, "/api/v1/workflows/runs/"+runID.String()+"/replay", opts, &run); err != nil {
 		return nil, err
 	}
 	return &run, nil
 }
 
+// DiffWorkflowRuns fetches two runs and compares them step by step, e.g.
+// to find what changed after a prompt or model change.
+func (c *Client) DiffWorkflowRuns(ctx context.Context, runA, runB models.RunID) (*models.RunDiff, error) {
+	a, err := c.GetWorkflowRun(ctx, runA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.GetWorkflowRun(ctx, runB)
+	if err != nil {
+		return nil, err
+	}
+	return models.DiffRuns(a, b), nil
+}
+
+// SimulateWorkflow plans a run of a workflow on input without executing
+// its LLM or tool steps, returning the step order, the resolved step
+// configuration and the estimated cost, e.g. as a pre-flight check in CI.
+func (c *Client) SimulateWorkflow(ctx context.Context, id models.WorkflowID, input map[string]interface{}) (*models.WorkflowSimulation, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+
+	var sim models.WorkflowSimulation
+	req := models.WorkflowSimulateRequest{InputData: input}
+	if err := c.post(ctx, "/api/v1/workflows/"+id.String()+"/simulate", req, &sim); err != nil {
+		return nil, err
+	}
+	return &sim, nil
+}
+
 // ================================
 // Context Methods
 // ================================
@@ -498,15 +1387,9 @@ func (c *Client) GetContextItem(ctx context.Context, id string) (*models.Context
 	return &item, nil
 }
 
-// ListContextItems lists context items.
-func (c *Client) ListContextItems(ctx context.Context) ([]models.ContextItem, error) {
-	var resp struct {
-		Items []models.ContextItem `json:"items"`
-	}
-	if err := c.get(ctx, "/api/v1/context", &resp); err != nil {
-		return nil, err
-	}
-	return resp.Items, nil
+// ListContextItems returns a page of context items.
+func (c *Client) ListContextItems(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.ContextItem], error) {
+	return List[models.ContextItem](ctx, c, "/api/v1/context", opts)
 }
 
 // DeleteContextItem deletes a context item.
@@ -526,3 +1409,62 @@ func (c *Client) HealthCheck(ctx context.Context) (*models.HealthStatus, error)
 	}
 	return &status, nil
 }
+
+// Readiness reports whether the server can take traffic, including the
+// health of each component it depends on. A server that is not ready
+// answers 503; its status is returned without an error so callers can
+// inspect which components are failing. Probes are never retried.
+func (c *Client) Readiness(ctx context.Context) (*models.HealthStatus, error) {
+	return c.probe(ctx, "/health/ready")
+}
+
+// Liveness reports whether the server process is running. Like Readiness,
+// an unhealthy server's 503 status is returned without an error.
+func (c *Client) Liveness(ctx context.Context) (*models.HealthStatus, error) {
+	return c.probe(ctx, "/health/live")
+}
+
+// probe performs a single GET of a health endpoint, decoding the status
+// carried by a 503 response rather than failing with it.
+func (c *Client) probe(ctx context.Context, path string) (*models.HealthStatus, error) {
+	ctx, cancel, httpClient := c.withOperationTimeout(ctx, http.MethodGet)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if err := decompressResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if err := c.decodeWireFormat(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(c.limitBody(resp.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var status models.HealthStatus
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		if json.Unmarshal(respBody, &status) == nil && status.Status != "" {
+			return &status, nil
+		}
+	}
+	if resp.StatusCode >= 400 {
+		return nil, parseErrorResponse(resp, respBody)
+	}
+	if err := json.Unmarshal(respBody, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &status, nil
+}
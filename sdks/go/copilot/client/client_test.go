@@ -2,12 +2,19 @@ package client
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
 	"github.com/llm-copilot-agent/sdk-go/copilot/models"
 )
 
@@ -54,9 +61,11 @@ func TestNewWithToken(t *testing.T) {
 
 func TestSetAccessToken(t *testing.T) {
 	client := New(nil)
-	client.SetAccessToken("new-token")
-	if client.config.AccessToken != "new-token" {
-		t.Errorf("expected access token 'new-token', got %s", client.config.AccessToken)
+	if err := client.SetAccessToken("new-token"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, _ := client.AccessToken(); token != "new-token" {
+		t.Errorf("expected access token 'new-token', got %s", token)
 	}
 }
 
@@ -73,9 +82,9 @@ func TestHealthCheck(t *testing.T) {
 			Status:        "healthy",
 			Version:       "1.0.0",
 			UptimeSeconds: 3600,
-			Components: map[string]string{
-				"database": "healthy",
-				"cache":    "healthy",
+			Components: map[string]models.ComponentHealth{
+				"database": {Status: "healthy", LatencyMS: 2.5},
+				"cache":    {Status: "healthy"},
 			},
 		}
 		json.NewEncoder(w).Encode(response)
@@ -98,6 +107,50 @@ func TestHealthCheck(t *testing.T) {
 	}
 }
 
+func TestReadinessAndLiveness(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/health/ready":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(models.HealthStatus{
+				Status: models.HealthUnhealthy,
+				Components: map[string]models.ComponentHealth{
+					"database": {Status: models.HealthUnhealthy, Error: "connection refused"},
+				},
+			})
+		case "/health/live":
+			json.NewEncoder(w).Encode(models.HealthStatus{Status: models.HealthHealthy})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, MaxRetries: 3})
+	ctx := context.Background()
+
+	ready, err := client.Readiness(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ready.Healthy() || ready.Components["database"].Error != "connection refused" {
+		t.Errorf("unexpected readiness %+v", ready)
+	}
+	if requests != 1 {
+		t.Errorf("expected probe not to be retried, got %d requests", requests)
+	}
+
+	live, err := client.Liveness(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !live.Healthy() {
+		t.Errorf("expected live server, got %+v", live)
+	}
+}
+
 func TestCreateConversation(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/v1/conversations" {
@@ -116,8 +169,8 @@ func TestCreateConversation(t *testing.T) {
 			ID:           "conv-123",
 			UserID:       "user-456",
 			MessageCount: 0,
-			CreatedAt:    time.Now(),
-			UpdatedAt:    time.Now(),
+			CreatedAt:    models.NewTimestamp(time.Now()),
+			UpdatedAt:    models.NewTimestamp(time.Now()),
 		}
 		json.NewEncoder(w).Encode(response)
 	}))
@@ -155,7 +208,7 @@ func TestSendMessage(t *testing.T) {
 			ConversationID: "conv-123",
 			Role:           models.RoleAssistant,
 			Content:        "Hello! How can I help you?",
-			CreatedAt:      time.Now(),
+			CreatedAt:      models.NewTimestamp(time.Now()),
 		}
 		json.NewEncoder(w).Encode(response)
 	}))
@@ -217,7 +270,7 @@ func TestLogin(t *testing.T) {
 	if resp.AccessToken != "access-token-123" {
 		t.Errorf("expected access token 'access-token-123', got %s", resp.AccessToken)
 	}
-	if client.config.AccessToken != "access-token-123" {
+	if token, _ := client.AccessToken(); token != "access-token-123" {
 		t.Errorf("expected client access token to be set")
 	}
 }
@@ -377,10 +430,412 @@ func TestIsRetryable(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			retryable := client.isRetryable(tt.err)
+			retryable := client.isRetryable(tt.err, nil)
 			if retryable != tt.retryable {
 				t.Errorf("expected retryable=%v, got %v", tt.retryable, retryable)
 			}
 		})
 	}
 }
+
+func TestHumanHandoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+
+		response := models.Conversation{ID: "conv-123", Handoff: &models.Handoff{}}
+		switch r.URL.Path {
+		case "/api/v1/conversations/conv-123/handoff":
+			if req["reason"] != "billing dispute" {
+				t.Errorf("expected reason 'billing dispute', got %s", req["reason"])
+			}
+			response.HandoffStatus = models.HandoffStatusRequested
+			response.Handoff.Reason = req["reason"]
+		case "/api/v1/conversations/conv-123/handoff/assign":
+			response.HandoffStatus = models.HandoffStatusAssigned
+			response.Handoff.AgentID = req["agent_id"]
+		case "/api/v1/conversations/conv-123/handoff/resolve":
+			response.HandoffStatus = models.HandoffStatusResolved
+			response.Handoff.Resolution = req["resolution"]
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	conv, err := client.RequestHumanHandoff(ctx, "conv-123", "billing dispute")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conv.HandoffStatus != models.HandoffStatusRequested {
+		t.Errorf("expected status 'requested', got %s", conv.HandoffStatus)
+	}
+
+	conv, err = client.AssignAgent(ctx, "conv-123", "agent-7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conv.HandoffStatus != models.HandoffStatusAssigned || conv.Handoff.AgentID != "agent-7" {
+		t.Errorf("expected assignment to agent-7, got %s/%s", conv.HandoffStatus, conv.Handoff.AgentID)
+	}
+
+	conv, err = client.ResolveHandoff(ctx, "conv-123", "refund issued")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conv.HandoffStatus != models.HandoffStatusResolved {
+		t.Errorf("expected status 'resolved', got %s", conv.HandoffStatus)
+	}
+}
+
+func TestReplayRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workflows/runs/run-123/replay" {
+			t.Errorf("expected path /api/v1/workflows/runs/run-123/replay, got %s", r.URL.Path)
+		}
+
+		var req models.ReplayOptions
+		json.NewDecoder(r.Body).Decode(&req)
+		if !req.FreezeLLMOutputs {
+			t.Errorf("expected freeze_llm_outputs to be set")
+		}
+
+		json.NewEncoder(w).Encode(models.WorkflowRun{
+			ID:       "run-456",
+			Status:   models.WorkflowStatusPending,
+			ReplayOf: "run-123",
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	run, err := client.ReplayRun(context.Background(), "run-123", models.ReplayOptions{FreezeLLMOutputs: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.ReplayOf != "run-123" {
+		t.Errorf("expected replay of 'run-123', got %s", run.ReplayOf)
+	}
+}
+
+func TestRunWorkflowClientToken(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req models.WorkflowRunCreate
+		json.NewDecoder(r.Body).Decode(&req)
+		tokens = append(tokens, req.ClientToken)
+		if len(tokens) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(models.WorkflowRun{ID: "run-1", WorkflowID: req.WorkflowID})
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, MaxRetries: 1, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	req := &models.WorkflowRunCreate{WorkflowID: "wf-1"}
+	if _, err := client.RunWorkflow(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] == "" || tokens[0] != tokens[1] {
+		t.Errorf("expected the retry to reuse a generated token, got %q", tokens)
+	}
+	if req.ClientToken != "" {
+		t.Errorf("expected the request to be left unchanged, got token %q", req.ClientToken)
+	}
+
+	tokens = nil
+	ctx := WithIdempotencyKey(context.Background(), "start-nightly")
+	if _, err := client.RunWorkflow(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens[0] != "start-nightly" {
+		t.Errorf("expected the idempotency key as token, got %q", tokens[0])
+	}
+}
+
+func TestCancelWorkflowRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workflows/runs/run-123/cancel" {
+			t.Errorf("expected path /api/v1/workflows/runs/run-123/cancel, got %s", r.URL.Path)
+		}
+
+		var req models.CancelOptions
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Reason != "superseded" || req.GraceSeconds != 30 || req.Force {
+			t.Errorf("unexpected cancel options %+v", req)
+		}
+
+		json.NewEncoder(w).Encode(models.WorkflowRun{
+			ID:           "run-123",
+			Status:       models.WorkflowStatusCancelled,
+			CancelReason: req.Reason,
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	run, err := client.CancelWorkflowRun(context.Background(), "run-123", models.CancelOptions{Reason: "superseded", GraceSeconds: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.CancelReason != "superseded" {
+		t.Errorf("expected cancel reason 'superseded', got %q", run.CancelReason)
+	}
+
+	if _, err := client.CancelWorkflowRun(context.Background(), "run-123", models.CancelOptions{GraceSeconds: -1}); err == nil {
+		t.Error("expected an error for a negative grace period")
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(CorrelationIDHeader) != "corr-123" {
+			t.Errorf("expected correlation header 'corr-123', got %s", r.Header.Get(CorrelationIDHeader))
+		}
+		w.Header().Set(CorrelationIDHeader, r.Header.Get(CorrelationIDHeader))
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.APIError{
+			Code:    "INVALID_REQUEST",
+			Message: "Content is required",
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := WithCorrelationID(context.Background(), "corr-123")
+
+	if id, ok := CorrelationIDFromContext(ctx); !ok || id != "corr-123" {
+		t.Errorf("expected correlation ID 'corr-123' in context, got %q", id)
+	}
+
+	_, err := client.SendMessage(ctx, "conv-123", "")
+	copilotErr, ok := err.(*CoPilotError)
+	if !ok {
+		t.Fatalf("expected CoPilotError, got %T", err)
+	}
+	if copilotErr.CorrelationID != "corr-123" {
+		t.Errorf("expected error correlation ID 'corr-123', got %s", copilotErr.CorrelationID)
+	}
+}
+
+func TestTokenStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/refresh":
+			json.NewEncoder(w).Encode(models.TokenPair{
+				AccessToken:  "access-2",
+				RefreshToken: "refresh-2",
+				ExpiresIn:    3600,
+			})
+		case "/api/v1/auth/me":
+			if r.Header.Get("Authorization") != "Bearer access-2" {
+				t.Errorf("expected refreshed token, got %s", r.Header.Get("Authorization"))
+			}
+			json.NewEncoder(w).Encode(models.User{ID: "user-123"})
+		case "/api/v1/auth/logout":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store := auth.NewMemoryStore(&auth.Token{AccessToken: "access-1", RefreshToken: "refresh-1"})
+	client := New(&Config{BaseURL: server.URL, TokenStore: store})
+	ctx := context.Background()
+
+	if _, err := client.RefreshTokens(ctx, "refresh-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token, _ := store.Get()
+	if token.AccessToken != "access-2" || token.RefreshToken != "refresh-2" {
+		t.Errorf("expected refreshed tokens in store, got %+v", token)
+	}
+	if token.ExpiresAt.IsZero() {
+		t.Error("expected expiry to be recorded")
+	}
+
+	if _, err := client.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.Logout(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, _ := store.Get(); token != nil {
+		t.Errorf("expected token to be deleted on logout, got %+v", token)
+	}
+}
+
+func TestCredentialsProvider(t *testing.T) {
+	var gotKey, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey, gotAuth = r.Header.Get("X-API-Key"), r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(models.User{ID: "user-123"})
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	var calls int
+	provider := auth.CredentialsProviderFunc(func(ctx context.Context) (*auth.Credentials, error) {
+		calls++
+		return &auth.Credentials{APIKey: "provided-key"}, nil
+	})
+	client := New(&Config{BaseURL: server.URL, Credentials: provider})
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetCurrentUser(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if gotKey != "provided-key" || calls != 1 {
+		t.Errorf("expected provider key resolved once, got %q after %d calls", gotKey, calls)
+	}
+
+	client = New(&Config{BaseURL: server.URL, Credentials: auth.StaticProvider{AccessToken: "provided-token"}})
+	if _, err := client.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer provided-token" {
+		t.Errorf("expected provider token, got %q", gotAuth)
+	}
+
+	client = New(&Config{BaseURL: server.URL, AccessToken: "explicit", Credentials: provider})
+	if _, err := client.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer explicit" || gotKey != "" {
+		t.Errorf("expected explicit token to win, got key %q auth %q", gotKey, gotAuth)
+	}
+}
+
+func TestHMACSigning(t *testing.T) {
+	signer := &auth.HMACSigner{KeyID: "key-1", Secret: []byte("secret")}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "" || r.Header.Get("Authorization") != "" {
+			t.Errorf("expected no raw credentials, got %v", r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		hash := sha256.Sum256(body)
+		if r.Header.Get(auth.ContentSHA256Header) != hex.EncodeToString(hash[:]) {
+			t.Errorf("body hash does not match the bytes sent")
+		}
+		mac := hmac.New(sha256.New, signer.Secret)
+		mac.Write([]byte(auth.HMACStringToSign(r.Method, r.URL.EscapedPath(), r.URL.RawQuery,
+			r.Header.Get(auth.TimestampHeader), r.Header.Get(auth.ContentSHA256Header))))
+		if r.Header.Get(auth.SignatureHeader) != "v1="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("invalid signature %s", r.Header.Get(auth.SignatureHeader))
+		}
+		json.NewEncoder(w).Encode(models.Conversation{ID: "conv-1"})
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, APIKey: "test-key", Signer: signer, CompressionThreshold: 1})
+	if _, err := client.CreateConversation(context.Background(), &models.ConversationCreate{Title: "signed"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.User{ID: "user-123", Username: strings.Repeat("x", 1024)})
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	client := New(&Config{BaseURL: server.URL, MaxResponseBytes: 4096})
+	if _, err := client.GetCurrentUser(ctx); err != nil {
+		t.Fatalf("unexpected error under limit: %v", err)
+	}
+
+	client = New(&Config{BaseURL: server.URL, MaxResponseBytes: 512})
+	if _, err := client.GetCurrentUser(ctx); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+}
+
+func TestEmptyResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, MaxResponseBytes: DefaultMaxResponseBytes})
+	if err := client.DeleteConversation(context.Background(), "conv-1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestInvalidIDRejected(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL})
+	if _, err := client.GetWorkflowRun(context.Background(), "../../admin"); !errors.Is(err, models.ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID, got %v", err)
+	}
+	if _, err := client.SendMessage(context.Background(), "", "Hello"); !errors.Is(err, models.ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID, got %v", err)
+	}
+	if hits != 0 {
+		t.Errorf("expected no requests, got %d", hits)
+	}
+}
+
+func TestGetConversationWithMessages(t *testing.T) {
+	var requests []string
+	expand := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/api/v1/conversations/conv-1":
+			if r.URL.Query().Get("include") != "messages" || r.URL.Query().Get("message_limit") != "20" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			resp := map[string]interface{}{"id": "conv-1", "title": "Planning"}
+			if expand {
+				resp["messages"] = []models.Message{{ID: "msg-1", Content: "Hi"}}
+			}
+			json.NewEncoder(w).Encode(resp)
+		case "/api/v1/conversations/conv-1/messages":
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.Message]{Items: []models.Message{{ID: "msg-1"}, {ID: "msg-2"}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	conv, err := client.GetConversationWithMessages(ctx, "conv-1", 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conv.Title != "Planning" || len(conv.Messages) != 1 || conv.Extra != nil {
+		t.Errorf("unexpected conversation: %+v", conv)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected a single request, got %v", requests)
+	}
+
+	// Servers without the expansion need a second request.
+	expand = false
+	requests = nil
+	conv, err = client.GetConversationWithMessages(ctx, "conv-1", 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conv.Messages) != 2 || len(requests) != 2 {
+		t.Errorf("expected fallback listing, got %d messages from %v", len(conv.Messages), requests)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// inflightCall is a GET shared by concurrent identical requests.
+type inflightCall struct {
+	done chan struct{}
+	body json.RawMessage
+	err  error
+}
+
+// coalesce performs a GET, sharing one round trip among concurrent callers
+// requesting the same path with the same scope restriction. Each caller
+// decodes its own copy of the body.
+func (c *Client) coalesce(ctx context.Context, path string, result interface{}) error {
+	key := path + "\x00" + scopesHeaderValue(ctx)
+
+	c.inflightMu.Lock()
+	if call, ok := c.inflight[key]; ok {
+		c.inflightMu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		// The leader gave up, but this caller has not.
+		if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+			return c.send(ctx, http.MethodGet, path, nil, result)
+		}
+		if call.err != nil {
+			return call.err
+		}
+		return decodeShared(call.body, result)
+	}
+
+	call := &inflightCall{done: make(chan struct{})}
+	if c.inflight == nil {
+		c.inflight = make(map[string]*inflightCall)
+	}
+	c.inflight[key] = call
+	c.inflightMu.Unlock()
+
+	call.err = c.send(ctx, http.MethodGet, path, nil, &call.body)
+
+	c.inflightMu.Lock()
+	delete(c.inflight, key)
+	c.inflightMu.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return call.err
+	}
+	return decodeShared(call.body, result)
+}
+
+// decodeShared decodes a shared response body into result.
+func decodeShared(body json.RawMessage, result interface{}) error {
+	if result == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestCoalesceRequests(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		json.NewEncoder(w).Encode(models.WorkflowRun{ID: "run-1", Status: models.WorkflowStatusRunning})
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, CoalesceRequests: true})
+	ctx := context.Background()
+
+	const callers = 10
+	var wg sync.WaitGroup
+	runs := make([]*models.WorkflowRun, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			runs[i], errs[i] = client.GetWorkflowRun(ctx, "run-1")
+		}(i)
+	}
+
+	// Give every caller time to join the leader's request.
+	for atomic.LoadInt32(&hits) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil || runs[i].ID != "run-1" {
+			t.Fatalf("caller %d: got %+v, %v", i, runs[i], errs[i])
+		}
+	}
+	if runs[0] == runs[1] {
+		t.Error("expected each caller to receive its own copy")
+	}
+	if hits != 1 {
+		t.Errorf("expected concurrent GETs to share one round trip, got %d", hits)
+	}
+}
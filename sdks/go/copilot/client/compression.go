@@ -0,0 +1,70 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultCompressionThreshold is the request body size, in bytes, above
+// which bodies are gzip-compressed by default.
+const DefaultCompressionThreshold = 8 << 10
+
+// compressBody gzips body when it exceeds the configured threshold and
+// reports whether it did.
+func (c *Client) compressBody(body []byte) ([]byte, bool, error) {
+	threshold := c.config.CompressionThreshold
+	if threshold <= 0 || len(body) <= threshold {
+		return body, false, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// gzipBody closes both the gzip reader and the underlying response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// decompressResponse transparently decodes a gzip-encoded response body.
+// The transport only does this itself when it added Accept-Encoding, which
+// the client sets explicitly so that custom HTTP clients benefit too.
+func decompressResponse(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") || resp.Uncompressed {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err == io.EOF {
+		// Empty body, e.g. 204 No Content.
+		return nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return fmt.Errorf("failed to decompress response: %w", err)
+	}
+
+	resp.Body = &gzipBody{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
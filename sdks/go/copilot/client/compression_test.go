@@ -0,0 +1,82 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestGzipCompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected gzip to be advertised, got %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("invalid gzip request body: %v", err)
+			}
+			body = zr
+		}
+		var req map[string]string
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("X-Request-Compressed", r.Header.Get("Content-Encoding"))
+		zw := gzip.NewWriter(w)
+		json.NewEncoder(zw).Encode(models.Message{ID: "msg-1", Content: req["content"]})
+		zw.Close()
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.BaseURL = server.URL
+	config.CompressionThreshold = 64
+	client := New(config)
+	ctx := context.Background()
+
+	msg, err := client.SendMessage(ctx, "conv-1", "short")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Content != "short" {
+		t.Errorf("expected decompressed response, got %q", msg.Content)
+	}
+
+	long := strings.Repeat("context ", 100)
+	msg, err = client.SendMessage(ctx, "conv-1", long)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Content != long {
+		t.Errorf("expected large body to round-trip, got %d bytes", len(msg.Content))
+	}
+}
+
+func TestCompressBodyThreshold(t *testing.T) {
+	client := New(&Config{CompressionThreshold: 10})
+
+	if _, compressed, _ := client.compressBody([]byte("small")); compressed {
+		t.Error("expected body under threshold to be sent as-is")
+	}
+	if _, compressed, _ := client.compressBody([]byte(strings.Repeat("x", 11))); !compressed {
+		t.Error("expected body over threshold to be compressed")
+	}
+
+	client = New(&Config{})
+	if _, compressed, _ := client.compressBody([]byte(strings.Repeat("x", 1<<20))); compressed {
+		t.Error("expected zero threshold to disable compression")
+	}
+}
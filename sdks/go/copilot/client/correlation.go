@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CorrelationIDHeader is the header used to propagate correlation IDs.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// RequestIDHeader is the header identifying a single call to the API.
+const RequestIDHeader = "X-Request-ID"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context that tags every request made with it
+// with the given correlation ID. The server propagates the ID into the
+// resulting messages, runs, stream events, webhook payloads, and audit logs.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// WithCorrelationIDFrom returns a context carrying the correlation ID of an
+// incoming request's headers, if any, so that a service calling the API
+// while handling the request continues its trace.
+func WithCorrelationIDFrom(ctx context.Context, header http.Header) context.Context {
+	if id := header.Get(CorrelationIDHeader); id != "" {
+		return WithCorrelationID(ctx, id)
+	}
+	return ctx
+}
+
+// CorrelationIDFromContext returns the correlation ID stored in the context.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose requests carry the given request
+// ID instead of a generated one. Every call is otherwise sent with a new
+// ID, kept across its retries, which CoPilotError.RequestID reports so
+// that a failure can be found in the server logs.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in the context.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	return NewIdempotencyKey()
+}
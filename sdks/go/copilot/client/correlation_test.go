@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequestIDs(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, MaxRetries: 1, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	ctx := context.Background()
+
+	_, err := client.HealthCheck(ctx)
+	var apiErr *CoPilotError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected a CoPilotError, got %v", err)
+	}
+	if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] {
+		t.Fatalf("expected retries to share a generated request ID, got %q", ids)
+	}
+	if apiErr.RequestID != ids[0] {
+		t.Errorf("expected the error to report request ID %q, got %q", ids[0], apiErr.RequestID)
+	}
+
+	client.HealthCheck(ctx)
+	if ids[2] == ids[0] {
+		t.Error("expected a new request ID per call")
+	}
+
+	client.HealthCheck(WithRequestID(ctx, "req-42"))
+	if ids[4] != "req-42" {
+		t.Errorf("expected the request ID from the context, got %q", ids[4])
+	}
+}
+
+func TestWithCorrelationIDFrom(t *testing.T) {
+	incoming := http.Header{}
+	incoming.Set(CorrelationIDHeader, "corr-1")
+	ctx := WithCorrelationIDFrom(context.Background(), incoming)
+	if id, _ := CorrelationIDFromContext(ctx); id != "corr-1" {
+		t.Errorf("expected corr-1, got %q", id)
+	}
+	if _, ok := CorrelationIDFromContext(WithCorrelationIDFrom(context.Background(), http.Header{})); ok {
+		t.Error("expected no correlation ID")
+	}
+}
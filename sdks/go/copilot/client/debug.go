@@ -0,0 +1,334 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// debugBodyLimit is how much of each body a debug transcript shows.
+const debugBodyLimit = 4096
+
+// redacted replaces secrets in debug transcripts.
+const redacted = "[REDACTED]"
+
+// secretHeaders are masked in debug transcripts.
+var secretHeaders = map[string]bool{
+	"Authorization":        true,
+	"Proxy-Authorization":  true,
+	"Cookie":               true,
+	"Set-Cookie":           true,
+	"X-Api-Key":            true,
+	"X-Amz-Security-Token": true,
+	"X-Copilot-Signature":  true,
+}
+
+// secretFields are masked in JSON bodies and query strings of debug
+// transcripts.
+var secretFields = map[string]bool{
+	"password":      true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+	"api_key":       true,
+	"key":           true,
+	"secret":        true,
+	"token":         true,
+	"subject_token": true,
+	"actor_token":   true,
+	"code_verifier": true,
+}
+
+// secretFormFields are masked in form-encoded bodies in addition to
+// secretFields. An OAuth authorization code is a credential there, while
+// "code" in a JSON body is usually an error code.
+var secretFormFields = map[string]bool{
+	"code":             true,
+	"client_assertion": true,
+}
+
+// secretFieldPattern matches secret string fields of a JSON document that
+// does not parse.
+var secretFieldPattern = regexp.MustCompile(`(?i)"(password|access_token|refresh_token|id_token|client_secret|api_key|key|secret|token|subject_token|actor_token|code_verifier)"\s*:\s*"(?:[^"\\]|\\.)*(?:"|$)`)
+
+// presignedParams are query parameters carrying the credentials of a
+// presigned storage URL. The whole query of such a URL is masked, as its
+// other parameters are part of the signed grant.
+var presignedParams = map[string]bool{
+	"x-amz-signature":      true,
+	"x-amz-credential":     true,
+	"x-amz-security-token": true,
+	"x-goog-signature":     true,
+	"x-goog-credential":    true,
+	"sig":                  true,
+	"signature":            true,
+}
+
+// debugTransport writes a sanitized transcript of every round trip to w.
+type debugTransport struct {
+	next http.RoundTripper
+	mu   *sync.Mutex
+	w    io.Writer
+}
+
+// withDebugTranscript returns a copy of httpClient whose round trips are
+// written to w, replacing any transcript it already writes. A nil w
+// stops the transcript.
+func withDebugTranscript(httpClient *http.Client, w io.Writer) *http.Client {
+	copied := *httpClient
+	if debug, ok := copied.Transport.(*debugTransport); ok {
+		copied.Transport = debug.next
+	}
+	if w != nil {
+		next := copied.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		copied.Transport = &debugTransport{next: next, mu: &sync.Mutex{}, w: w}
+	}
+	return &copied
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--> %s %s\n", req.Method, redactURL(req.URL))
+	writeDebugHeaders(&buf, req.Header)
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, debugBodyLimit+1))
+			body.Close()
+			writeDebugBody(&buf, data, req.Header, req.ContentLength)
+		}
+	}
+	t.write(buf.Bytes())
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.write([]byte(fmt.Sprintf("<-- %s %s failed after %s: %v\n\n", req.Method, redactURL(req.URL), elapsed, err)))
+		return nil, err
+	}
+
+	buf.Reset()
+	fmt.Fprintf(&buf, "<-- %s %s %s (%s)\n", resp.Status, req.Method, redactURL(req.URL), elapsed)
+	writeDebugHeaders(&buf, resp.Header)
+	if resp.StatusCode == http.StatusSwitchingProtocols || strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Streams are long-lived; their bodies are not captured.
+		buf.WriteString("[stream]\n\n")
+		t.write(buf.Bytes())
+		return resp, nil
+	}
+	resp.Body = &debugBody{ReadCloser: resp.Body, t: t, head: buf.Bytes(), header: resp.Header, length: resp.ContentLength}
+	return resp, nil
+}
+
+// write writes an entry of the transcript.
+func (t *debugTransport) write(entry []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.w.Write(entry)
+}
+
+// debugBody captures the start of a response body, writing the response
+// to the transcript once the body is closed.
+type debugBody struct {
+	io.ReadCloser
+	t      *debugTransport
+	head   []byte
+	header http.Header
+	length int64
+	data   []byte
+	once   sync.Once
+}
+
+func (b *debugBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := debugBodyLimit + 1 - len(b.data); room > 0 {
+		if n < room {
+			room = n
+		}
+		b.data = append(b.data, p[:room]...)
+	}
+	return n, err
+}
+
+func (b *debugBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		buf := bytes.NewBuffer(b.head)
+		writeDebugBody(buf, b.data, b.header, b.length)
+		b.t.write(buf.Bytes())
+	})
+	return err
+}
+
+// writeDebugHeaders writes headers sorted by name, masking secrets.
+func writeDebugHeaders(buf *bytes.Buffer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if secretHeaders[http.CanonicalHeaderKey(name)] {
+				value = redactHeader(value)
+			}
+			fmt.Fprintf(buf, "%s: %s\n", name, value)
+		}
+	}
+	buf.WriteString("\n")
+}
+
+// redactHeader masks a header value, keeping an authentication scheme such
+// as "Bearer".
+func redactHeader(value string) string {
+	if scheme, _, ok := strings.Cut(value, " "); ok && !strings.ContainsAny(scheme, "=,") {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// redactURL returns u with secret query parameters masked, or with its
+// whole query masked if it is a presigned URL.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	for name := range query {
+		if presignedParams[strings.ToLower(name)] {
+			copied := *u
+			copied.RawQuery = redacted
+			return copied.String()
+		}
+	}
+	masked := false
+	for name := range query {
+		if secretFields[strings.ToLower(name)] {
+			query.Set(name, redacted)
+			masked = true
+		}
+	}
+	if !masked {
+		return u.String()
+	}
+	copied := *u
+	copied.RawQuery = query.Encode()
+	return copied.String()
+}
+
+// writeDebugBody writes the start of a body, decompressed and with JSON
+// secrets masked. data holds at most debugBodyLimit+1 bytes.
+func writeDebugBody(buf *bytes.Buffer, data []byte, header http.Header, length int64) {
+	if len(data) == 0 {
+		return
+	}
+	truncated := len(data) > debugBodyLimit
+	if header.Get("Content-Encoding") == "gzip" {
+		// A truncated stream still yields its start.
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			fmt.Fprintf(buf, "[%d bytes, gzip]\n\n", len(data))
+			return
+		}
+		data, _ = io.ReadAll(io.LimitReader(zr, debugBodyLimit+1))
+		truncated = truncated || len(data) > debugBodyLimit
+	}
+	if strings.Contains(header.Get("Content-Type"), "msgpack") {
+		fmt.Fprintf(buf, "[msgpack body]\n\n")
+		return
+	}
+	if truncated {
+		data = data[:debugBodyLimit]
+	}
+
+	var v interface{}
+	if strings.HasPrefix(header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		data = redactForm(data)
+	} else if json.Unmarshal(data, &v) == nil {
+		if redactJSON(v) {
+			data, _ = json.Marshal(v)
+		}
+	} else {
+		// A truncated document does not parse; mask what looks like a
+		// secret field, even when its value is cut short.
+		data = secretFieldPattern.ReplaceAll(data, []byte(`"$1":"`+redacted+`"`))
+	}
+	buf.Write(data)
+	if truncated {
+		if length > 0 {
+			fmt.Fprintf(buf, "... [truncated, %d bytes total]", length)
+		} else {
+			buf.WriteString("... [truncated]")
+		}
+	}
+	buf.WriteString("\n\n")
+}
+
+// redactForm masks secret fields of a form-encoded body. A body that does
+// not parse, such as a truncated one, is masked entirely.
+func redactForm(data []byte) []byte {
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return []byte(redacted)
+	}
+	for name := range form {
+		if key := strings.ToLower(name); secretFields[key] || secretFormFields[key] {
+			form.Set(name, redacted)
+		}
+	}
+	return []byte(form.Encode())
+}
+
+// redactJSON masks the values of secret fields and the queries of
+// presigned URLs in a decoded JSON value, reporting whether any were found.
+func redactJSON(v interface{}) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if secretFields[strings.ToLower(key)] {
+				v[key] = redacted
+				found = true
+			} else if masked, ok := redactPresigned(value); ok {
+				v[key] = masked
+				found = true
+			} else if redactJSON(value) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			if masked, ok := redactPresigned(value); ok {
+				v[i] = masked
+				found = true
+			} else if redactJSON(value) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// redactPresigned masks the query of v if it is a presigned URL string.
+func redactPresigned(v interface{}) (string, bool) {
+	s, ok := v.(string)
+	if !ok || !strings.Contains(s, "?") {
+		return "", false
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" {
+		return "", false
+	}
+	masked := redactURL(u)
+	return masked, masked != s && strings.HasSuffix(masked, "?"+redacted)
+}
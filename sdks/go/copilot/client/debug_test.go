@@ -0,0 +1,121 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestDebugTranscript(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"secret-access","refresh_token":"secret-refresh","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	var transcript bytes.Buffer
+	client := New(&Config{BaseURL: server.URL, APIKey: "secret-key", DebugWriter: &transcript})
+	if _, err := client.Login(context.Background(), "ada", "secret-password"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := transcript.String()
+	for _, secret := range []string{"secret-key", "secret-password", "secret-access", "secret-refresh"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("transcript leaks %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"--> POST " + server.URL, "<-- 200 OK POST", "X-Api-Key: [REDACTED]", `"token_type":"Bearer"`, "X-Request-Id: "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("transcript lacks %q:\n%s", want, out)
+		}
+	}
+
+	// Copies may stop the transcript.
+	transcript.Reset()
+	if _, err := client.With(func(c *Config) { c.DebugWriter = nil }).HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transcript.Len() != 0 {
+		t.Errorf("expected no transcript, got:\n%s", transcript.String())
+	}
+}
+
+func TestDebugBodyTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	data := []byte(`{"items":["` + strings.Repeat("x", debugBodyLimit) + `"],"password":"hunter2"}`)
+	writeDebugBody(&buf, data[:debugBodyLimit+1], http.Header{}, int64(len(data)))
+	if !strings.Contains(buf.String(), "[truncated") {
+		t.Errorf("expected a truncation note, got %q", buf.String()[debugBodyLimit-20:])
+	}
+
+	buf.Reset()
+	writeDebugBody(&buf, []byte(`{"a":1,"password":"hunt`), http.Header{}, 0)
+	if strings.Contains(buf.String(), "hunt") {
+		t.Errorf("partial secret leaked: %q", buf.String())
+	}
+}
+
+func TestDebugTranscriptCredentials(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/auth/token-exchange":
+			w.Write([]byte(`{"access_token":"a","refresh_token":"r","expires_in":60}`))
+		case "/oauth/token":
+			w.Write([]byte(`{"access_token":"a","token_type":"Bearer"}`))
+		case "/api/v1/context/uploads":
+			json.NewEncoder(w).Encode(models.PresignedUpload{UploadID: "up-1", PresignedURL: models.PresignedURL{
+				URL:       server.URL + "/bucket/up-1?X-Amz-Credential=amz-secret-credential&X-Amz-Signature=amz-secret-signature",
+				Method:    http.MethodPut,
+				ExpiresAt: models.NewTimestamp(time.Now().Add(time.Minute)),
+			}})
+		case "/api/v1/context/uploads/up-1/complete":
+			json.NewEncoder(w).Encode(models.ContextItem{ID: "ctx-1"})
+		case "/api/v1/context/ctx-1/download-url":
+			json.NewEncoder(w).Encode(models.PresignedURL{URL: server.URL + "/bucket/ctx-1?X-Goog-Signature=goog-secret-signature"})
+		}
+	}))
+	defer server.Close()
+
+	var transcript bytes.Buffer
+	client := New(&Config{BaseURL: server.URL, DebugWriter: &transcript})
+	ctx := context.Background()
+
+	if _, err := client.ExchangeIDToken(ctx, "idp-secret-token"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.OAuthConfig("cli").Exchange(ctx, "oauth-secret-code", &auth.PKCE{Verifier: "pkce-secret-verifier"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.UploadContextItem(ctx, &models.UploadURLRequest{Name: "a.txt", Size: 3}, strings.NewReader("abc")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetDownloadURL(ctx, "ctx-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u, _ := url.Parse(server.URL + "/blob?sv=2024&sig=azure-secret-signature")
+	transcript.WriteString(redactURL(u))
+
+	out := transcript.String()
+	for _, secret := range []string{"idp-secret-token", "oauth-secret-code", "pkce-secret-verifier", "amz-secret", "goog-secret-signature", "azure-secret-signature"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("transcript leaks %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"grant_type=authorization_code", "PUT " + server.URL + "/bucket/up-1?[REDACTED]", "/bucket/ctx-1?[REDACTED]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("transcript lacks %q:\n%s", want, out)
+		}
+	}
+}
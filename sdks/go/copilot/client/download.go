@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrContentChanged is returned when content changes on the server while
+// a download is being resumed, so the parts already written do not match.
+var ErrContentChanged = errors.New("content changed during download")
+
+// DownloadContextItem streams the stored content of a context item to w,
+// in full rather than the possibly truncated ContextItem.Content. A
+// transfer cut short by a network failure is resumed where it stopped, up
+// to the retry policy's MaxRetries times.
+func (c *Client) DownloadContextItem(ctx context.Context, id string, w io.Writer) error {
+	return c.DownloadContextItemFrom(ctx, id, w, 0)
+}
+
+// DownloadContextItemFrom is DownloadContextItem starting at byte offset,
+// e.g. to complete a partial file left by an earlier attempt. w receives
+// the content from offset on.
+func (c *Client) DownloadContextItemFrom(ctx context.Context, id string, w io.Writer, offset int64) error {
+	if id == "" {
+		return errors.New("context item ID is empty")
+	}
+	if offset < 0 {
+		return fmt.Errorf("invalid offset %d", offset)
+	}
+	return c.downloadResumable(ctx, "/api/v1/context/"+id+"/content", w, offset)
+}
+
+// downloadResumable copies the content at path from offset on to w,
+// resuming with range requests after network failures. Resumed requests
+// carry If-Range so that a changed resource is detected rather than
+// spliced.
+func (c *Client) downloadResumable(ctx context.Context, path string, w io.Writer, offset int64) error {
+	if err := c.ensureFreshToken(ctx); err != nil {
+		return err
+	}
+	policy := c.retryPolicy(http.MethodGet, path)
+
+	var validator string
+	for attempt := 0; ; attempt++ {
+		n, v, err := c.downloadRange(ctx, path, w, offset, validator)
+		offset += n
+		if validator == "" {
+			validator = v
+		}
+		if err == nil {
+			return nil
+		}
+		var readErr *downloadReadError
+		if !errors.As(err, &readErr) && !c.isRetryable(err, nil) || attempt >= policy.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(policy.backoff(attempt + 1)):
+		}
+	}
+}
+
+// downloadReadError is a failure reading a download's body, after which
+// the download may resume.
+type downloadReadError struct {
+	err error
+}
+
+func (e *downloadReadError) Error() string { return "failed to read response body: " + e.err.Error() }
+
+func (e *downloadReadError) Unwrap() error { return e.err }
+
+// readTracker remembers the error of its reader, to tell it apart from
+// failures writing what was read.
+type readTracker struct {
+	r   io.Reader
+	err error
+}
+
+func (t *readTracker) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err != nil && err != io.EOF {
+		t.err = err
+	}
+	return n, err
+}
+
+// downloadRange performs a single GET of path from offset on, copying the
+// body to w. It returns the bytes written and the response's validator.
+func (c *Client) downloadRange(ctx context.Context, path string, w io.Writer, offset int64, validator string) (int64, string, error) {
+	ctx, cancel, httpClient := c.withOperationTimeout(ctx, http.MethodGet)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Accept", "*/*")
+	// Ranges of an encoded body would count encoded bytes.
+	req.Header.Set("Accept-Encoding", "identity")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if validator != "" {
+			req.Header.Set("If-Range", validator)
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	// If-Range takes only strong validators.
+	v := resp.Header.Get("ETag")
+	if v == "" || strings.HasPrefix(v, "W/") {
+		v = resp.Header.Get("Last-Modified")
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// Nothing is left when offset is the size.
+		if resp.Header.Get("Content-Range") == fmt.Sprintf("bytes */%d", offset) {
+			return 0, v, nil
+		}
+		return 0, v, fmt.Errorf("offset %d is past the end of the content", offset)
+	case resp.StatusCode >= 400:
+		respBody, err := io.ReadAll(c.limitBody(resp.Body))
+		if err != nil {
+			return 0, v, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return 0, v, parseErrorResponse(resp, respBody)
+	case resp.StatusCode == http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), "bytes "+strconv.FormatInt(offset, 10)+"-") {
+			return 0, v, fmt.Errorf("unexpected Content-Range %q", resp.Header.Get("Content-Range"))
+		}
+	case offset > 0:
+		// The whole content was sent: it changed, or the server does not
+		// support ranges and the start is skipped.
+		if validator != "" {
+			return 0, v, ErrContentChanged
+		}
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			return 0, v, &downloadReadError{err: err}
+		}
+		if resp.ContentLength > 0 {
+			resp.ContentLength -= offset
+		}
+	}
+
+	reportDownloadFrom(ctx, resp, offset)
+	body := &readTracker{r: resp.Body}
+	n, err := io.Copy(w, body)
+	if err != nil {
+		if body.err != nil {
+			return n, v, &downloadReadError{err: body.err}
+		}
+		return n, v, fmt.Errorf("failed to write content: %w", err)
+	}
+	return n, v, nil
+}
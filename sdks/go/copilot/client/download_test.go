@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyContentServer serves content, dropping the connection halfway
+// through the first response.
+func flakyContentServer(t *testing.T, content func() string) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/context/ctx-1/content" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		body := content()
+		w.Header().Set("ETag", `"`+strconv.Itoa(len(body))+body[:1]+`"`)
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.Write([]byte(body[:len(body)/2]))
+			w.(http.Flusher).Flush()
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestDownloadContextItemResumes(t *testing.T) {
+	content := strings.Repeat("0123456789", 10000)
+	server, requests := flakyContentServer(t, func() string { return content })
+	client := New(&Config{BaseURL: server.URL, MaxRetries: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+
+	var out bytes.Buffer
+	var done, total int64
+	ctx := WithDownloadProgress(context.Background(), func(bytesDone, bytesTotal int64) {
+		done, total = bytesDone, bytesTotal
+	})
+	if err := client.DownloadContextItem(ctx, "ctx-1", &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != content {
+		t.Errorf("expected %d bytes of content, got %d", len(content), out.Len())
+	}
+	if *requests != 2 {
+		t.Errorf("expected one resumed request, got %d requests", *requests)
+	}
+	if done != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("unexpected progress: %d of %d", done, total)
+	}
+}
+
+func TestDownloadContextItemChanged(t *testing.T) {
+	var version int32
+	server, _ := flakyContentServer(t, func() string {
+		if atomic.AddInt32(&version, 1) == 1 {
+			return strings.Repeat("a", 10000)
+		}
+		return strings.Repeat("b", 10000)
+	})
+	client := New(&Config{BaseURL: server.URL, MaxRetries: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+
+	var out bytes.Buffer
+	if err := client.DownloadContextItem(context.Background(), "ctx-1", &out); !errors.Is(err, ErrContentChanged) {
+		t.Errorf("expected ErrContentChanged, got %v", err)
+	}
+}
+
+func TestDownloadContextItemFrom(t *testing.T) {
+	content := "hello, world"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+	}))
+	defer server.Close()
+	client := New(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	if err := client.DownloadContextItemFrom(ctx, "ctx-1", &out, 7); err != nil || out.String() != "world" {
+		t.Errorf("expected the rest of the content, got %q (%v)", out.String(), err)
+	}
+	out.Reset()
+	if err := client.DownloadContextItemFrom(ctx, "ctx-1", &out, int64(len(content))); err != nil || out.Len() != 0 {
+		t.Errorf("expected nothing left, got %q (%v)", out.String(), err)
+	}
+	if err := client.DownloadContextItemFrom(ctx, "ctx-1", &out, 100); err == nil {
+		t.Error("expected an offset past the end to fail")
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// SaveDraft stores the user's unsent input for a conversation, replacing
+// any previous draft.
+func (c *Client) SaveDraft(ctx context.Context, conversationID models.ConversationID, draft models.DraftSave) (*models.Draft, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+
+	var resp models.Draft
+	if err := c.request(ctx, http.MethodPut, draftPath(conversationID), draft, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDraft returns the draft saved for a conversation, or nil if there is
+// none.
+func (c *Client) GetDraft(ctx context.Context, conversationID models.ConversationID) (*models.Draft, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+
+	var draft models.Draft
+	if err := c.get(ctx, draftPath(conversationID), &draft); err != nil {
+		var apiErr *CoPilotError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// DeleteDraft discards the draft saved for a conversation, e.g. once it
+// has been sent.
+func (c *Client) DeleteDraft(ctx context.Context, conversationID models.ConversationID) error {
+	if err := conversationID.Validate(); err != nil {
+		return err
+	}
+	return c.delete(ctx, draftPath(conversationID))
+}
+
+func draftPath(conversationID models.ConversationID) string {
+	return fmt.Sprintf("/api/v1/conversations/%s/draft", conversationID)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestDraftLifecycle(t *testing.T) {
+	saved := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/conversations/conv-1/draft" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			var req models.DraftSave
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Content != "half a thought" || req.ParentMessageID != "msg-1" {
+				t.Errorf("unexpected draft %+v", req)
+			}
+			saved = true
+			json.NewEncoder(w).Encode(models.Draft{ConversationID: "conv-1", Content: req.Content, ParentMessageID: req.ParentMessageID})
+		case http.MethodGet:
+			if !saved {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(models.Draft{ConversationID: "conv-1", Content: "half a thought"})
+		case http.MethodDelete:
+			saved = false
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	draft, err := client.GetDraft(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draft != nil {
+		t.Errorf("expected no draft, got %+v", draft)
+	}
+
+	draft, err = client.SaveDraft(ctx, "conv-1", models.DraftSave{Content: "half a thought", ParentMessageID: "msg-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draft.Content != "half a thought" || draft.ParentMessageID != "msg-1" {
+		t.Errorf("unexpected draft %+v", draft)
+	}
+
+	draft, err = client.GetDraft(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draft == nil || draft.Content != "half a thought" {
+		t.Errorf("unexpected draft %+v", draft)
+	}
+
+	if err := client.DeleteDraft(ctx, "conv-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SaveDraft(ctx, "", models.DraftSave{}); err == nil {
+		t.Error("expected an error for an empty conversation ID")
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// environmentPath returns the path of an environment.
+func environmentPath(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("environment name is empty")
+	}
+	return "/api/v1/environments/" + url.PathEscape(name), nil
+}
+
+// CreateEnvironment creates an environment, such as models.EnvironmentProd,
+// that runs can bind. The request is validated first, failing with
+// models.ErrInvalidEnvironment.
+func (c *Client) CreateEnvironment(ctx context.Context, req models.EnvironmentCreate) (*models.Environment, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var env models.Environment
+	if err := c.post(ctx, "/api/v1/environments", req, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// GetEnvironment retrieves an environment by name.
+func (c *Client) GetEnvironment(ctx context.Context, name string) (*models.Environment, error) {
+	path, err := environmentPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var env models.Environment
+	if err := c.get(ctx, path, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// ListEnvironments returns all environments.
+func (c *Client) ListEnvironments(ctx context.Context) ([]models.Environment, error) {
+	fetch := func(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.Environment], error) {
+		return List[models.Environment](ctx, c, "/api/v1/environments", opts)
+	}
+	return NewPager(fetch, nil).All(ctx)
+}
+
+// UpdateEnvironment updates an environment's description or replaces its
+// variables.
+func (c *Client) UpdateEnvironment(ctx context.Context, name string, req models.EnvironmentUpdate) (*models.Environment, error) {
+	path, err := environmentPath(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var env models.Environment
+	if err := c.patch(ctx, path, req, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// DeleteEnvironment deletes an environment.
+func (c *Client) DeleteEnvironment(ctx context.Context, name string) error {
+	path, err := environmentPath(name)
+	if err != nil {
+		return err
+	}
+	return c.delete(ctx, path)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestEnvironmentLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/environments":
+			var req models.EnvironmentCreate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != models.EnvironmentStaging || req.Variables["REGION"] != "eu-west-1" {
+				t.Errorf("unexpected environment request %+v", req)
+			}
+			json.NewEncoder(w).Encode(models.Environment{Name: req.Name, Variables: req.Variables})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/environments/staging":
+			json.NewEncoder(w).Encode(models.Environment{Name: "staging", Variables: map[string]string{"REGION": "eu-west-1"}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/environments":
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.Environment]{Items: []models.Environment{{Name: "dev"}, {Name: "staging"}}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/environments/staging":
+			var req models.EnvironmentUpdate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Description == nil || *req.Description != "Pre-release" || req.Variables != nil {
+				t.Errorf("unexpected environment update %+v", req)
+			}
+			json.NewEncoder(w).Encode(models.Environment{Name: "staging", Description: *req.Description})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/environments/staging":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	env, err := client.CreateEnvironment(ctx, models.EnvironmentCreate{Name: models.EnvironmentStaging, Variables: map[string]string{"REGION": "eu-west-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.Name != "staging" {
+		t.Errorf("unexpected environment %+v", env)
+	}
+	if env, err = client.GetEnvironment(ctx, "staging"); err != nil || env.Variables["REGION"] != "eu-west-1" {
+		t.Errorf("unexpected environment %+v, error %v", env, err)
+	}
+	envs, err := client.ListEnvironments(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(envs) != 2 {
+		t.Errorf("expected 2 environments, got %d", len(envs))
+	}
+	description := "Pre-release"
+	if env, err = client.UpdateEnvironment(ctx, "staging", models.EnvironmentUpdate{Description: &description}); err != nil || env.Description != description {
+		t.Errorf("unexpected environment %+v, error %v", env, err)
+	}
+	if err := client.DeleteEnvironment(ctx, "staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.CreateEnvironment(ctx, models.EnvironmentCreate{Name: "dev", Variables: map[string]string{"not valid": "x"}}); !errors.Is(err, models.ErrInvalidEnvironment) {
+		t.Errorf("expected ErrInvalidEnvironment, got %v", err)
+	}
+	if _, err := client.GetEnvironment(ctx, ""); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+}
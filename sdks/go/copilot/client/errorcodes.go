@@ -0,0 +1,77 @@
+package client
+
+import "strings"
+
+// ErrorCode identifies the kind of failure reported by the API in
+// CoPilotError.Code. It implements error so that it can be matched with
+// errors.Is anywhere in an error's chain:
+//
+//	if errors.Is(err, client.CodeModelOverloaded) {
+//		// fall back to a smaller model
+//	}
+type ErrorCode string
+
+// Error implements the error interface.
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+// Server error codes.
+const (
+	// Request errors
+	CodeInvalidRequest   ErrorCode = "invalid_request"
+	CodeValidationFailed ErrorCode = "validation_error"
+	CodePayloadTooLarge  ErrorCode = "payload_too_large"
+	CodeContextTooLarge  ErrorCode = "context_too_large"
+	CodeMethodNotAllowed ErrorCode = "method_not_allowed"
+
+	// Authentication and authorization errors
+	CodeUnauthorized       ErrorCode = "unauthorized"
+	CodeInvalidToken       ErrorCode = "invalid_token"
+	CodeExpiredToken       ErrorCode = "expired_token"
+	CodeInvalidCredentials ErrorCode = "invalid_credentials"
+	CodeForbidden          ErrorCode = "forbidden"
+	CodeInsufficientScope  ErrorCode = "insufficient_scope"
+	CodeFeatureNotEnabled  ErrorCode = "feature_not_enabled"
+
+	// Resource errors
+	CodeNotFound      ErrorCode = "not_found"
+	CodeAlreadyExists ErrorCode = "already_exists"
+	CodeConflict      ErrorCode = "conflict"
+
+	// Limits
+	CodeRateLimitExceeded ErrorCode = "rate_limit_exceeded"
+	CodeQuotaExceeded     ErrorCode = "quota_exceeded"
+
+	// State errors
+	CodeInvalidState     ErrorCode = "invalid_state"
+	CodeApprovalRequired ErrorCode = "approval_required"
+
+	// Model errors
+	CodeModelOverloaded ErrorCode = "model_overloaded"
+	CodeModelTimeout    ErrorCode = "model_timeout"
+	CodeModelError      ErrorCode = "model_error"
+	CodeContentFiltered ErrorCode = "content_filtered"
+
+	// Server errors
+	CodeInternalError      ErrorCode = "internal_error"
+	CodeServiceUnavailable ErrorCode = "service_unavailable"
+)
+
+// Is reports whether the error carries the code target, when target is an
+// ErrorCode. Codes are compared ignoring case, as some deployments send
+// them upper-case.
+func (e *CoPilotError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && e.Code != "" && strings.EqualFold(e.Code, string(code))
+}
+
+// HasCode reports whether the error carries any of codes.
+func (e *CoPilotError) HasCode(codes ...ErrorCode) bool {
+	for _, code := range codes {
+		if e.Is(code) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestErrorCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"code":"MODEL_OVERLOADED","message":"try again later"}`))
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, MaxRetries: 1, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	_, err := client.SendMessage(context.Background(), "conv-1", "hello")
+
+	// The code is found through the retry wrapping, ignoring case.
+	if !errors.Is(err, CodeModelOverloaded) {
+		t.Fatalf("expected %s, got %v", CodeModelOverloaded, err)
+	}
+	if errors.Is(err, CodeContextTooLarge) {
+		t.Error("unexpected match of another code")
+	}
+	var apiErr *CoPilotError
+	if !errors.As(err, &apiErr) || !apiErr.HasCode(CodeRateLimitExceeded, CodeModelOverloaded) {
+		t.Errorf("expected HasCode to match, got %v", err)
+	}
+	if (&CoPilotError{StatusCode: 500}).HasCode(CodeInternalError) {
+		t.Error("an error without a code matched")
+	}
+}
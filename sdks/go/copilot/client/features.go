@@ -0,0 +1,128 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Feature identifies an optional SDK capability that can be force-disabled.
+type Feature string
+
+const (
+	FeatureStreaming      Feature = "streaming"
+	FeatureWebSockets     Feature = "websockets"
+	FeatureBatch          Feature = "batch"
+	FeatureSemanticSearch Feature = "semantic_search"
+)
+
+// DisabledFeaturesEnv is the environment variable holding a comma-separated
+// list of features to disable, e.g. "streaming,websockets".
+const DisabledFeaturesEnv = "COPILOT_DISABLED_FEATURES"
+
+// ErrFeatureDisabled is returned when a call requires a disabled feature.
+var ErrFeatureDisabled = errors.New("feature disabled")
+
+// featureSet tracks which optional features are disabled.
+type featureSet struct {
+	mu       sync.RWMutex
+	disabled map[Feature]bool
+}
+
+// normalize returns f in the lowercase form the Feature constants use, so
+// names from the config, the environment and callers compare equal.
+func (f Feature) normalize() Feature {
+	return Feature(strings.ToLower(strings.TrimSpace(string(f))))
+}
+
+// newFeatureSet builds the feature set from the config and environment.
+func newFeatureSet(config *Config) *featureSet {
+	fs := &featureSet{disabled: make(map[Feature]bool)}
+	for _, f := range config.DisabledFeatures {
+		if f = f.normalize(); f != "" {
+			fs.disabled[f] = true
+		}
+	}
+	for _, name := range strings.Split(os.Getenv(DisabledFeaturesEnv), ",") {
+		if f := Feature(name).normalize(); f != "" {
+			fs.disabled[f] = true
+		}
+	}
+	return fs
+}
+
+func (fs *featureSet) enabled(f Feature) bool {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return !fs.disabled[f.normalize()]
+}
+
+func (fs *featureSet) set(f Feature, enabled bool) {
+	f = f.normalize()
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if enabled {
+		delete(fs.disabled, f)
+	} else {
+		fs.disabled[f] = true
+	}
+}
+
+// clone returns a copy of the feature set with disabled also disabled.
+func (fs *featureSet) clone(disabled []Feature) *featureSet {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	copied := &featureSet{disabled: make(map[Feature]bool, len(fs.disabled)+len(disabled))}
+	for f := range fs.disabled {
+		copied.disabled[f] = true
+	}
+	for _, f := range disabled {
+		if f = f.normalize(); f != "" {
+			copied.disabled[f] = true
+		}
+	}
+	return copied
+}
+
+// addedFeatures returns the features of after that are not in before.
+func addedFeatures(before, after []Feature) []Feature {
+	var added []Feature
+	for _, f := range after {
+		found := false
+		for _, g := range before {
+			if f == g {
+				found = true
+				break
+			}
+		}
+		if !found {
+			added = append(added, f)
+		}
+	}
+	return added
+}
+
+// FeatureEnabled reports whether an optional feature is currently enabled.
+func (c *Client) FeatureEnabled(f Feature) bool {
+	return c.features.enabled(f)
+}
+
+// DisableFeature force-disables an optional feature at runtime.
+func (c *Client) DisableFeature(f Feature) {
+	c.features.set(f, false)
+}
+
+// EnableFeature re-enables a previously disabled feature.
+func (c *Client) EnableFeature(f Feature) {
+	c.features.set(f, true)
+}
+
+// requireFeature returns ErrFeatureDisabled if the feature is disabled.
+func (c *Client) requireFeature(f Feature) error {
+	if !c.features.enabled(f) {
+		return fmt.Errorf("%w: %s", ErrFeatureDisabled, f)
+	}
+	return nil
+}
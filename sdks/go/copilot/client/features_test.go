@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestFeatureToggles(t *testing.T) {
+	t.Run("config", func(t *testing.T) {
+		client := New(&Config{DisabledFeatures: []Feature{FeatureStreaming}})
+		if client.FeatureEnabled(FeatureStreaming) {
+			t.Error("expected streaming to be disabled")
+		}
+		if !client.FeatureEnabled(FeatureBatch) {
+			t.Error("expected batch to be enabled")
+		}
+
+		client = New(&Config{DisabledFeatures: []Feature{"Streaming", " BATCH "}})
+		if client.FeatureEnabled(FeatureStreaming) || client.FeatureEnabled(FeatureBatch) {
+			t.Error("expected feature names to match regardless of case")
+		}
+		client.EnableFeature("STREAMING")
+		if !client.FeatureEnabled(FeatureStreaming) {
+			t.Error("expected streaming to be re-enabled")
+		}
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(DisabledFeaturesEnv, "websockets, Semantic_Search")
+		client := New(nil)
+		if client.FeatureEnabled(FeatureWebSockets) {
+			t.Error("expected websockets to be disabled")
+		}
+		if client.FeatureEnabled(FeatureSemanticSearch) {
+			t.Error("expected semantic search to be disabled")
+		}
+	})
+
+	t.Run("runtime", func(t *testing.T) {
+		client := New(nil)
+		client.DisableFeature(FeatureStreaming)
+		_, err := client.StreamMessage(context.Background(), "conv-123", "Hello!")
+		if !errors.Is(err, ErrFeatureDisabled) {
+			t.Errorf("expected ErrFeatureDisabled, got %v", err)
+		}
+		client.EnableFeature(FeatureStreaming)
+		if !client.FeatureEnabled(FeatureStreaming) {
+			t.Error("expected streaming to be re-enabled")
+		}
+	})
+}
+
+func TestSendMessageStreaming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/conversations/conv-123/messages/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"type\":\"message_start\",\"message_id\":\"msg-1\"}\n\n")
+			fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"Hel\"}}\n\n")
+			fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"lo\"}}\n\n")
+			fmt.Fprint(w, "data: {\"type\":\"message_end\",\"message_id\":\"msg-1\"}\n\n")
+		case "/api/v1/conversations/conv-123/messages":
+			json.NewEncoder(w).Encode(models.Message{ID: "msg-2", Content: "Hello"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	for _, streamingEnabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("streaming=%v", streamingEnabled), func(t *testing.T) {
+			client := NewWithAPIKey(server.URL, "test-key")
+			if !streamingEnabled {
+				client.DisableFeature(FeatureStreaming)
+			}
+
+			var chunks []string
+			msg, err := client.SendMessageStreaming(context.Background(), "conv-123", "Hi", func(s string) {
+				chunks = append(chunks, s)
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if msg.Content != "Hello" {
+				t.Errorf("expected content 'Hello', got %q", msg.Content)
+			}
+			if strings.Join(chunks, "") != "Hello" {
+				t.Errorf("expected chunks to join to 'Hello', got %v", chunks)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// SubmitFeedback records a rating on a message.
+func (c *Client) SubmitFeedback(ctx context.Context, messageID models.MessageID, feedback models.Feedback) (*models.MessageFeedback, error) {
+	if err := messageID.Validate(); err != nil {
+		return nil, err
+	}
+
+	var resp models.MessageFeedback
+	path := fmt.Sprintf("/api/v1/messages/%s/feedback", messageID)
+	if err := c.post(ctx, path, feedback, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListFeedback returns a page of recorded feedback matching the query. Pass
+// the returned NextCursor in the next query to fetch the following page.
+func (c *Client) ListFeedback(ctx context.Context, query models.FeedbackQuery) (*models.PaginatedResponse[models.MessageFeedback], error) {
+	params := feedbackQueryValues(query)
+	if query.Cursor != "" {
+		params.Del("offset")
+		params.Set("cursor", query.Cursor)
+	}
+	return List[models.MessageFeedback](ctx, c, "/api/v1/feedback", &ListOptions{Query: params})
+}
+
+// ExportFeedback writes all feedback matching the query to w in the given format.
+func (c *Client) ExportFeedback(ctx context.Context, query models.FeedbackQuery, format models.FeedbackExportFormat, w io.Writer) error {
+	params := feedbackQueryValues(query)
+	if format == "" {
+		format = models.FeedbackExportJSONL
+	}
+	params.Set("format", string(format))
+
+	return c.download(ctx, "/api/v1/feedback/export?"+params.Encode(), w)
+}
+
+// feedbackQueryValues encodes a feedback query as URL parameters.
+func feedbackQueryValues(query models.FeedbackQuery) url.Values {
+	params := url.Values{}
+	if query.ConversationID != "" {
+		params.Set("conversation_id", query.ConversationID.String())
+	}
+	if query.Rating != "" {
+		params.Set("rating", string(query.Rating))
+	}
+	if query.Category != "" {
+		params.Set("category", query.Category)
+	}
+	if !query.From.IsZero() {
+		params.Set("from", query.From.UTC().Format(time.RFC3339))
+	}
+	if !query.To.IsZero() {
+		params.Set("to", query.To.UTC().Format(time.RFC3339))
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+	return params
+}
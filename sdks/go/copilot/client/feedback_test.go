@@ -0,0 +1,96 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestSubmitFeedback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/messages/msg-123/feedback" {
+			t.Errorf("expected path /api/v1/messages/msg-123/feedback, got %s", r.URL.Path)
+		}
+
+		var req models.Feedback
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Rating != models.RatingThumbsDown {
+			t.Errorf("expected rating 'thumbs_down', got %s", req.Rating)
+		}
+
+		json.NewEncoder(w).Encode(models.MessageFeedback{
+			ID:         "fb-1",
+			MessageID:  "msg-123",
+			Rating:     req.Rating,
+			Categories: req.Categories,
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	fb, err := client.SubmitFeedback(context.Background(), "msg-123", models.Feedback{
+		Rating:     models.RatingThumbsDown,
+		Categories: []string{"inaccurate"},
+		Comment:    "Wrong answer",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fb.ID != "fb-1" {
+		t.Errorf("expected ID 'fb-1', got %s", fb.ID)
+	}
+}
+
+func TestListFeedback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("rating") != "thumbs_up" {
+			t.Errorf("expected rating filter, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("limit") != "10" {
+			t.Errorf("expected limit 10, got %s", r.URL.Query().Get("limit"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": []models.MessageFeedback{{ID: "fb-1"}, {ID: "fb-2"}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	items, err := client.ListFeedback(context.Background(), models.FeedbackQuery{
+		Rating: models.RatingThumbsUp,
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items.Items) != 2 {
+		t.Errorf("expected 2 items, got %d", len(items.Items))
+	}
+}
+
+func TestExportFeedback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/feedback/export" {
+			t.Errorf("expected path /api/v1/feedback/export, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("format") != "csv" {
+			t.Errorf("expected format csv, got %s", r.URL.Query().Get("format"))
+		}
+		w.Write([]byte("id,rating\nfb-1,thumbs_up\n"))
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	var buf bytes.Buffer
+	if err := client.ExportFeedback(context.Background(), models.FeedbackQuery{}, models.FeedbackExportCSV, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "id,rating\nfb-1,thumbs_up\n" {
+		t.Errorf("unexpected export body: %q", buf.String())
+	}
+}
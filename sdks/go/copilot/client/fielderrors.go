@@ -0,0 +1,88 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// fieldErrors returns the field errors of an error response, which some
+// servers nest in its details.
+func fieldErrors(apiErr *models.APIError) []models.FieldError {
+	if len(apiErr.FieldErrors) > 0 {
+		return apiErr.FieldErrors
+	}
+	nested, ok := apiErr.Details["field_errors"]
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(nested)
+	if err != nil {
+		return nil
+	}
+	var errs []models.FieldError
+	if json.Unmarshal(data, &errs) != nil {
+		return nil
+	}
+	return errs
+}
+
+// Field returns the first validation error of the field at path, or nil
+// if it has none. Array indexes may be written "steps[2].type" or
+// "steps.2.type".
+func (e *CoPilotError) Field(path string) *models.FieldError {
+	path = normalizeFieldPath(path)
+	for i := range e.FieldErrors {
+		if normalizeFieldPath(e.FieldErrors[i].Field) == path {
+			return &e.FieldErrors[i]
+		}
+	}
+	return nil
+}
+
+// FieldErrorsUnder returns the validation errors of the field at prefix
+// and of the fields nested in it, e.g. all errors of "steps[2]".
+func (e *CoPilotError) FieldErrorsUnder(prefix string) []models.FieldError {
+	prefix = normalizeFieldPath(prefix)
+	var errs []models.FieldError
+	for _, fe := range e.FieldErrors {
+		field := normalizeFieldPath(fe.Field)
+		if prefix == "" || field == prefix || strings.HasPrefix(field, prefix) && strings.ContainsRune(".[", rune(field[len(prefix)])) {
+			errs = append(errs, fe)
+		}
+	}
+	return errs
+}
+
+// Fields returns the paths of the fields that failed validation, in the
+// order the server reported them, without duplicates.
+func (e *CoPilotError) Fields() []string {
+	seen := make(map[string]bool, len(e.FieldErrors))
+	var fields []string
+	for _, fe := range e.FieldErrors {
+		if !seen[fe.Field] {
+			seen[fe.Field] = true
+			fields = append(fields, fe.Field)
+		}
+	}
+	return fields
+}
+
+// normalizeFieldPath writes numeric segments of a dotted path as indexes,
+// so that "steps.2.type" becomes "steps[2].type".
+func normalizeFieldPath(path string) string {
+	segments := strings.Split(path, ".")
+	var b strings.Builder
+	for i, segment := range segments {
+		switch {
+		case i > 0 && segment != "" && strings.Trim(segment, "0123456789") == "":
+			b.WriteString("[" + segment + "]")
+		case i > 0:
+			b.WriteString("." + segment)
+		default:
+			b.WriteString(segment)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestFieldErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"validation_error","message":"invalid workflow","details":{"field_errors":[
+			{"field":"name","message":"Name must be 1-200 characters","constraint":"length: 1-200"},
+			{"field":"steps[2].type","message":"unknown step type"},
+			{"field":"steps[2].timeout","message":"must be positive"},
+			{"field":"steps[12].type","message":"unknown step type"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	_, err := client.CreateConversation(context.Background(), nil)
+	var apiErr *CoPilotError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected a CoPilotError, got %v", err)
+	}
+
+	if fe := apiErr.Field("name"); fe == nil || fe.Constraint != "length: 1-200" {
+		t.Errorf("unexpected name error: %+v", fe)
+	}
+	if fe := apiErr.Field("steps.2.type"); fe == nil || fe.Message != "unknown step type" {
+		t.Errorf("unexpected steps[2].type error: %+v", fe)
+	}
+	if fe := apiErr.Field("steps[1].type"); fe != nil {
+		t.Errorf("unexpected error for a valid field: %+v", fe)
+	}
+	if got := apiErr.FieldErrorsUnder("steps[2]"); len(got) != 2 {
+		t.Errorf("expected 2 errors under steps[2], got %+v", got)
+	}
+	want := []string{"name", "steps[2].type", "steps[2].timeout", "steps[12].type"}
+	if got := apiErr.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected fields %v, got %v", want, got)
+	}
+}
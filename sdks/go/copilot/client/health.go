@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// ErrUnhealthy is returned by WaitUntilHealthy, along with the context's
+// error, when the server did not become healthy in time.
+var ErrUnhealthy = errors.New("server not healthy")
+
+// DefaultWaitInterval is the polling interval of WaitUntilHealthy.
+const DefaultWaitInterval = time.Second
+
+// WaitOptions configures WaitUntilHealthy.
+type WaitOptions struct {
+	// Interval is the time between readiness probes. Defaults to
+	// DefaultWaitInterval.
+	Interval time.Duration
+	// Timeout bounds the wait. Zero waits until ctx is done.
+	Timeout time.Duration
+	// RequiredComponents are the components that must be healthy. When
+	// set, the server is considered ready once they are, even if other
+	// components are degraded; otherwise the overall status must be
+	// healthy.
+	RequiredComponents []string
+}
+
+// WaitUntilHealthy polls Readiness until the server is ready, for services
+// that must block startup on the CoPilot API and its dependencies.
+// Connection errors are treated as not ready yet. It returns the first
+// healthy status, or an error wrapping ErrUnhealthy and the context's
+// error that describes the last failure.
+func (c *Client) WaitUntilHealthy(ctx context.Context, opts WaitOptions) (*models.HealthStatus, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		status, err := c.Readiness(ctx)
+		if err == nil {
+			if err = checkHealth(status, opts.RequiredComponents); err == nil {
+				return status, nil
+			}
+		}
+		// A probe cut short by the deadline says nothing about the server.
+		if ctx.Err() == nil || lastErr == nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w (last: %v)", ErrUnhealthy, ctx.Err(), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkHealth reports why status does not satisfy the required components.
+func checkHealth(status *models.HealthStatus, required []string) error {
+	if len(required) == 0 {
+		if !status.Healthy() {
+			return fmt.Errorf("status %s", status.Status)
+		}
+		return nil
+	}
+	for _, name := range required {
+		component, ok := status.Components[name]
+		if !ok {
+			return fmt.Errorf("component %s not reported", name)
+		}
+		if component.Status != models.HealthHealthy {
+			if component.Error != "" {
+				return fmt.Errorf("component %s %s: %s", name, component.Status, component.Error)
+			}
+			return fmt.Errorf("component %s %s", name, component.Status)
+		}
+	}
+	return nil
+}
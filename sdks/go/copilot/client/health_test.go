@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestWaitUntilHealthy(t *testing.T) {
+	var probes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes++
+		status := models.HealthStatus{
+			Status: models.HealthDegraded,
+			Components: map[string]models.ComponentHealth{
+				"database": {Status: models.HealthUnhealthy, Error: "starting"},
+				"cache":    {Status: models.HealthUnhealthy},
+			},
+		}
+		if probes >= 3 {
+			status.Components["database"] = models.ComponentHealth{Status: models.HealthHealthy}
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(status)
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	status, err := client.WaitUntilHealthy(ctx, WaitOptions{
+		Interval:           time.Millisecond,
+		Timeout:            5 * time.Second,
+		RequiredComponents: []string{"database"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if probes != 3 || status.Components["database"].Status != models.HealthHealthy {
+		t.Errorf("unexpected status after %d probes: %+v", probes, status)
+	}
+
+	_, err = client.WaitUntilHealthy(ctx, WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	if !errors.Is(err, ErrUnhealthy) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected unhealthy deadline error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "status degraded") {
+		t.Errorf("expected last failure in error, got %v", err)
+	}
+}
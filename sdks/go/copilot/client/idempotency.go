@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// IdempotencyKeyHeader is the header carrying a request's idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyKey struct{}
+
+// WithIdempotencyKey returns a context that sends the given idempotency key
+// with requests made with it, so the server applies a mutation at most once
+// however many times it is retried or replayed.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key stored in the context.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyKey{}).(string)
+	return key, ok && key != ""
+}
+
+// NewIdempotencyKey returns a random idempotency key.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("copilot: failed to generate idempotency key: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// CreateLabel creates a label. Set ParentID to nest it in another label,
+// as a folder.
+func (c *Client) CreateLabel(ctx context.Context, req models.LabelCreate) (*models.Label, error) {
+	var label models.Label
+	if err := c.post(ctx, "/api/v1/labels", req, &label); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// GetLabel retrieves a label.
+func (c *Client) GetLabel(ctx context.Context, id string) (*models.Label, error) {
+	var label models.Label
+	if err := c.get(ctx, "/api/v1/labels/"+id, &label); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// ListLabels returns all labels in the workspace.
+func (c *Client) ListLabels(ctx context.Context) ([]models.Label, error) {
+	fetch := func(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.Label], error) {
+		return List[models.Label](ctx, c, "/api/v1/labels", opts)
+	}
+	return NewPager(fetch, nil).All(ctx)
+}
+
+// UpdateLabel renames, recolors or moves a label.
+func (c *Client) UpdateLabel(ctx context.Context, id string, req models.LabelUpdate) (*models.Label, error) {
+	var label models.Label
+	if err := c.patch(ctx, "/api/v1/labels/"+id, req, &label); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// DeleteLabel deletes a label and removes it from every conversation.
+func (c *Client) DeleteLabel(ctx context.Context, id string) error {
+	return c.delete(ctx, "/api/v1/labels/"+id)
+}
+
+// AddLabel applies a label to a conversation and returns the updated
+// conversation. Adding a label the conversation already has is a no-op.
+func (c *Client) AddLabel(ctx context.Context, conversationID models.ConversationID, labelID string) (*models.Conversation, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+	if labelID == "" {
+		return nil, errors.New("label ID is empty")
+	}
+
+	req := map[string]string{"label_id": labelID}
+
+	var conv models.Conversation
+	path := fmt.Sprintf("/api/v1/conversations/%s/labels", conversationID)
+	if err := c.post(ctx, path, req, &conv); err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
+
+// RemoveLabel removes a label from a conversation. The label itself is
+// kept.
+func (c *Client) RemoveLabel(ctx context.Context, conversationID models.ConversationID, labelID string) error {
+	if err := conversationID.Validate(); err != nil {
+		return err
+	}
+	if labelID == "" {
+		return errors.New("label ID is empty")
+	}
+	return c.delete(ctx, fmt.Sprintf("/api/v1/conversations/%s/labels/%s", conversationID, labelID))
+}
+
+// ListConversationsByLabel returns a page of the conversations carrying
+// all of the given labels. With no labels it lists every conversation.
+func (c *Client) ListConversationsByLabel(ctx context.Context, labelIDs []string, opts *ListOptions) (*models.PaginatedResponse[models.Conversation], error) {
+	if len(labelIDs) > 0 {
+		opts = opts.withFilter("label", strings.Join(labelIDs, ","))
+	}
+	return List[models.Conversation](ctx, c, "/api/v1/conversations", opts)
+}
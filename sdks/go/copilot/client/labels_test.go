@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestLabelLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/labels":
+			var req models.LabelCreate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "Billing" || req.ParentID != "lbl-0" {
+				t.Errorf("unexpected label request %+v", req)
+			}
+			json.NewEncoder(w).Encode(models.Label{ID: "lbl-1", Name: req.Name, ParentID: req.ParentID})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/labels/lbl-1":
+			json.NewEncoder(w).Encode(models.Label{ID: "lbl-1", Name: "Billing"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/labels":
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.Label]{Items: []models.Label{{ID: "lbl-0"}, {ID: "lbl-1"}}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/labels/lbl-1":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req) != 1 || req["color"] != "#ff0000" {
+				t.Errorf("expected only the color in the update, got %v", req)
+			}
+			json.NewEncoder(w).Encode(models.Label{ID: "lbl-1", Color: "#ff0000"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/labels/lbl-1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/conversations/conv-1/labels":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["label_id"] != "lbl-1" {
+				t.Errorf("unexpected label_id %q", req["label_id"])
+			}
+			json.NewEncoder(w).Encode(models.Conversation{ID: "conv-1", Labels: []string{"lbl-1"}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/conversations/conv-1/labels/lbl-1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/conversations":
+			if got := r.URL.Query().Get("label"); got != "lbl-0,lbl-1" {
+				t.Errorf("unexpected label filter %q", got)
+			}
+			if got := r.URL.Query().Get("limit"); got != "5" {
+				t.Errorf("unexpected limit %q", got)
+			}
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.Conversation]{Items: []models.Conversation{{ID: "conv-1"}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	label, err := client.CreateLabel(ctx, models.LabelCreate{Name: "Billing", ParentID: "lbl-0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if label.ID != "lbl-1" || label.ParentID != "lbl-0" {
+		t.Errorf("unexpected label %+v", label)
+	}
+	if label, err = client.GetLabel(ctx, "lbl-1"); err != nil || label.Name != "Billing" {
+		t.Errorf("unexpected label %+v, error %v", label, err)
+	}
+	labels, err := client.ListLabels(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != 2 {
+		t.Errorf("expected 2 labels, got %d", len(labels))
+	}
+	color := "#ff0000"
+	if label, err = client.UpdateLabel(ctx, "lbl-1", models.LabelUpdate{Color: &color}); err != nil || label.Color != color {
+		t.Errorf("unexpected label %+v, error %v", label, err)
+	}
+
+	conv, err := client.AddLabel(ctx, "conv-1", "lbl-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conv.Labels) != 1 || conv.Labels[0] != "lbl-1" {
+		t.Errorf("unexpected labels %v", conv.Labels)
+	}
+	page, err := client.ListConversationsByLabel(ctx, []string{"lbl-0", "lbl-1"}, &ListOptions{Limit: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Errorf("expected 1 conversation, got %d", len(page.Items))
+	}
+	if err := client.RemoveLabel(ctx, "conv-1", "lbl-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.DeleteLabel(ctx, "lbl-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.AddLabel(ctx, "conv-1", ""); err == nil {
+		t.Error("expected an error for an empty label ID")
+	}
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// ListOptions controls pagination and filtering for List.
+type ListOptions struct {
+	// Limit is the maximum number of items to return. Zero uses the
+	// server default.
+	Limit int
+	// Offset is the number of items to skip. It is ignored when Cursor
+	// is set.
+	Offset int
+	// Cursor resumes listing after the page that returned it as
+	// NextCursor. Cursors stay stable while items are added or removed,
+	// which offsets do not.
+	Cursor string
+	// Query holds endpoint-specific filter parameters.
+	Query url.Values
+}
+
+// values encodes the options as URL parameters.
+func (o *ListOptions) values() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	for key, vals := range o.Query {
+		params[key] = append([]string(nil), vals...)
+	}
+	if o.Limit > 0 {
+		params.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		params.Set("cursor", o.Cursor)
+	} else if o.Offset > 0 {
+		params.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return params
+}
+
+// MessageListOptions filters ListMessages by creation time.
+type MessageListOptions struct {
+	ListOptions
+	// Since limits the listing to messages created after this time,
+	// typically the CreatedAt of the last message already synced.
+	Since time.Time
+	// Until limits the listing to messages created before this time.
+	Until time.Time
+	// Order sorts by creation time; the server default is ascending.
+	Order models.SortOrder
+}
+
+// listOptions merges the time window into the list options.
+func (o *MessageListOptions) listOptions() *ListOptions {
+	if o == nil {
+		return nil
+	}
+	opts := &o.ListOptions
+	if !o.Since.IsZero() {
+		opts = opts.withFilter("since", o.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if !o.Until.IsZero() {
+		opts = opts.withFilter("until", o.Until.UTC().Format(time.RFC3339Nano))
+	}
+	if o.Order != "" {
+		opts = opts.withFilter("order", string(o.Order))
+	}
+	return opts
+}
+
+// withFilter returns a copy of o with the filter key set to value.
+func (o *ListOptions) withFilter(key, value string) *ListOptions {
+	opts := ListOptions{}
+	if o != nil {
+		opts = *o
+	}
+	query := url.Values{}
+	for k, vals := range opts.Query {
+		query[k] = vals
+	}
+	query.Set(key, value)
+	opts.Query = query
+	return &opts
+}
+
+// List fetches one page of T from a paginated endpoint. It lets callers
+// reach list endpoints the client has no dedicated method for.
+func List[T any](ctx context.Context, c *Client, path string, opts *ListOptions) (*models.PaginatedResponse[T], error) {
+	var page models.PaginatedResponse[T]
+	if err := c.get(ctx, withQuery(path, opts.values()), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Get fetches a single T from path.
+func Get[T any](ctx context.Context, c *Client, path string) (*T, error) {
+	var v T
+	if err := c.get(ctx, path, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// withQuery appends params to path, which may already carry a query.
+func withQuery(path string, params url.Values) string {
+	if len(params) == 0 {
+		return path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&" + params.Encode()
+	}
+	return path + "?" + params.Encode()
+}
+
+// PageFunc fetches one page of a list. List methods such as
+// Client.ListConversations can be used directly as a PageFunc.
+type PageFunc[T any] func(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[T], error)
+
+// Pager walks a list page by page. It follows NextCursor when the server
+// returns one and falls back to offsets when it does not.
+type Pager[T any] struct {
+	fetch PageFunc[T]
+	opts  ListOptions
+	// offset is the position of the next item, used when the server
+	// returns no cursor.
+	offset int
+	done   bool
+}
+
+// NewPager returns a Pager that starts at opts.
+func NewPager[T any](fetch PageFunc[T], opts *ListOptions) *Pager[T] {
+	p := &Pager[T]{fetch: fetch}
+	if opts != nil {
+		p.opts = *opts
+	}
+	if p.opts.Cursor == "" {
+		p.offset = p.opts.Offset
+	}
+	return p
+}
+
+// More reports whether another page may be available.
+func (p *Pager[T]) More() bool {
+	return !p.done
+}
+
+// Next fetches the next page. It returns io.EOF once the list is exhausted.
+func (p *Pager[T]) Next(ctx context.Context) (*models.PaginatedResponse[T], error) {
+	if p.done {
+		return nil, io.EOF
+	}
+
+	opts := p.opts
+	page, err := p.fetch(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+	p.offset += len(page.Items)
+
+	switch {
+	case page.NextCursor != "":
+		p.opts.Cursor = page.NextCursor
+	case page.HasMore && len(page.Items) > 0:
+		p.opts.Cursor = ""
+		p.opts.Offset = p.offset
+	default:
+		p.done = true
+	}
+	return page, nil
+}
+
+// All fetches every remaining page and returns their items.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for p.More() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, page.Items...)
+	}
+	return items, nil
+}
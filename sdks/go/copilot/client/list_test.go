@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+type widget struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestGenericListAndGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/widgets":
+			q := r.URL.Query()
+			if q.Get("limit") != "2" || q.Get("offset") != "4" || q.Get("color") != "blue" || q.Get("sort") != "name" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(models.PaginatedResponse[widget]{
+				Items:   []widget{{ID: "w-5", Name: "five"}, {ID: "w-6", Name: "six"}},
+				Total:   10,
+				HasMore: true,
+			})
+		case "/api/v1/widgets/w-5":
+			json.NewEncoder(w).Encode(widget{ID: "w-5", Name: "five"})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	page, err := List[widget](ctx, client, "/api/v1/widgets?sort=name", &ListOptions{
+		Limit:  2,
+		Offset: 4,
+		Query:  url.Values{"color": {"blue"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 2 || page.Total != 10 || !page.HasMore {
+		t.Errorf("unexpected page: %+v", page)
+	}
+
+	item, err := Get[widget](ctx, client, "/api/v1/widgets/w-5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Name != "five" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+}
+
+func TestPagerPrefersCursor(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, r.URL.RawQuery)
+		var page models.PaginatedResponse[models.Conversation]
+		switch {
+		case q.Get("cursor") == "" && q.Get("offset") == "":
+			page = models.PaginatedResponse[models.Conversation]{
+				Items: []models.Conversation{{ID: "conv-1"}, {ID: "conv-2"}}, HasMore: true, NextCursor: "abc",
+			}
+		case q.Get("cursor") == "abc":
+			// The server stops issuing cursors mid-listing.
+			page = models.PaginatedResponse[models.Conversation]{
+				Items: []models.Conversation{{ID: "conv-3"}, {ID: "conv-4"}}, HasMore: true,
+			}
+		case q.Get("offset") == "4":
+			page = models.PaginatedResponse[models.Conversation]{Items: []models.Conversation{{ID: "conv-5"}}}
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL})
+	pager := NewPager(client.ListConversations, &ListOptions{Limit: 2})
+	convs, err := pager.All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(convs) != 5 || convs[4].ID != "conv-5" {
+		t.Errorf("unexpected conversations: %+v", convs)
+	}
+	if pager.More() {
+		t.Error("expected pager to be exhausted")
+	}
+	if _, err := pager.Next(context.Background()); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if len(queries) != 3 || queries[1] != "cursor=abc&limit=2" {
+		t.Errorf("unexpected queries: %v", queries)
+	}
+}
+
+func TestListMessagesTimeWindow(t *testing.T) {
+	since := time.Date(2024, 3, 15, 10, 30, 0, 500, time.UTC)
+	until := since.Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v1/conversations/conv-1/messages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q.Get("since") != "2024-03-15T10:30:00.0000005Z" || q.Get("until") != "2024-03-15T11:30:00.0000005Z" {
+			t.Errorf("unexpected window: %s", r.URL.RawQuery)
+		}
+		if q.Get("order") != "desc" || q.Get("limit") != "50" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(models.PaginatedResponse[models.Message]{Items: []models.Message{{ID: "msg-9"}}})
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL})
+	page, err := client.ListMessages(context.Background(), "conv-1", &MessageListOptions{
+		ListOptions: ListOptions{Limit: 50},
+		Since:       since,
+		Until:       until,
+		Order:       models.OrderDesc,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Errorf("expected 1 message, got %d", len(page.Items))
+	}
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// memoryPath returns the path of a memory namespace, or of a key in it.
+func memoryPath(namespace string, key ...string) (string, error) {
+	if namespace == "" {
+		return "", errors.New("memory namespace is empty")
+	}
+	path := "/api/v1/memory/" + url.PathEscape(namespace)
+	for _, k := range key {
+		if k == "" {
+			return "", errors.New("memory key is empty")
+		}
+		path += "/" + url.PathEscape(k)
+	}
+	return path, nil
+}
+
+// PutMemory stores value under key in an agent memory namespace,
+// replacing any value already there. The value is any JSON-encodable
+// fact; it is recalled across conversations.
+func (c *Client) PutMemory(ctx context.Context, namespace, key string, value interface{}) (*models.Memory, error) {
+	path, err := memoryPath(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+
+	req := map[string]interface{}{"value": value}
+
+	var mem models.Memory
+	if err := c.request(ctx, http.MethodPut, path, req, &mem); err != nil {
+		return nil, err
+	}
+	return &mem, nil
+}
+
+// GetMemory returns the memory stored under key.
+func (c *Client) GetMemory(ctx context.Context, namespace, key string) (*models.Memory, error) {
+	path, err := memoryPath(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var mem models.Memory
+	if err := c.get(ctx, path, &mem); err != nil {
+		return nil, err
+	}
+	return &mem, nil
+}
+
+// DeleteMemory forgets the memory stored under key.
+func (c *Client) DeleteMemory(ctx context.Context, namespace, key string) error {
+	path, err := memoryPath(namespace, key)
+	if err != nil {
+		return err
+	}
+	return c.delete(ctx, path)
+}
+
+// QueryMemory returns the memories in a namespace relevant to query, most
+// relevant first, with their scores. An empty query lists every memory.
+func (c *Client) QueryMemory(ctx context.Context, namespace, query string) ([]models.Memory, error) {
+	path, err := memoryPath(namespace)
+	if err != nil {
+		return nil, err
+	}
+	var opts *ListOptions
+	if query != "" {
+		opts = opts.withFilter("q", query)
+	}
+	fetch := func(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.Memory], error) {
+		return List[models.Memory](ctx, c, path, opts)
+	}
+	return NewPager(fetch, opts).All(ctx)
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestMemoryLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.EscapedPath() == "/api/v1/memory/user%2F42/favorite%20color":
+			var req struct {
+				Value map[string]string `json:"value"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Value["color"] != "teal" {
+				t.Errorf("unexpected value %v", req.Value)
+			}
+			json.NewEncoder(w).Encode(models.Memory{Namespace: "user/42", Key: "favorite color", Value: req.Value})
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v1/memory/user%2F42/favorite%20color":
+			json.NewEncoder(w).Encode(models.Memory{Namespace: "user/42", Key: "favorite color", Value: "teal"})
+		case r.Method == http.MethodDelete && r.URL.EscapedPath() == "/api/v1/memory/user%2F42/favorite%20color":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v1/memory/user%2F42":
+			if got := r.URL.Query().Get("q"); got != "colors" {
+				t.Errorf("unexpected query %q", got)
+			}
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.Memory]{Items: []models.Memory{{Key: "favorite color", Score: 0.9}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	mem, err := client.PutMemory(ctx, "user/42", "favorite color", map[string]string{"color": "teal"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mem.Key != "favorite color" {
+		t.Errorf("unexpected memory %+v", mem)
+	}
+	if mem, err = client.GetMemory(ctx, "user/42", "favorite color"); err != nil || mem.Value != "teal" {
+		t.Errorf("unexpected memory %+v, error %v", mem, err)
+	}
+	memories, err := client.QueryMemory(ctx, "user/42", "colors")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(memories) != 1 || memories[0].Score != 0.9 {
+		t.Errorf("unexpected memories %+v", memories)
+	}
+	if err := client.DeleteMemory(ctx, "user/42", "favorite color"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.GetMemory(ctx, "", "key"); err == nil {
+		t.Error("expected an error for an empty namespace")
+	}
+	if _, err := client.GetMemory(ctx, "user/42", ""); err == nil {
+		t.Error("expected an error for an empty key")
+	}
+}
@@ -0,0 +1,461 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// WireFormat is the encoding of request and response bodies.
+type WireFormat string
+
+// Supported wire formats.
+const (
+	// FormatJSON encodes bodies as JSON. It is the default.
+	FormatJSON WireFormat = "json"
+	// FormatMsgpack encodes bodies as MessagePack once the server has
+	// shown it supports it, falling back to JSON otherwise.
+	FormatMsgpack WireFormat = "msgpack"
+)
+
+// Media types of the wire formats.
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeMsgpack = "application/msgpack"
+)
+
+// wireState records whether the server supports MessagePack. It is shared
+// with copies made by ForTenant.
+type wireState struct {
+	msgpack atomic.Bool
+}
+
+// requestFormat returns the content type to encode request bodies with.
+// MessagePack bodies are only sent once a response has advertised support,
+// so servers that only speak JSON never see one.
+func (c *Client) requestFormat() string {
+	if c.config.WireFormat == FormatMsgpack && c.wire.msgpack.Load() {
+		return ContentTypeMsgpack
+	}
+	return ContentTypeJSON
+}
+
+// acceptHeader returns the Accept header for JSON API requests.
+func (c *Client) acceptHeader() string {
+	if c.config.WireFormat == FormatMsgpack {
+		return ContentTypeMsgpack + ", " + ContentTypeJSON + ";q=0.9"
+	}
+	return ContentTypeJSON
+}
+
+// rejectedMsgpack reports whether resp refuses a MessagePack request body,
+// in which case the client stops sending them.
+func (c *Client) rejectedMsgpack(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnsupportedMediaType || !isMsgpack(req.Header.Get("Content-Type")) {
+		return false
+	}
+	c.wire.msgpack.Store(false)
+	return true
+}
+
+// decodeWireFormat rewrites a MessagePack response body as JSON, so that
+// the rest of the client only ever decodes JSON. A MessagePack response
+// advertises that the server accepts MessagePack requests too.
+func (c *Client) decodeWireFormat(resp *http.Response) error {
+	if !isMsgpack(resp.Header.Get("Content-Type")) {
+		return nil
+	}
+	c.wire.msgpack.Store(true)
+
+	data, err := io.ReadAll(c.limitBody(resp.Body))
+	resp.Body.Close()
+	if err != nil {
+		if errors.Is(err, ErrResponseTooLarge) {
+			return err
+		}
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	var jsonBody []byte
+	if len(data) > 0 {
+		if jsonBody, err = msgpackToJSON(data); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(jsonBody))
+	resp.Header.Set("Content-Type", ContentTypeJSON)
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(jsonBody))
+	return nil
+}
+
+func isMsgpack(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == ContentTypeMsgpack || mediaType == "application/x-msgpack"
+}
+
+// jsonToMsgpack re-encodes a JSON document as MessagePack, keeping the
+// order of object keys. Going through JSON keeps the models' struct tags
+// and custom marshalers authoritative for both formats.
+func jsonToMsgpack(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return appendMsgpackValue(nil, dec)
+}
+
+func appendMsgpackValue(b []byte, dec *json.Decoder) ([]byte, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := tok.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case string:
+		return appendMsgpackString(b, v), nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return appendMsgpackInt(b, n), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, 0xcb)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(f)), nil
+	case json.Delim:
+		var body []byte
+		n := 0
+		for dec.More() {
+			if v == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				body = appendMsgpackString(body, key.(string))
+			}
+			if body, err = appendMsgpackValue(body, dec); err != nil {
+				return nil, err
+			}
+			n++
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		if v == '{' {
+			b = appendMsgpackHeader(b, n, 0x80, 0xde)
+		} else {
+			b = appendMsgpackHeader(b, n, 0x90, 0xdc)
+		}
+		return append(b, body...), nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
+
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= 0x7f, n < 0 && n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8 && n <= math.MaxInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16 && n <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// appendMsgpackHeader writes the header of a map or array of n entries,
+// given the fix and 16-bit type bytes; the 32-bit type follows the latter.
+func appendMsgpackHeader(b []byte, n int, fix, wide byte) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, wide), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, wide+1), uint32(n))
+}
+
+// msgpackToJSON re-encodes a MessagePack document as JSON. Binary values
+// become base64 strings and timestamps RFC 3339 strings, as encoding/json
+// would write them.
+func msgpackToJSON(data []byte) ([]byte, error) {
+	d := &msgpackDecoder{b: data}
+	var buf bytes.Buffer
+	if err := d.value(&buf, 0); err != nil {
+		return nil, err
+	}
+	if len(d.b) > 0 {
+		return nil, errors.New("msgpack: trailing data")
+	}
+	return buf.Bytes(), nil
+}
+
+// maxMsgpackDepth bounds nesting so hostile input cannot exhaust the stack.
+const maxMsgpackDepth = 1000
+
+var errMsgpackTruncated = errors.New("msgpack: unexpected end of data")
+
+type msgpackDecoder struct {
+	b []byte
+}
+
+func (d *msgpackDecoder) read(n int) ([]byte, error) {
+	if n < 0 || n > len(d.b) {
+		return nil, errMsgpackTruncated
+	}
+	p := d.b[:n]
+	d.b = d.b[n:]
+	return p, nil
+}
+
+// uint reads a big-endian unsigned integer of size bytes.
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	p, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, c := range p {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+// length reads a length prefix of size bytes.
+func (d *msgpackDecoder) length(size int) (int, error) {
+	n, err := d.uint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.b)) {
+		return 0, errMsgpackTruncated
+	}
+	return int(n), nil
+}
+
+func (d *msgpackDecoder) value(w *bytes.Buffer, depth int) error {
+	if depth > maxMsgpackDepth {
+		return errors.New("msgpack: nesting too deep")
+	}
+	p, err := d.read(1)
+	if err != nil {
+		return err
+	}
+	switch t := p[0]; {
+	case t <= 0x7f:
+		w.WriteString(strconv.Itoa(int(t)))
+	case t >= 0xe0:
+		w.WriteString(strconv.Itoa(int(int8(t))))
+	case t >= 0x80 && t <= 0x8f:
+		return d.mapBody(w, int(t&0x0f), depth)
+	case t >= 0x90 && t <= 0x9f:
+		return d.arrayBody(w, int(t&0x0f), depth)
+	case t >= 0xa0 && t <= 0xbf:
+		return d.str(w, int(t&0x1f))
+	case t == 0xc0:
+		w.WriteString("null")
+	case t == 0xc2:
+		w.WriteString("false")
+	case t == 0xc3:
+		w.WriteString("true")
+	case t >= 0xc4 && t <= 0xc6:
+		n, err := d.length(1 << (t - 0xc4))
+		if err != nil {
+			return err
+		}
+		data, _ := d.read(n)
+		return writeJSON(w, base64.StdEncoding.EncodeToString(data))
+	case t >= 0xc7 && t <= 0xc9:
+		n, err := d.length(1 << (t - 0xc7))
+		if err != nil {
+			return err
+		}
+		return d.ext(w, n)
+	case t == 0xca:
+		v, err := d.uint(4)
+		if err != nil {
+			return err
+		}
+		return writeFloat(w, float64(math.Float32frombits(uint32(v))), 32)
+	case t == 0xcb:
+		v, err := d.uint(8)
+		if err != nil {
+			return err
+		}
+		return writeFloat(w, math.Float64frombits(v), 64)
+	case t >= 0xcc && t <= 0xcf:
+		v, err := d.uint(1 << (t - 0xcc))
+		if err != nil {
+			return err
+		}
+		w.WriteString(strconv.FormatUint(v, 10))
+	case t >= 0xd0 && t <= 0xd3:
+		size := 1 << (t - 0xd0)
+		v, err := d.uint(size)
+		if err != nil {
+			return err
+		}
+		// Sign-extend from the encoded width.
+		shift := 64 - 8*size
+		w.WriteString(strconv.FormatInt(int64(v<<shift)>>shift, 10))
+	case t >= 0xd4 && t <= 0xd8:
+		return d.ext(w, 1<<(t-0xd4))
+	case t >= 0xd9 && t <= 0xdb:
+		n, err := d.length(1 << (t - 0xd9))
+		if err != nil {
+			return err
+		}
+		return d.str(w, n)
+	case t == 0xdc || t == 0xdd:
+		n, err := d.uint(2 << (t - 0xdc))
+		if err != nil {
+			return err
+		}
+		// Every element takes at least one byte.
+		if n > uint64(len(d.b)) {
+			return errMsgpackTruncated
+		}
+		return d.arrayBody(w, int(n), depth)
+	case t == 0xde || t == 0xdf:
+		n, err := d.uint(2 << (t - 0xde))
+		if err != nil {
+			return err
+		}
+		if n > uint64(len(d.b)) {
+			return errMsgpackTruncated
+		}
+		return d.mapBody(w, int(n), depth)
+	default:
+		return fmt.Errorf("msgpack: invalid type byte 0x%02x", t)
+	}
+	return nil
+}
+
+func (d *msgpackDecoder) str(w *bytes.Buffer, n int) error {
+	s, err := d.read(n)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, string(s))
+}
+
+func (d *msgpackDecoder) arrayBody(w *bytes.Buffer, n int, depth int) error {
+	w.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		if err := d.value(w, depth+1); err != nil {
+			return err
+		}
+	}
+	w.WriteByte(']')
+	return nil
+}
+
+// mapBody writes a map as a JSON object. Keys must be strings or
+// integers, which are quoted.
+func (d *msgpackDecoder) mapBody(w *bytes.Buffer, n int, depth int) error {
+	w.WriteByte('{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		var key bytes.Buffer
+		if err := d.value(&key, depth+1); err != nil {
+			return err
+		}
+		switch k := key.Bytes(); {
+		case len(k) > 0 && k[0] == '"':
+			w.Write(k)
+		case len(k) > 0 && (k[0] == '-' || k[0] >= '0' && k[0] <= '9') && bytes.IndexAny(k, ".eE") < 0:
+			writeJSON(w, string(k))
+		default:
+			return fmt.Errorf("msgpack: unsupported map key %s", k)
+		}
+		w.WriteByte(':')
+		if err := d.value(w, depth+1); err != nil {
+			return err
+		}
+	}
+	w.WriteByte('}')
+	return nil
+}
+
+// ext decodes an extension of n data bytes. Only the timestamp extension
+// is supported.
+func (d *msgpackDecoder) ext(w *bytes.Buffer, n int) error {
+	p, err := d.read(1)
+	if err != nil {
+		return err
+	}
+	if int8(p[0]) != -1 {
+		return fmt.Errorf("msgpack: unsupported extension type %d", int8(p[0]))
+	}
+	data, err := d.read(n)
+	if err != nil {
+		return err
+	}
+	var t time.Time
+	switch n {
+	case 4:
+		t = time.Unix(int64(binary.BigEndian.Uint32(data)), 0)
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		t = time.Unix(int64(v&(1<<34-1)), int64(v>>34))
+	case 12:
+		t = time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data)))
+	default:
+		return fmt.Errorf("msgpack: invalid timestamp length %d", n)
+	}
+	return writeJSON(w, t.UTC().Format(time.RFC3339Nano))
+}
+
+func writeJSON(w *bytes.Buffer, s string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	w.Write(data)
+	return nil
+}
+
+func writeFloat(w *bytes.Buffer, f float64, bits int) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("msgpack: unsupported float value %v", f)
+	}
+	w.WriteString(strconv.FormatFloat(f, 'g', -1, bits))
+	return nil
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	long := strings.Repeat("x", 300)
+	keys := make([]string, 20)
+	for i := range keys {
+		keys[i] = `"k` + strings.Repeat("y", i) + `":` + "-" + strings.Repeat("1", i%10+1)
+	}
+	docs := []string{
+		`{"a":1}`,
+		`null`,
+		`[true,false,null,0,127,128,-1,-32,-33,-129,70000,-70000,5000000000,-5000000000]`,
+		`{"float":1.5,"small":-0.25,"big":1e+300}`,
+		`{"text":"héllo \"quoted\"\n","long":"` + long + `","empty":""}`,
+		`{"nested":{"list":[{"id":"msg-1"},[],{}]}}`,
+		`{` + strings.Join(keys, ",") + `}`,
+	}
+	for _, doc := range docs {
+		packed, err := jsonToMsgpack([]byte(doc))
+		if err != nil {
+			t.Fatalf("jsonToMsgpack(%s): %v", doc, err)
+		}
+		got, err := msgpackToJSON(packed)
+		if err != nil {
+			t.Fatalf("msgpackToJSON(%s): %v", doc, err)
+		}
+		if string(got) != doc {
+			t.Errorf("round trip mismatch:\n got %s\nwant %s", got, doc)
+		}
+	}
+
+	packed, _ := jsonToMsgpack([]byte(`{"a":1}`))
+	if want := []byte{0x81, 0xa1, 'a', 0x01}; !bytes.Equal(packed, want) {
+		t.Errorf("expected % x, got % x", want, packed)
+	}
+}
+
+func TestMsgpackToJSONExtensions(t *testing.T) {
+	// {"bin": bin8 "hi", "at": timestamp32 1700000000, 1: uint16 300}
+	data := []byte{0x83,
+		0xa3, 'b', 'i', 'n', 0xc4, 0x02, 'h', 'i',
+		0xa2, 'a', 't', 0xd6, 0xff, 0x65, 0x53, 0xf1, 0x00,
+		0x01, 0xcd, 0x01, 0x2c,
+	}
+	got, err := msgpackToJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"bin":"aGk=","at":"2023-11-14T22:13:20Z","1":300}`
+	if string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	for _, bad := range [][]byte{
+		{0xa5, 'a'},
+		{0x92, 0x01},
+		{0xc1},
+		{0x81, 0xc0, 0x01},
+		{0xd4, 0x01, 0x00},
+		{0x01, 0x02},
+	} {
+		if _, err := msgpackToJSON(bad); err == nil {
+			t.Errorf("expected error decoding % x", bad)
+		}
+	}
+}
+
+// msgpackServer echoes message content, answering in MessagePack when
+// asked. It rejects MessagePack bodies with 415 if rejectMsgpack is set.
+type msgpackServer struct {
+	rejectMsgpack bool
+
+	mu           sync.Mutex
+	contentTypes []string
+}
+
+func (s *msgpackServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.contentTypes = append(s.contentTypes, r.Header.Get("Content-Type"))
+	s.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if isMsgpack(r.Header.Get("Content-Type")) {
+		if s.rejectMsgpack {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		var err error
+		if body, err = msgpackToJSON(body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	var req models.MessageCreate
+	json.Unmarshal(body, &req)
+
+	resp, _ := json.Marshal(models.Message{ID: "msg-1", Content: req.Content})
+	if strings.HasPrefix(r.Header.Get("Accept"), ContentTypeMsgpack) {
+		resp, _ = jsonToMsgpack(resp)
+		w.Header().Set("Content-Type", ContentTypeMsgpack)
+	} else {
+		w.Header().Set("Content-Type", ContentTypeJSON)
+	}
+	w.Write(resp)
+}
+
+func (s *msgpackServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.contentTypes...)
+}
+
+func TestWireFormatMsgpack(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *msgpackServer
+		format WireFormat
+		want   []string
+	}{
+		{
+			name:   "negotiated after first response",
+			server: &msgpackServer{},
+			format: FormatMsgpack,
+			want:   []string{ContentTypeJSON, ContentTypeMsgpack},
+		},
+		{
+			name:   "falls back when rejected",
+			server: &msgpackServer{rejectMsgpack: true},
+			format: FormatMsgpack,
+			want:   []string{ContentTypeJSON, ContentTypeMsgpack, ContentTypeJSON},
+		},
+		{
+			name:   "json by default",
+			server: &msgpackServer{},
+			want:   []string{ContentTypeJSON, ContentTypeJSON},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.server)
+			defer server.Close()
+
+			config := DefaultConfig()
+			config.BaseURL = server.URL
+			config.WireFormat = tt.format
+			client := New(config)
+
+			for _, content := range []string{"first", "second"} {
+				msg, err := client.SendMessage(context.Background(), "conv-1", content)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if msg.Content != content {
+					t.Errorf("expected content %q, got %q", content, msg.Content)
+				}
+			}
+
+			got := tt.server.requests()
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("expected request content types %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !plan9
+
+package client
+
+import (
+	"errors"
+	"syscall"
+)
+
+// connectionReset reports whether err is the peer resetting an
+// established connection.
+func connectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET)
+}
+
+// connectionFailed reports whether err is a connection the system could
+// not make or keep: refused, reset, or with no route to the server.
+func connectionFailed(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ENETUNREACH) ||
+		errors.Is(err, syscall.EHOSTUNREACH)
+}
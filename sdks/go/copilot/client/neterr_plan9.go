@@ -0,0 +1,9 @@
+package client
+
+// Plan 9 reports network failures as strings rather than errno values.
+// They arrive wrapped in a *net.OpError, which the callers already treat
+// as a network failure.
+
+func connectionReset(err error) bool { return false }
+
+func connectionFailed(err error) bool { return false }
@@ -0,0 +1,35 @@
+//go:build !plan9
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestErrnoNetworkErrors(t *testing.T) {
+	wrap := func(err error) error { return &url.Error{Op: "Get", URL: "https://api.example.com", Err: err} }
+	tests := []struct {
+		name      string
+		err       error
+		offline   bool
+		transient bool
+	}{
+		{"refused", wrap(os.NewSyscallError("connect", syscall.ECONNREFUSED)), true, false},
+		{"reset", wrap(syscall.ECONNRESET), true, true},
+		{"network unreachable", wrap(syscall.ENETUNREACH), true, false},
+		{"host unreachable", wrap(syscall.EHOSTUNREACH), true, false},
+		{"permission", wrap(syscall.EACCES), false, false},
+	}
+	for _, tt := range tests {
+		if got := isOffline(context.Background(), tt.err); got != tt.offline {
+			t.Errorf("%s: isOffline = %v, want %v", tt.name, got, tt.offline)
+		}
+		if got := transientNetworkError(tt.err); got != tt.transient {
+			t.Errorf("%s: transientNetworkError = %v, want %v", tt.name, got, tt.transient)
+		}
+	}
+}
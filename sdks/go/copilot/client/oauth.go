@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// OAuthConfig returns an OAuth2 configuration for the CoPilot server's own
+// authorization endpoints.
+func (c *Client) OAuthConfig(clientID string, scopes ...string) *auth.OAuthConfig {
+	return &auth.OAuthConfig{
+		AuthURL:    c.config.BaseURL + "/oauth/authorize",
+		TokenURL:   c.config.BaseURL + "/oauth/token",
+		ClientID:   clientID,
+		Scopes:     scopes,
+		HTTPClient: c.httpClient,
+	}
+}
+
+// LoginWithBrowser signs in with the OAuth2 authorization-code flow with
+// PKCE and stores the resulting tokens. If open is nil, the system browser
+// is launched.
+func (c *Client) LoginWithBrowser(ctx context.Context, config *auth.OAuthConfig, open func(authURL string) error) (*auth.Token, error) {
+	token, err := config.LoginWithBrowser(ctx, open)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.storeToken(token); err != nil {
+		return nil, fmt.Errorf("failed to store token: %w", err)
+	}
+	return token, nil
+}
+
+// ExchangeIDToken trades an OpenID Connect ID token issued by an external
+// identity provider, such as Okta, Entra ID or Google, for a CoPilot
+// access token, and stores the resulting tokens.
+func (c *Client) ExchangeIDToken(ctx context.Context, idToken string) (*models.LoginResponse, error) {
+	if idToken == "" {
+		return nil, errors.New("empty ID token")
+	}
+	req := models.TokenExchangeRequest{
+		GrantType:        models.TokenExchangeGrantType,
+		SubjectToken:     idToken,
+		SubjectTokenType: models.TokenTypeIDToken,
+	}
+
+	var resp models.LoginResponse
+	if err := c.post(withoutTokenRefresh(ctx), "/api/v1/auth/token-exchange", req, &resp); err != nil {
+		return nil, err
+	}
+	return c.storeLogin(&resp)
+}
+
+// LoginWithOIDC signs in through an external OpenID Connect provider: it
+// discovers the provider at issuer, runs the authorization-code flow with
+// PKCE in the browser, and exchanges the resulting ID token for CoPilot
+// tokens. If open is nil, the system browser is launched.
+func (c *Client) LoginWithOIDC(ctx context.Context, issuer, clientID string, open func(authURL string) error) (*models.LoginResponse, error) {
+	provider, err := auth.DiscoverOIDC(ctx, c.httpClient, issuer)
+	if err != nil {
+		return nil, err
+	}
+	config := provider.OAuthConfig(clientID, "email", "profile")
+	config.HTTPClient = c.httpClient
+
+	token, err := config.LoginWithBrowser(ctx, open)
+	if err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%s did not issue an ID token", issuer)
+	}
+	return c.ExchangeIDToken(ctx, token.IDToken)
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestLoginWithOIDC(t *testing.T) {
+	var idp *httptest.Server
+	idp = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{
+				"issuer":                 idp.URL,
+				"authorization_endpoint": idp.URL + "/authorize",
+				"token_endpoint":         idp.URL + "/token",
+			})
+		case "/token":
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "idp-access", "id_token": "id-token"})
+		default:
+			t.Errorf("unexpected IdP request %s", r.URL.Path)
+		}
+	}))
+	defer idp.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/auth/token-exchange" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		var req models.TokenExchangeRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.GrantType != models.TokenExchangeGrantType || req.SubjectToken != "id-token" || req.SubjectTokenType != models.TokenTypeIDToken {
+			t.Errorf("unexpected exchange request %+v", req)
+		}
+		json.NewEncoder(w).Encode(models.LoginResponse{AccessToken: "copilot-access", ExpiresIn: 3600, User: models.User{ID: "user-1"}})
+	}))
+	defer server.Close()
+
+	open := func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		q := u.Query()
+		if q.Get("scope") != "openid email profile" {
+			t.Errorf("unexpected scopes %q", q.Get("scope"))
+		}
+		go http.Get(q.Get("redirect_uri") + "?code=code&state=" + url.QueryEscape(q.Get("state")))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := New(&Config{BaseURL: server.URL})
+	resp, err := client.LoginWithOIDC(ctx, idp.URL, "app", open)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.User.ID != "user-1" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if token, _ := client.token(); token == nil || token.AccessToken != "copilot-access" {
+		t.Errorf("expected exchanged token to be stored, got %+v", token)
+	}
+}
@@ -0,0 +1,325 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// ErrQueued is returned when a mutating call could not reach the server and
+// was queued for replay. The call's result is delivered to
+// Config.OnReplay once the request is replayed.
+var ErrQueued = errors.New("request queued while offline")
+
+// QueuedRequest is a mutating request waiting to be replayed.
+type QueuedRequest struct {
+	ID             string          `json:"id"`
+	Method         string          `json:"method"`
+	Path           string          `json:"path"`
+	Body           json.RawMessage `json:"body,omitempty"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	CorrelationID  string          `json:"correlation_id,omitempty"`
+	TenantID       string          `json:"tenant_id,omitempty"`
+	// Scopes is the scope restriction the request was made with. Nil
+	// means unrestricted; an empty list restricts to no scopes.
+	Scopes   []models.ApiKeyScope `json:"scopes"`
+	QueuedAt time.Time            `json:"queued_at"`
+}
+
+// ReplayResult reports the outcome of replaying a queued request.
+type ReplayResult struct {
+	Request *QueuedRequest
+	// Response is the raw response body on success.
+	Response json.RawMessage
+	// Err is the API error the server answered with, if any.
+	Err error
+}
+
+// RequestQueue persists queued requests. Implementations must be safe for
+// concurrent use.
+type RequestQueue interface {
+	// Enqueue adds a request to the end of the queue.
+	Enqueue(req *QueuedRequest) error
+	// Pending returns the queued requests, oldest first.
+	Pending() ([]*QueuedRequest, error)
+	// Remove deletes a request from the queue.
+	Remove(id string) error
+}
+
+// FileQueue is a RequestQueue storing one file per request in a directory,
+// so queued requests survive process restarts.
+type FileQueue struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileQueue creates a FileQueue in dir, which is created if needed.
+func NewFileQueue(dir string) (*FileQueue, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create queue directory: %w", err)
+	}
+	return &FileQueue{dir: dir}, nil
+}
+
+// Enqueue writes the request to the queue directory.
+func (q *FileQueue) Enqueue(req *QueuedRequest) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode queued request: %w", err)
+	}
+
+	// The timestamp prefix keeps directory order equal to queue order.
+	name := fmt.Sprintf("%020d-%s.json", req.QueuedAt.UnixNano(), req.ID)
+	tmp, err := os.CreateTemp(q.dir, ".queue-*")
+	if err != nil {
+		return fmt.Errorf("failed to write queued request: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write queued request: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write queued request: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(q.dir, name)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write queued request: %w", err)
+	}
+	return nil
+}
+
+// Pending reads the queued requests, oldest first.
+func (q *FileQueue) Pending() ([]*QueuedRequest, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names, err := q.files()
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]*QueuedRequest, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(q.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read queued request: %w", err)
+		}
+		var req QueuedRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			return nil, fmt.Errorf("malformed queued request %s: %w", name, err)
+		}
+		reqs = append(reqs, &req)
+	}
+	return reqs, nil
+}
+
+// Remove deletes the request with the given ID.
+func (q *FileQueue) Remove(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names, err := q.files()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "-"+id+".json") {
+			if err := os.Remove(filepath.Join(q.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to remove queued request: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// files returns the queue file names in queue order.
+func (q *FileQueue) files() ([]string, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue directory: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, ".json") && !strings.HasPrefix(name, ".") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// queueable reports whether a failed request may be queued for replay.
+// Authentication calls, workflow secrets and requests whose body carries a
+// credential, such as a webhook signing secret, are never queued, so that
+// no credential is written to the queue.
+func (c *Client) queueable(method, path string, body interface{}) bool {
+	return c.config.OfflineQueue != nil &&
+		method != http.MethodGet &&
+		!strings.HasPrefix(path, "/api/v1/auth/") &&
+		!isSecretsPath(path) &&
+		!carriesCredential(body)
+}
+
+// carriesCredential reports whether body has a field that debug
+// transcripts mask, such as "secret" or "password". Bodies that cannot be
+// encoded are treated as carrying one.
+func carriesCredential(body interface{}) bool {
+	if body == nil {
+		return false
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return true
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return true
+	}
+	return redactJSON(v)
+}
+
+// isOffline reports whether err means the server could not be reached, as
+// opposed to the server rejecting the request or the caller giving up.
+// Only network failures count: a failed dial or DNS lookup, or a refused
+// or reset connection. Certificate errors and malformed URLs would fail
+// the same way on replay, so they are returned to the caller.
+func isOffline(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || certificateError(err) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	return errors.As(err, &dnsErr) ||
+		errors.As(err, &opErr) ||
+		connectionFailed(err)
+}
+
+// certificateError reports whether err is a failure to establish TLS with
+// the server, such as an untrusted or mismatched certificate.
+func certificateError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &hostnameErr)
+}
+
+// enqueue stores a request that failed because the client is offline.
+func (c *Client) enqueue(ctx context.Context, method, path string, body interface{}, cause error) error {
+	queued := &QueuedRequest{
+		ID:       NewIdempotencyKey(),
+		Method:   method,
+		Path:     path,
+		TenantID: c.config.TenantID,
+		QueuedAt: time.Now().UTC(),
+	}
+	queued.IdempotencyKey, _ = IdempotencyKeyFromContext(ctx)
+	queued.CorrelationID, _ = CorrelationIDFromContext(ctx)
+	if scopes, ok := ScopesFromContext(ctx); ok {
+		queued.Scopes = append([]models.ApiKeyScope{}, scopes...)
+	}
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		queued.Body = data
+	}
+
+	if err := c.config.OfflineQueue.Enqueue(queued); err != nil {
+		return fmt.Errorf("failed to queue request: %v (original error: %w)", err, cause)
+	}
+	return fmt.Errorf("%w: %v", ErrQueued, cause)
+}
+
+// ReplayQueue sends queued requests in order, with their original
+// idempotency keys, and reports each outcome to Config.OnReplay. Requests
+// the server answers, successfully or with an error, leave the queue.
+// Replay stops at the first request that still cannot be delivered; the
+// number of requests replayed is returned.
+func (c *Client) ReplayQueue(ctx context.Context) (int, error) {
+	if c.config.OfflineQueue == nil {
+		return 0, nil
+	}
+
+	pending, err := c.config.OfflineQueue.Pending()
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, queued := range pending {
+		reqCtx := WithIdempotencyKey(ctx, queued.IdempotencyKey)
+		if queued.CorrelationID != "" {
+			reqCtx = WithCorrelationID(reqCtx, queued.CorrelationID)
+		}
+		if queued.Scopes != nil {
+			reqCtx = WithScopes(reqCtx, queued.Scopes...)
+		}
+
+		var body interface{}
+		if len(queued.Body) > 0 {
+			body = queued.Body
+		}
+		// Requests queued by a tenant-scoped copy replay for that tenant.
+		sender := c
+		if queued.TenantID != c.config.TenantID {
+			sender = c.ForTenant(queued.TenantID)
+		}
+		var response json.RawMessage
+		err := sender.send(reqCtx, queued.Method, queued.Path, body, &response)
+		var apiErr *CoPilotError
+		if err != nil && (!errors.As(err, &apiErr) || c.isRetryable(apiErr, nil)) {
+			return replayed, err
+		}
+
+		if err := c.config.OfflineQueue.Remove(queued.ID); err != nil {
+			return replayed, err
+		}
+		replayed++
+		if c.config.OnReplay != nil {
+			c.config.OnReplay(ReplayResult{Request: queued, Response: response, Err: err})
+		}
+	}
+	return replayed, nil
+}
+
+// StartQueueReplayer replays the offline queue every interval until ctx is
+// cancelled.
+func (c *Client) StartQueueReplayer(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.ReplayQueue(ctx)
+			}
+		}
+	}()
+}
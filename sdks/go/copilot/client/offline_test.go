@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// flakyTransport fails every request while offline is set.
+type flakyTransport struct {
+	offline atomic.Bool
+}
+
+func (f *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if f.offline.Load() {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("network is unreachable")}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestOfflineQueue(t *testing.T) {
+	keys := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys[r.Header.Get(IdempotencyKeyHeader)]++
+		if r.URL.Path == "/api/v1/conversations/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.APIError{Code: "not_found", Message: "gone"})
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(models.Conversation{ID: "conv-1"})
+	}))
+	defer server.Close()
+
+	queue, err := NewFileQueue(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &flakyTransport{}
+	transport.offline.Store(true)
+
+	var results []ReplayResult
+	client := New(&Config{
+		BaseURL:      server.URL,
+		APIKey:       "key",
+		HTTPClient:   &http.Client{Transport: transport},
+		OfflineQueue: queue,
+		OnReplay:     func(r ReplayResult) { results = append(results, r) },
+	})
+	ctx := WithCorrelationID(context.Background(), "corr-1")
+
+	if _, err := client.CreateConversation(ctx, nil); !errors.Is(err, ErrQueued) {
+		t.Fatalf("expected ErrQueued, got %v", err)
+	}
+	if err := client.DeleteConversation(ctx, "missing"); !errors.Is(err, ErrQueued) {
+		t.Fatalf("expected ErrQueued, got %v", err)
+	}
+	if _, err := client.GetConversation(ctx, "conv-1"); err == nil || errors.Is(err, ErrQueued) {
+		t.Errorf("expected reads to fail without queueing, got %v", err)
+	}
+
+	if n, err := client.ReplayQueue(ctx); err == nil || n != 0 {
+		t.Errorf("expected replay to stop while offline, got %d, %v", n, err)
+	}
+
+	transport.offline.Store(false)
+	n, err := client.ReplayQueue(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 replayed requests, got %d, %v", n, err)
+	}
+	if pending, _ := queue.Pending(); len(pending) != 0 {
+		t.Errorf("expected empty queue, got %d", len(pending))
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 replay results, got %d", len(results))
+	}
+	var conv models.Conversation
+	if err := json.Unmarshal(results[0].Response, &conv); err != nil || conv.ID != "conv-1" {
+		t.Errorf("expected created conversation, got %s, %v", results[0].Response, err)
+	}
+	if results[0].Request.CorrelationID != "corr-1" {
+		t.Errorf("expected correlation ID to be kept, got %q", results[0].Request.CorrelationID)
+	}
+	var apiErr *CoPilotError
+	if !errors.As(results[1].Err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected not found error for second replay, got %v", results[1].Err)
+	}
+
+	for key, count := range keys {
+		if key == "" || count != 1 {
+			t.Errorf("expected each replay to carry a unique idempotency key, got %q x%d", key, count)
+		}
+	}
+}
+
+func TestOfflineQueueSkipsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	queue, err := NewFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &flakyTransport{}
+	transport.offline.Store(true)
+	client := New(&Config{
+		BaseURL:      "http://copilot.invalid",
+		APIKey:       "key",
+		MaxRetries:   -1,
+		HTTPClient:   &http.Client{Transport: transport},
+		OfflineQueue: queue,
+	})
+
+	_, err = client.SetWorkflowSecret(context.Background(), "wf-1", "DEPLOY_TOKEN", "tok-123")
+	if err == nil || errors.Is(err, ErrQueued) {
+		t.Fatalf("expected the secret to fail without queueing, got %v", err)
+	}
+	if pending, _ := queue.Pending(); len(pending) != 0 {
+		t.Errorf("expected empty queue, got %d", len(pending))
+	}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if data, _ := os.ReadFile(path); err == nil && !d.IsDir() && strings.Contains(string(data), "tok-123") {
+			t.Errorf("expected the secret not to be persisted, found it in %s", path)
+		}
+		return nil
+	})
+}
+
+func TestOfflineQueueSkipsWebhookSecrets(t *testing.T) {
+	dir := t.TempDir()
+	queue, err := NewFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &flakyTransport{}
+	transport.offline.Store(true)
+	client := New(&Config{
+		BaseURL:      "http://copilot.invalid",
+		APIKey:       "key",
+		MaxRetries:   -1,
+		HTTPClient:   &http.Client{Transport: transport},
+		OfflineQueue: queue,
+	})
+	ctx := context.Background()
+
+	_, err = client.CreateWebhook(ctx, models.WebhookCreate{URL: "https://example.com/hooks", Secret: "whsec-123"})
+	if err == nil || errors.Is(err, ErrQueued) {
+		t.Fatalf("expected the webhook to fail without queueing, got %v", err)
+	}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if data, _ := os.ReadFile(path); err == nil && !d.IsDir() && strings.Contains(string(data), "whsec-123") {
+			t.Errorf("expected the signing secret not to be persisted, found it in %s", path)
+		}
+		return nil
+	})
+
+	// Writes without a credential are still queued.
+	active := false
+	if _, err := client.UpdateWebhook(ctx, "wh-1", models.WebhookUpdate{Active: &active}); !errors.Is(err, ErrQueued) {
+		t.Errorf("expected the update to be queued, got %v", err)
+	}
+	if pending, _ := queue.Pending(); len(pending) != 1 {
+		t.Errorf("expected 1 queued request, got %d", len(pending))
+	}
+}
+
+func TestIsOffline(t *testing.T) {
+	wrap := func(err error) error { return &url.Error{Op: "Post", URL: "https://api.example.com", Err: err} }
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"dial", wrap(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}), true},
+		{"dns", wrap(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "api.example.com"}}), true},
+		{"certificate", wrap(&tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}), false},
+		{"hostname", wrap(x509.HostnameError{Host: "api.example.com"}), false},
+		{"malformed url", wrap(errors.New("unsupported protocol scheme \"\"")), false},
+		{"api error", &CoPilotError{StatusCode: http.StatusServiceUnavailable}, false},
+	}
+	for _, tt := range tests {
+		if got := isOffline(context.Background(), tt.err); got != tt.want {
+			t.Errorf("%s: isOffline = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if isOffline(ctx, wrap(&net.OpError{Op: "dial", Err: errors.New("connection refused")})) {
+		t.Error("expected a cancelled request not to count as offline")
+	}
+}
+
+func TestOfflineQueueSkipsCertificateErrors(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the handshake to fail")
+	}))
+	defer server.Close()
+
+	queue, err := NewFileQueue(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := New(&Config{BaseURL: server.URL, APIKey: "key", MaxRetries: 1, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond, OfflineQueue: queue})
+	_, err = client.CreateConversation(context.Background(), &models.ConversationCreate{Title: "untrusted"})
+	if err == nil || errors.Is(err, ErrQueued) {
+		t.Fatalf("expected the certificate error, got %v", err)
+	}
+	if pending, _ := queue.Pending(); len(pending) != 0 {
+		t.Errorf("expected nothing queued, got %d requests", len(pending))
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// PinMessage pins a message in a conversation so it is surfaced to
+// participants. With includeInContext the message is always part of the
+// model's context, e.g. for standing instructions.
+func (c *Client) PinMessage(ctx context.Context, conversationID models.ConversationID, messageID models.MessageID, includeInContext bool) (*models.PinnedMessage, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+	if err := messageID.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := models.PinCreate{MessageID: messageID, IncludeInContext: includeInContext}
+
+	var pin models.PinnedMessage
+	path := fmt.Sprintf("/api/v1/conversations/%s/pins", conversationID)
+	if err := c.post(ctx, path, req, &pin); err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// UnpinMessage unpins a message. The message itself is kept.
+func (c *Client) UnpinMessage(ctx context.Context, conversationID models.ConversationID, messageID models.MessageID) error {
+	if err := conversationID.Validate(); err != nil {
+		return err
+	}
+	if err := messageID.Validate(); err != nil {
+		return err
+	}
+	return c.delete(ctx, fmt.Sprintf("/api/v1/conversations/%s/pins/%s", conversationID, messageID))
+}
+
+// ListPinnedMessages returns the messages pinned in a conversation, most
+// recently pinned first.
+func (c *Client) ListPinnedMessages(ctx context.Context, conversationID models.ConversationID) ([]models.PinnedMessage, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/api/v1/conversations/%s/pins", conversationID)
+	fetch := func(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.PinnedMessage], error) {
+		return List[models.PinnedMessage](ctx, c, path, opts)
+	}
+	return NewPager(fetch, nil).All(ctx)
+}
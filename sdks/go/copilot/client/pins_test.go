@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestPinLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/conversations/conv-1/pins":
+			var req models.PinCreate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.MessageID != "msg-1" || !req.IncludeInContext {
+				t.Errorf("unexpected pin request %+v", req)
+			}
+			json.NewEncoder(w).Encode(models.PinnedMessage{Message: models.Message{ID: req.MessageID}, IncludeInContext: true})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/conversations/conv-1/pins":
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.PinnedMessage]{
+				Items: []models.PinnedMessage{{Message: models.Message{ID: "msg-1"}, IncludeInContext: true}},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/conversations/conv-1/pins/msg-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	pin, err := client.PinMessage(ctx, "conv-1", "msg-1", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pin.Message.ID != "msg-1" || !pin.IncludeInContext {
+		t.Errorf("unexpected pin %+v", pin)
+	}
+
+	pins, err := client.ListPinnedMessages(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pins) != 1 || pins[0].Message.ID != "msg-1" {
+		t.Errorf("unexpected pins %+v", pins)
+	}
+
+	if err := client.UnpinMessage(ctx, "conv-1", "msg-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.PinMessage(ctx, "conv-1", "", false); err == nil {
+		t.Error("expected an error for an empty message ID")
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// ErrURLExpired is returned when a presigned URL is used after it expired.
+var ErrURLExpired = errors.New("presigned URL has expired")
+
+// GetUploadURL returns a presigned URL for uploading the content of a new
+// context item directly to object storage. After the upload, the item is
+// created by CompleteUpload; UploadContextItem does both.
+func (c *Client) GetUploadURL(ctx context.Context, req *models.UploadURLRequest) (*models.PresignedUpload, error) {
+	var upload models.PresignedUpload
+	if err := c.post(ctx, "/api/v1/context/uploads", req, &upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// CompleteUpload creates the context item of a finished upload.
+func (c *Client) CompleteUpload(ctx context.Context, uploadID string) (*models.ContextItem, error) {
+	var item models.ContextItem
+	if err := c.post(ctx, "/api/v1/context/uploads/"+uploadID+"/complete", nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// AbortUpload discards an upload that will not be completed.
+func (c *Client) AbortUpload(ctx context.Context, uploadID string) error {
+	return c.delete(ctx, "/api/v1/context/uploads/"+uploadID)
+}
+
+// GetDownloadURL returns a presigned URL for downloading a context item's
+// content directly from object storage, e.g. to hand to a browser.
+func (c *Client) GetDownloadURL(ctx context.Context, id string) (*models.PresignedURL, error) {
+	var u models.PresignedURL
+	if err := c.get(ctx, "/api/v1/context/"+id+"/download-url", &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// UploadContextItem creates a context item from the req.Size bytes of r,
+// which move directly to object storage rather than through the API. The
+// upload is aborted if it fails. Progress is reported to the ProgressFunc
+// set with WithUploadProgress.
+func (c *Client) UploadContextItem(ctx context.Context, req *models.UploadURLRequest, r io.Reader) (*models.ContextItem, error) {
+	if req.Size < 0 {
+		return nil, fmt.Errorf("invalid size %d", req.Size)
+	}
+	upload, err := c.GetUploadURL(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.putPresigned(ctx, &upload.PresignedURL, r, req.Size); err != nil {
+		// The upload is released even when ctx is what failed.
+		c.AbortUpload(context.WithoutCancel(ctx), upload.UploadID)
+		return nil, err
+	}
+	return c.CompleteUpload(ctx, upload.UploadID)
+}
+
+// putPresigned sends size bytes of r to a presigned upload URL. The URL's
+// signature is its credential, so no API headers are sent.
+func (c *Client) putPresigned(ctx context.Context, u *models.PresignedURL, r io.Reader, size int64) error {
+	if u.Expired() {
+		return ErrURLExpired
+	}
+	method := u.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+	ctx, cancel, httpClient := c.withOperationTimeout(ctx, method)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.URL, io.LimitReader(r, size))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	for key, value := range u.Headers {
+		req.Header.Set(key, value)
+	}
+	reportUpload(req)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		// Storage errors are not API errors; show the start of the body.
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("upload failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// storageServer emulates the API and object storage for uploads. Storage
+// requests fail with status storageStatus when it is set.
+type storageServer struct {
+	*httptest.Server
+	mu            sync.Mutex
+	calls         []string
+	stored        string
+	storageHeader http.Header
+	storageStatus int
+}
+
+func newStorageServer(t *testing.T) *storageServer {
+	s := &storageServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.calls = append(s.calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/context/uploads":
+			var req models.UploadURLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(models.PresignedUpload{
+				UploadID: "up-1",
+				PresignedURL: models.PresignedURL{
+					URL:       s.URL + "/bucket/up-1?signature=abc",
+					Method:    http.MethodPut,
+					Headers:   map[string]string{"Content-Type": req.ContentType},
+					ExpiresAt: models.NewTimestamp(time.Now().Add(time.Minute)),
+				},
+			})
+		case "/bucket/up-1":
+			s.storageHeader = r.Header.Clone()
+			if s.storageStatus != 0 {
+				w.WriteHeader(s.storageStatus)
+				io.WriteString(w, "<Error>AccessDenied</Error>")
+				return
+			}
+			data, _ := io.ReadAll(r.Body)
+			s.stored = string(data)
+		case "/api/v1/context/uploads/up-1/complete":
+			json.NewEncoder(w).Encode(models.ContextItem{ID: "ctx-1", Content: s.stored})
+		case "/api/v1/context/uploads/up-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestUploadContextItem(t *testing.T) {
+	server := newStorageServer(t)
+	client := New(&Config{BaseURL: server.URL, APIKey: "secret-key"})
+
+	content := strings.Repeat("x", 10000)
+	var done, total int64
+	ctx := WithUploadProgress(context.Background(), func(bytesDone, bytesTotal int64) {
+		done, total = bytesDone, bytesTotal
+	})
+	item, err := client.UploadContextItem(ctx, &models.UploadURLRequest{
+		Type:        models.ContextTypeFile,
+		Name:        "big.txt",
+		Size:        int64(len(content)),
+		ContentType: "text/plain",
+	}, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.ID != "ctx-1" || item.Content != content {
+		t.Errorf("unexpected item %s with %d bytes", item.ID, len(item.Content))
+	}
+
+	want := []string{"POST /api/v1/context/uploads", "PUT /bucket/up-1", "POST /api/v1/context/uploads/up-1/complete"}
+	if strings.Join(server.calls, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected calls %v, got %v", want, server.calls)
+	}
+	if server.storageHeader.Get("X-API-Key") != "" || server.storageHeader.Get(RequestIDHeader) != "" {
+		t.Errorf("expected no API headers at storage, got %v", server.storageHeader)
+	}
+	if server.storageHeader.Get("Content-Type") != "text/plain" {
+		t.Errorf("expected the signed Content-Type, got %q", server.storageHeader.Get("Content-Type"))
+	}
+	if done != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("unexpected progress: %d of %d", done, total)
+	}
+}
+
+func TestUploadContextItemAborts(t *testing.T) {
+	server := newStorageServer(t)
+	server.storageStatus = http.StatusForbidden
+	client := New(&Config{BaseURL: server.URL})
+
+	_, err := client.UploadContextItem(context.Background(), &models.UploadURLRequest{Name: "a.txt", Size: 3}, strings.NewReader("abc"))
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("expected the storage error, got %v", err)
+	}
+	if last := server.calls[len(server.calls)-1]; last != "DELETE /api/v1/context/uploads/up-1" {
+		t.Errorf("expected the upload to be aborted, got calls %v", server.calls)
+	}
+}
+
+func TestPutPresignedExpired(t *testing.T) {
+	client := New(&Config{BaseURL: "http://example.invalid"})
+	u := &models.PresignedURL{URL: "http://example.invalid/bucket", ExpiresAt: models.NewTimestamp(time.Now().Add(-time.Second))}
+	if err := client.putPresigned(context.Background(), u, strings.NewReader(""), 0); !errors.Is(err, ErrURLExpired) {
+		t.Errorf("expected ErrURLExpired, got %v", err)
+	}
+}
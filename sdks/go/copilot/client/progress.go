@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// ProgressFunc reports the progress of a transfer: bytesDone of bytesTotal
+// bytes, where bytesTotal is -1 when unknown. It is called from the
+// goroutine making the transfer, after every read, and starts over from
+// zero when a request is retried.
+type ProgressFunc func(bytesDone, bytesTotal int64)
+
+type uploadProgressKey struct{}
+
+type downloadProgressKey struct{}
+
+// WithUploadProgress returns a context whose requests report the upload
+// of their bodies to fn, e.g. to render a progress bar while a large
+// context item is created.
+func WithUploadProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, uploadProgressKey{}, fn)
+}
+
+// WithDownloadProgress returns a context whose downloads, such as feedback
+// exports, report the transfer of their content to fn.
+func WithDownloadProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, downloadProgressKey{}, fn)
+}
+
+// progressReader reports the bytes read through it.
+type progressReader struct {
+	io.ReadCloser
+	done  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	if n > 0 {
+		p.done += int64(n)
+		p.fn(p.done, p.total)
+	}
+	return n, err
+}
+
+// reportUpload reports the upload of req's body to the ProgressFunc in its
+// context, if any.
+func reportUpload(req *http.Request) {
+	fn, ok := req.Context().Value(uploadProgressKey{}).(ProgressFunc)
+	if !ok || fn == nil || req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+	req.Body = &progressReader{ReadCloser: req.Body, total: req.ContentLength, fn: fn}
+}
+
+// reportDownload reports the transfer of resp's body, as sent, to the
+// ProgressFunc in ctx, if any.
+func reportDownload(ctx context.Context, resp *http.Response) {
+	reportDownloadFrom(ctx, resp, 0)
+}
+
+// reportDownloadFrom is reportDownload for a body resuming a transfer
+// after offset bytes.
+func reportDownloadFrom(ctx context.Context, resp *http.Response, offset int64) {
+	fn, ok := ctx.Value(downloadProgressKey{}).(ProgressFunc)
+	if !ok || fn == nil {
+		return
+	}
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	resp.Body = &progressReader{ReadCloser: resp.Body, done: offset, total: total, fn: fn}
+}
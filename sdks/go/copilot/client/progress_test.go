@@ -0,0 +1,62 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestUploadProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"id":"ctx-1"}`))
+	}))
+	defer server.Close()
+	client := New(&Config{BaseURL: server.URL})
+
+	var done, total int64
+	calls := 0
+	ctx := WithUploadProgress(context.Background(), func(bytesDone, bytesTotal int64) {
+		if bytesDone < done {
+			t.Errorf("progress went backwards: %d after %d", bytesDone, done)
+		}
+		done, total = bytesDone, bytesTotal
+		calls++
+	})
+	content := strings.Repeat("x", 256<<10)
+	if _, err := client.CreateContextItem(ctx, &models.ContextItemCreate{Type: models.ContextTypeFile, Name: "big.txt", Content: content}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls < 2 || done != total || total <= int64(len(content)) {
+		t.Errorf("unexpected progress: %d calls, %d of %d bytes", calls, done, total)
+	}
+}
+
+func TestDownloadProgress(t *testing.T) {
+	body := bytes.Repeat([]byte("row\n"), 64<<10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write(body)
+	}))
+	defer server.Close()
+	client := NewWithAPIKey(server.URL, "test-key")
+
+	var done, total int64
+	ctx := WithDownloadProgress(context.Background(), func(bytesDone, bytesTotal int64) {
+		done, total = bytesDone, bytesTotal
+	})
+	var out bytes.Buffer
+	if err := client.download(ctx, "/export", &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done != int64(len(body)) || total != int64(len(body)) || out.Len() != len(body) {
+		t.Errorf("unexpected progress: %d of %d bytes, wrote %d", done, total, out.Len())
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// CreatePromptTemplate creates a prompt template at version 1.
+func (c *Client) CreatePromptTemplate(ctx context.Context, req models.PromptTemplateCreate) (*models.PromptTemplate, error) {
+	var tmpl models.PromptTemplate
+	if err := c.post(ctx, "/api/v1/prompts", req, &tmpl); err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// GetPromptTemplate retrieves a version of a prompt template, or the
+// latest version if version is zero.
+func (c *Client) GetPromptTemplate(ctx context.Context, id string, version int) (*models.PromptTemplate, error) {
+	path := "/api/v1/prompts/" + id
+	if version > 0 {
+		path = fmt.Sprintf("%s/versions/%d", path, version)
+	}
+
+	var tmpl models.PromptTemplate
+	if err := c.get(ctx, path, &tmpl); err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// ListPromptTemplates returns a page of prompt templates at their latest
+// versions.
+func (c *Client) ListPromptTemplates(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.PromptTemplate], error) {
+	return List[models.PromptTemplate](ctx, c, "/api/v1/prompts", opts)
+}
+
+// ListPromptTemplateVersions returns every version of a prompt template,
+// newest first.
+func (c *Client) ListPromptTemplateVersions(ctx context.Context, id string) ([]models.PromptTemplate, error) {
+	path := "/api/v1/prompts/" + id + "/versions"
+	fetch := func(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.PromptTemplate], error) {
+		return List[models.PromptTemplate](ctx, c, path, opts)
+	}
+	return NewPager(fetch, nil).All(ctx)
+}
+
+// UpdatePromptTemplate saves a new version of a prompt template and
+// returns it.
+func (c *Client) UpdatePromptTemplate(ctx context.Context, id string, req models.PromptTemplateUpdate) (*models.PromptTemplate, error) {
+	var tmpl models.PromptTemplate
+	if err := c.patch(ctx, "/api/v1/prompts/"+id, req, &tmpl); err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// DeletePromptTemplate deletes a prompt template and all its versions.
+func (c *Client) DeletePromptTemplate(ctx context.Context, id string) error {
+	return c.delete(ctx, "/api/v1/prompts/"+id)
+}
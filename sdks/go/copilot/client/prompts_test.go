@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestPromptTemplateLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/prompts":
+			var req models.PromptTemplateCreate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "greeting" || len(req.Variables) != 1 || req.Variables[0].Name != "name" {
+				t.Errorf("unexpected template request %+v", req)
+			}
+			json.NewEncoder(w).Encode(models.PromptTemplate{ID: "tpl-1", Name: req.Name, Content: req.Content, Version: 1})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/prompts/tpl-1":
+			json.NewEncoder(w).Encode(models.PromptTemplate{ID: "tpl-1", Version: 2})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/prompts/tpl-1/versions/1":
+			json.NewEncoder(w).Encode(models.PromptTemplate{ID: "tpl-1", Version: 1})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/prompts/tpl-1/versions":
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.PromptTemplate]{Items: []models.PromptTemplate{{Version: 2}, {Version: 1}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/prompts":
+			if got := r.URL.Query().Get("offset"); got != "10" {
+				t.Errorf("unexpected offset %q", got)
+			}
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.PromptTemplate]{Items: []models.PromptTemplate{{ID: "tpl-1"}}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/prompts/tpl-1":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req) != 1 || req["content"] != "Hi {{name}}!" {
+				t.Errorf("expected only the content in the update, got %v", req)
+			}
+			json.NewEncoder(w).Encode(models.PromptTemplate{ID: "tpl-1", Content: "Hi {{name}}!", Version: 2})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/prompts/tpl-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	tmpl, err := client.CreatePromptTemplate(ctx, models.PromptTemplateCreate{
+		Name:      "greeting",
+		Content:   "Hello {{name}}",
+		Variables: []models.PromptVariable{{Name: "name", Required: true}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.ID != "tpl-1" || tmpl.Version != 1 {
+		t.Errorf("unexpected template %+v", tmpl)
+	}
+	content := "Hi {{name}}!"
+	if tmpl, err = client.UpdatePromptTemplate(ctx, "tpl-1", models.PromptTemplateUpdate{Content: &content}); err != nil || tmpl.Version != 2 {
+		t.Errorf("unexpected template %+v, error %v", tmpl, err)
+	}
+	if tmpl, err = client.GetPromptTemplate(ctx, "tpl-1", 0); err != nil || tmpl.Version != 2 {
+		t.Errorf("expected the latest version, got %+v, error %v", tmpl, err)
+	}
+	if tmpl, err = client.GetPromptTemplate(ctx, "tpl-1", 1); err != nil || tmpl.Version != 1 {
+		t.Errorf("expected version 1, got %+v, error %v", tmpl, err)
+	}
+	versions, err := client.ListPromptTemplateVersions(ctx, "tpl-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 {
+		t.Errorf("unexpected versions %+v", versions)
+	}
+	page, err := client.ListPromptTemplates(ctx, &ListOptions{Offset: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Errorf("expected 1 template, got %d", len(page.Items))
+	}
+	if err := client.DeletePromptTemplate(ctx, "tpl-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// SubscribeOptions selects the change events delivered by Subscribe. Empty
+// lists match everything the caller may see.
+type SubscribeOptions struct {
+	ConversationIDs []models.ConversationID
+	RunIDs          []models.RunID
+	EventTypes      []models.ChangeEventType
+}
+
+// query encodes the options as query parameters.
+func (o SubscribeOptions) query() (url.Values, error) {
+	query := url.Values{}
+	if len(o.ConversationIDs) > 0 {
+		ids := make([]string, len(o.ConversationIDs))
+		for i, id := range o.ConversationIDs {
+			if err := id.Validate(); err != nil {
+				return nil, err
+			}
+			ids[i] = id.String()
+		}
+		query.Set("conversation_id", strings.Join(ids, ","))
+	}
+	if len(o.RunIDs) > 0 {
+		ids := make([]string, len(o.RunIDs))
+		for i, id := range o.RunIDs {
+			if err := id.Validate(); err != nil {
+				return nil, err
+			}
+			ids[i] = id.String()
+		}
+		query.Set("run_id", strings.Join(ids, ","))
+	}
+	if len(o.EventTypes) > 0 {
+		types := make([]string, len(o.EventTypes))
+		for i, t := range o.EventTypes {
+			types[i] = string(t)
+		}
+		query.Set("event_type", strings.Join(types, ","))
+	}
+	return query, nil
+}
+
+// Subscription delivers realtime change events over one long-lived
+// connection, so that UIs need not poll.
+type Subscription struct {
+	*eventChannel[models.ChangeEvent]
+}
+
+// Subscribe opens a realtime subscription to change events such as new
+// messages, run status changes, context updates and the typing and
+// presence of conversation participants. It lives until it is closed, the
+// server ends it, or ctx is done. It returns ErrFeatureDisabled when
+// WebSockets have been turned off.
+func (c *Client) Subscribe(ctx context.Context, opts SubscribeOptions) (*Subscription, error) {
+	query, err := opts.query()
+	if err != nil {
+		return nil, err
+	}
+	path := "/api/v1/realtime"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	conn, err := c.dialWebSocket(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return &Subscription{newEventChannel[models.ChangeEvent](ctx, conn)}, nil
+}
+
+// SetTyping tells the other participants of a conversation whether the
+// caller is typing; subscribers receive a conversation.typing event.
+// Servers expire the indicator after a few seconds, so callers keep
+// sending true while the user types.
+func (c *Client) SetTyping(ctx context.Context, conversationID models.ConversationID, typing bool) error {
+	if err := conversationID.Validate(); err != nil {
+		return err
+	}
+
+	req := map[string]bool{"typing": typing}
+	path := fmt.Sprintf("/api/v1/conversations/%s/typing", conversationID)
+	return c.post(ctx, path, req, nil)
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestSubscribeOptionsQuery(t *testing.T) {
+	query, err := SubscribeOptions{
+		ConversationIDs: []models.ConversationID{"conv-1", "conv-2"},
+		RunIDs:          []models.RunID{"run-1"},
+		EventTypes:      []models.ChangeEventType{models.ChangeMessageCreated, models.ChangeRunStatusChanged},
+	}.query()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "conversation_id=conv-1%2Cconv-2&event_type=message.created%2Crun.status_changed&run_id=run-1"
+	if got := query.Encode(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if query, _ := (SubscribeOptions{}).query(); len(query) != 0 {
+		t.Errorf("expected empty query, got %v", query)
+	}
+	if _, err := (SubscribeOptions{RunIDs: []models.RunID{"bad id"}}).query(); !errors.Is(err, models.ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type skipTokenRefreshKey struct{}
+
+// withoutTokenRefresh marks a context so its requests skip proactive
+// refresh, preventing the refresh call from refreshing itself.
+func withoutTokenRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipTokenRefreshKey{}, true)
+}
+
+// ensureFreshToken refreshes the access token if it expires within the
+// configured margin. If refreshing fails while the current token is still
+// valid, the current token is used.
+func (c *Client) ensureFreshToken(ctx context.Context) error {
+	if c.config.RefreshMargin <= 0 {
+		return nil
+	}
+	if apiKey, err := c.apiKey(ctx); err != nil || apiKey != "" {
+		return err
+	}
+	if skip, _ := ctx.Value(skipTokenRefreshKey{}).(bool); skip {
+		return nil
+	}
+
+	// Serialize refreshes so concurrent callers share one refresh.
+	c.session.refreshMu.Lock()
+	defer c.session.refreshMu.Unlock()
+
+	token, err := c.token()
+	if err != nil {
+		return fmt.Errorf("failed to load access token: %w", err)
+	}
+	if token == nil || token.RefreshToken == "" || !token.ExpiresWithin(c.config.RefreshMargin) {
+		return nil
+	}
+
+	if _, err := c.RefreshTokens(ctx, token.RefreshToken); err != nil {
+		if token.ExpiresWithin(0) {
+			return fmt.Errorf("access token expired and refresh failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Bounds of the wait between failed background refreshes, which doubles
+// with each consecutive failure.
+const (
+	minRefresherBackoff = 30 * time.Second
+	maxRefresherBackoff = 10 * time.Minute
+)
+
+// StartTokenRefresher refreshes the access token in the background shortly
+// before it expires, until ctx is cancelled. Use it for long-lived
+// processes so no request pays the refresh latency. Failed refreshes are
+// retried with exponential backoff. While there is nothing to refresh, no
+// token, no refresh token or an unknown expiry, it waits for the client
+// to store a new token. It does nothing for clients using API keys or
+// without a RefreshMargin.
+func (c *Client) StartTokenRefresher(ctx context.Context) {
+	if c.config.RefreshMargin <= 0 {
+		return
+	}
+	if apiKey, err := c.apiKey(ctx); err != nil || apiKey != "" {
+		return
+	}
+	go func() {
+		failures := 0
+		for {
+			token, changed, err := c.tokenWithChanges()
+			var wait time.Duration
+			switch {
+			case err != nil:
+				failures++
+				wait = refresherBackoff(failures)
+			case token == nil || token.RefreshToken == "" || token.Expiry().IsZero():
+				// Nothing can be refreshed until the token changes.
+				select {
+				case <-ctx.Done():
+					return
+				case <-changed:
+					failures = 0
+					continue
+				}
+			case token.ExpiresWithin(c.config.RefreshMargin) && failures > 0:
+				// The last refresh left the token due; back off.
+				wait = refresherBackoff(failures)
+			default:
+				wait = time.Until(token.Expiry()) - c.config.RefreshMargin
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				failures = 0
+				continue
+			case <-time.After(wait):
+			}
+
+			c.ensureFreshToken(ctx)
+			// ensureFreshToken reports no error while the current token is
+			// valid, so failure is judged by whether the token is still due.
+			if token, err := c.token(); err != nil || token == nil || token.ExpiresWithin(c.config.RefreshMargin) {
+				failures++
+			} else {
+				failures = 0
+			}
+		}
+	}()
+}
+
+// refresherBackoff returns the wait after the given number of consecutive
+// failed refreshes.
+func refresherBackoff(failures int) time.Duration {
+	wait := minRefresherBackoff
+	for i := 1; i < failures && wait < maxRefresherBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxRefresherBackoff {
+		wait = maxRefresherBackoff
+	}
+	return wait
+}
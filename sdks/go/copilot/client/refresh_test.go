@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestProactiveTokenRefresh(t *testing.T) {
+	var refreshes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/refresh":
+			atomic.AddInt32(&refreshes, 1)
+			json.NewEncoder(w).Encode(models.TokenPair{
+				AccessToken:  "fresh-token",
+				RefreshToken: "refresh-2",
+				ExpiresIn:    3600,
+			})
+		case "/api/v1/auth/me":
+			if r.Header.Get("Authorization") != "Bearer fresh-token" {
+				t.Errorf("expected fresh token, got %s", r.Header.Get("Authorization"))
+			}
+			json.NewEncoder(w).Encode(models.User{ID: "user-123"})
+		}
+	}))
+	defer server.Close()
+
+	store := auth.NewMemoryStore(&auth.Token{
+		AccessToken:  "stale-token",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Now().Add(30 * time.Second),
+	})
+	client := New(&Config{
+		BaseURL:       server.URL,
+		TokenStore:    store,
+		RefreshMargin: time.Minute,
+	})
+
+	// Concurrent calls share a single refresh.
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCurrentUser(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Errorf("expected 1 refresh, got %d", n)
+	}
+}
+
+// countingStore counts reads of the token store.
+type countingStore struct {
+	auth.TokenStore
+	gets atomic.Int32
+}
+
+func (s *countingStore) Get() (*auth.Token, error) {
+	s.gets.Add(1)
+	return s.TokenStore.Get()
+}
+
+func TestTokenRefresherWaitsWithoutExpiry(t *testing.T) {
+	refreshed := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/auth/refresh" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(models.TokenPair{AccessToken: "fresh-token", RefreshToken: "refresh-2", ExpiresIn: 3600})
+		refreshed <- struct{}{}
+	}))
+	defer server.Close()
+
+	// An opaque token with no expiry cannot be refreshed proactively.
+	store := &countingStore{TokenStore: auth.NewMemoryStore(&auth.Token{AccessToken: "opaque", RefreshToken: "refresh-1"})}
+	client := New(&Config{BaseURL: server.URL, TokenStore: store, RefreshMargin: time.Minute})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.StartTokenRefresher(ctx)
+
+	time.Sleep(100 * time.Millisecond)
+	if n := store.gets.Load(); n > 1 {
+		t.Errorf("expected the refresher to wait for a new token, got %d store reads", n)
+	}
+
+	// Storing a token that is due wakes the refresher.
+	client.storeToken(&auth.Token{AccessToken: "stale", RefreshToken: "refresh-1", ExpiresAt: time.Now().Add(30 * time.Second)})
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the new token to be refreshed")
+	}
+}
+
+func TestRefresherBackoff(t *testing.T) {
+	for failures, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 10: 10 * time.Minute} {
+		if got := refresherBackoff(failures); got != want {
+			t.Errorf("after %d failures: expected %s, got %s", failures, want, got)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how a request is retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries. Negative or zero
+	// disables retries.
+	MaxRetries int
+	// WaitMin is the wait before the first retry; it doubles per retry.
+	WaitMin time.Duration
+	// WaitMax caps the wait between retries.
+	WaitMax time.Duration
+}
+
+// RetryPolicyFunc returns the retry policy for a request, identified by
+// method and path, e.g. never retrying message sends.
+type RetryPolicyFunc func(method, path string) RetryPolicy
+
+// RetryableFunc reports whether a failed request should be retried. resp
+// is the response that carried the error, its body already read, or nil
+// when none arrived.
+type RetryableFunc func(err error, resp *http.Response) bool
+
+// Retryable is implemented by errors that know whether the request that
+// failed with them may be retried, such as those returned by custom
+// transports. It is found anywhere in an error's chain.
+type Retryable interface {
+	Retryable() bool
+}
+
+// DefaultRetryable is the classification used when Config.ShouldRetry is
+// not set. Errors implementing Retryable decide for themselves, which for
+// *CoPilotError means server errors and rate limits are retried.
+// Otherwise transient network failures, such as timeouts, refused or
+// reset connections and DNS lookups that did not complete, are retried
+// for idempotent methods, which cannot have taken effect twice. Custom
+// RetryableFuncs may fall back to it.
+func DefaultRetryable(err error, resp *http.Response) bool {
+	var retryable Retryable
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	var urlErr *url.Error
+	return resp == nil && errors.As(err, &urlErr) && idempotent(urlErr.Op) && transientNetworkError(urlErr.Err)
+}
+
+// idempotent reports whether method may be repeated without changing the
+// outcome. Methods are compared ignoring case, as url.Error.Op holds them
+// as "Get", "Put" and so on.
+func idempotent(method string) bool {
+	for _, m := range []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete} {
+		if strings.EqualFold(method, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// transientNetworkError reports whether err is a network failure likely to
+// pass: a timeout, a connection that failed or broke, or a DNS lookup that
+// did not complete. Cancellation and unknown hosts are permanent.
+func transientNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		connectionReset(err)
+}
+
+// NoRetry is a policy that never retries.
+var NoRetry = RetryPolicy{}
+
+// backoff returns the wait before the given retry attempt, starting at 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.WaitMin * time.Duration(1<<uint(attempt-1))
+	if delay > p.WaitMax {
+		delay = p.WaitMax
+	}
+	return delay
+}
+
+// defaultRetryPolicy returns the policy built from the client config.
+func (c *Client) defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: c.config.MaxRetries,
+		WaitMin:    c.config.RetryWaitMin,
+		WaitMax:    c.config.RetryWaitMax,
+	}
+}
+
+// retryPolicy returns the policy for a request, consulting
+// Config.RetryPolicy when set.
+func (c *Client) retryPolicy(method, path string) RetryPolicy {
+	if c.config.RetryPolicy != nil {
+		return c.config.RetryPolicy(method, path)
+	}
+	return c.defaultRetryPolicy()
+}
+
+// isRetryable reports whether a request that failed with err may be
+// retried, consulting Config.ShouldRetry when set.
+func (c *Client) isRetryable(err error, resp *http.Response) bool {
+	if c.config.ShouldRetry != nil {
+		return c.config.ShouldRetry(err, resp)
+	}
+	return DefaultRetryable(err, resp)
+}
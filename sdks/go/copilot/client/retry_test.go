@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyFunc(t *testing.T) {
+	var messages, health int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/messages") {
+			atomic.AddInt32(&messages, 1)
+		} else {
+			atomic.AddInt32(&health, 1)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(&Config{
+		BaseURL:      server.URL,
+		MaxRetries:   1,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+		RetryPolicy: func(method, path string) RetryPolicy {
+			if method == http.MethodPost && strings.HasSuffix(path, "/messages") {
+				return NoRetry
+			}
+			return RetryPolicy{MaxRetries: 4, WaitMin: time.Millisecond, WaitMax: time.Millisecond}
+		},
+	})
+	ctx := context.Background()
+
+	client.SendMessage(ctx, "conv-1", "hello")
+	client.HealthCheck(ctx)
+
+	if messages != 1 {
+		t.Errorf("expected SendMessage not to be retried, got %d attempts", messages)
+	}
+	if health != 5 {
+		t.Errorf("expected health check to be retried 4 times, got %d attempts", health)
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.Header().Set("X-Lock-Contention", "1")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	client := New(&Config{
+		BaseURL:      server.URL,
+		MaxRetries:   3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+		ShouldRetry: func(err error, resp *http.Response) bool {
+			if resp != nil && resp.Header.Get("X-Lock-Contention") != "" {
+				return true
+			}
+			return DefaultRetryable(err, resp)
+		},
+	})
+
+	if _, err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// flakyError is a transport error that reports itself as retryable.
+type flakyError struct{}
+
+func (flakyError) Error() string   { return "flaky transport" }
+func (flakyError) Retryable() bool { return true }
+
+type failingTransport struct {
+	failures int32
+	next     http.RoundTripper
+}
+
+func (f *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&f.failures, -1) >= 0 {
+		return nil, flakyError{}
+	}
+	return f.next.RoundTrip(req)
+}
+
+func TestRetryableError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	client := New(&Config{
+		BaseURL:      server.URL,
+		HTTPClient:   &http.Client{Transport: &failingTransport{failures: 2, next: http.DefaultTransport}},
+		MaxRetries:   2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+
+	if _, err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("expected the wrapped retryable error to be retried, got %v", err)
+	}
+}
+
+func TestDefaultRetryableNetworkErrors(t *testing.T) {
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"reset GET", &url.Error{Op: "Get", Err: reset}, true},
+		{"reset DELETE", &url.Error{Op: "Delete", Err: reset}, true},
+		{"reset POST", &url.Error{Op: "Post", Err: reset}, false},
+		{"reset PATCH", &url.Error{Op: "Patch", Err: reset}, false},
+		{"unexpected EOF", &url.Error{Op: "Get", Err: io.ErrUnexpectedEOF}, true},
+		{"timeout", &url.Error{Op: "Get", Err: context.DeadlineExceeded}, true},
+		{"canceled", &url.Error{Op: "Get", Err: context.Canceled}, false},
+		{"DNS timeout", &url.Error{Op: "Get", Err: &net.OpError{Op: "dial", Err: &net.DNSError{IsTimeout: true}}}, true},
+		{"unknown host", &url.Error{Op: "Get", Err: &net.OpError{Op: "dial", Err: &net.DNSError{IsNotFound: true}}}, false},
+		{"other", &url.Error{Op: "Get", Err: errors.New("x509: certificate signed by unknown authority")}, false},
+		{"not a url.Error", reset, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultRetryable(tt.err, nil); got != tt.retryable {
+				t.Errorf("expected retryable=%v, got %v", tt.retryable, got)
+			}
+		})
+	}
+}
+
+func TestRetryConnectionReset(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// Drop the connection without a response.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	client := New(&Config{
+		BaseURL:      server.URL,
+		MaxRetries:   2,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: time.Millisecond,
+	})
+	ctx := context.Background()
+
+	if _, err := client.HealthCheck(ctx); err != nil {
+		t.Fatalf("expected the dropped GET to be retried, got %v", err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+
+	// A POST may have taken effect before the connection dropped.
+	atomic.StoreInt32(&attempts, 0)
+	if _, err := client.SendMessage(ctx, "conv-1", "hello"); err == nil {
+		t.Fatal("expected the dropped POST to fail")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("expected POST not to be retried, got %d attempts", n)
+	}
+}
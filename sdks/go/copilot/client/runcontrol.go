@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// RunChannel is a duplex control channel to a workflow run. It streams
+// step events and accepts commands on the same connection, for building
+// live operator consoles. Commands may be sent from any goroutine.
+type RunChannel struct {
+	*eventChannel[models.RunEvent]
+	runID models.RunID
+	seq   atomic.Int64
+}
+
+// AttachRun opens a control channel to a workflow run. The channel lives
+// until it is closed, the server ends it, or ctx is done. It returns
+// ErrFeatureDisabled when WebSockets have been turned off.
+func (c *Client) AttachRun(ctx context.Context, runID models.RunID) (*RunChannel, error) {
+	if err := runID.Validate(); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.dialWebSocket(ctx, "/api/v1/workflows/runs/"+runID.String()+"/attach")
+	if err != nil {
+		return nil, err
+	}
+	return &RunChannel{
+		eventChannel: newEventChannel[models.RunEvent](ctx, conn),
+		runID:        runID,
+	}, nil
+}
+
+// RunID returns the ID of the attached run.
+func (ch *RunChannel) RunID() models.RunID {
+	return ch.runID
+}
+
+// Send sends a command to the run. An empty cmd.ID is assigned so that
+// the command's command.result event can be matched.
+func (ch *RunChannel) Send(cmd *models.RunCommand) error {
+	if cmd.ID == "" {
+		cmd.ID = "cmd-" + strconv.FormatInt(ch.seq.Add(1), 10)
+	}
+	if err := ch.send(cmd); err != nil {
+		return fmt.Errorf("failed to send command: %w", err)
+	}
+	return nil
+}
+
+// Cancel asks the server to cancel the run.
+func (ch *RunChannel) Cancel() error {
+	return ch.Send(&models.RunCommand{Type: models.RunCommandCancel})
+}
+
+// InjectInput supplies input to a step waiting for it.
+func (ch *RunChannel) InjectInput(stepID string, input map[string]interface{}) error {
+	return ch.Send(&models.RunCommand{Type: models.RunCommandInjectInput, StepID: stepID, Input: input})
+}
+
+// Approve approves a human review step.
+func (ch *RunChannel) Approve(stepID, comment string) error {
+	return ch.Send(&models.RunCommand{Type: models.RunCommandApprove, StepID: stepID, Comment: comment})
+}
+
+// Reject rejects a human review step.
+func (ch *RunChannel) Reject(stepID, comment string) error {
+	return ch.Send(&models.RunCommand{Type: models.RunCommandReject, StepID: stepID, Comment: comment})
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/internal/websocket"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestAttachRun(t *testing.T) {
+	commands := make(chan models.RunCommand, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workflows/runs/run-1/attach" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("X-API-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := websocket.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		send := func(event models.RunEvent) {
+			data, _ := json.Marshal(event)
+			conn.WriteMessage(websocket.TextMessage, data)
+		}
+		send(models.RunEvent{Type: models.RunEventReview, RunID: "run-1", StepID: "review"})
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var cmd models.RunCommand
+		json.Unmarshal(data, &cmd)
+		commands <- cmd
+		send(models.RunEvent{Type: models.RunEventCommandResult, RunID: "run-1", CommandID: cmd.ID})
+		send(models.RunEvent{Type: models.RunEventStatus, RunID: "run-1", Status: models.WorkflowStatusCompleted})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := client.AttachRun(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer ch.Close()
+
+	event := <-ch.Events()
+	if event.Type != models.RunEventReview || event.StepID != "review" {
+		t.Fatalf("expected review event, got %+v", event)
+	}
+	if err := ch.Approve("review", "looks good"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cmd := <-commands
+	if cmd.Type != models.RunCommandApprove || cmd.StepID != "review" || cmd.Comment != "looks good" || cmd.ID == "" {
+		t.Errorf("unexpected command %+v", cmd)
+	}
+
+	var got []models.RunEvent
+	for event := range ch.Events() {
+		got = append(got, event)
+	}
+	if len(got) != 2 || got[0].CommandID != cmd.ID || got[1].Status != models.WorkflowStatusCompleted {
+		t.Errorf("unexpected events %+v", got)
+	}
+	if err := ch.Err(); err != nil {
+		t.Errorf("expected normal close, got %v", err)
+	}
+}
+
+func TestAttachRunErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"not_found","message":"run not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	_, err := client.AttachRun(context.Background(), "run-404")
+	var apiErr *CoPilotError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 CoPilotError, got %v", err)
+	}
+
+	client.DisableFeature(FeatureWebSockets)
+	if _, err := client.AttachRun(context.Background(), "run-1"); !errors.Is(err, ErrFeatureDisabled) {
+		t.Errorf("expected ErrFeatureDisabled, got %v", err)
+	}
+}
+
+func TestAttachRunContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := client.AttachRun(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cancel()
+	for range ch.Events() {
+	}
+	if !errors.Is(ch.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", ch.Err())
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// ScopesHeader is the header restricting a request to a subset of the
+// credential's scopes.
+const ScopesHeader = "X-CoPilot-Scopes"
+
+type scopesKey struct{}
+
+// WithScopes returns a context whose requests may only use the given
+// scopes, even when the client's credential grants more. The server
+// rejects anything outside them, so a broadly scoped key can safely act on
+// behalf of untrusted users.
+func WithScopes(ctx context.Context, scopes ...models.ApiKeyScope) context.Context {
+	return context.WithValue(ctx, scopesKey{}, append([]models.ApiKeyScope(nil), scopes...))
+}
+
+// ScopesFromContext returns the scope restriction stored in the context.
+func ScopesFromContext(ctx context.Context) ([]models.ApiKeyScope, bool) {
+	scopes, ok := ctx.Value(scopesKey{}).([]models.ApiKeyScope)
+	return scopes, ok
+}
+
+// scopesHeaderValue encodes the context's scope restriction, or returns ""
+// when there is none. An empty restriction is sent as "none" so that it
+// cannot be mistaken for an unrestricted call.
+func scopesHeaderValue(ctx context.Context) string {
+	scopes, ok := ScopesFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if len(scopes) == 0 {
+		return "none"
+	}
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestWithScopes(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(ScopesHeader))
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	client := New(&Config{BaseURL: server.URL, APIKey: "admin-key"})
+	ctx := context.Background()
+
+	calls := []context.Context{
+		ctx,
+		WithScopes(ctx, models.ScopeRead),
+		WithScopes(ctx, models.ScopeRead, models.ScopeChat),
+		WithScopes(ctx),
+	}
+	for _, callCtx := range calls {
+		if _, err := client.HealthCheck(callCtx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"", "read", "read,chat", "none"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d: expected scopes header %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestScopesSurviveOfflineReplay(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(ScopesHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	queue, err := NewFileQueue(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &flakyTransport{}
+	transport.offline.Store(true)
+	client := New(&Config{
+		BaseURL:      server.URL,
+		HTTPClient:   &http.Client{Transport: transport},
+		OfflineQueue: queue,
+	})
+
+	ctx := context.Background()
+	client.DeleteConversation(WithScopes(ctx), "conv-1")
+	client.DeleteConversation(WithScopes(ctx, models.ScopeWrite), "conv-2")
+	client.DeleteConversation(ctx, "conv-3")
+
+	transport.offline.Store(false)
+	if n, err := client.ReplayQueue(ctx); err != nil || n != 3 {
+		t.Fatalf("expected 3 replayed requests, got %d, %v", n, err)
+	}
+	want := []string{"none", "write", ""}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("replay %d: expected scopes header %q, got %q", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// SearchOptions narrows SearchMessages.
+type SearchOptions struct {
+	ListOptions
+	// ConversationIDs limits the search to these conversations.
+	ConversationIDs []models.ConversationID
+	// Roles limits the search to messages with these roles.
+	Roles []models.MessageRole
+	// Since and Until bound the messages' creation time.
+	Since time.Time
+	Until time.Time
+	// ContextMessages is the number of messages before and after each hit
+	// returned in its Context.
+	ContextMessages int
+	// Semantic matches by meaning rather than by terms. When
+	// FeatureSemanticSearch is disabled the search falls back to matching
+	// terms.
+	Semantic bool
+}
+
+// listOptions merges the search filters into the list options.
+func (o *SearchOptions) listOptions(query string) (*ListOptions, error) {
+	var opts *ListOptions
+	if o != nil {
+		opts = &o.ListOptions
+	}
+	opts = opts.withFilter("q", query)
+	if o == nil {
+		return opts, nil
+	}
+	if len(o.ConversationIDs) > 0 {
+		ids := make([]string, len(o.ConversationIDs))
+		for i, id := range o.ConversationIDs {
+			if err := id.Validate(); err != nil {
+				return nil, err
+			}
+			ids[i] = id.String()
+		}
+		opts = opts.withFilter("conversation_id", strings.Join(ids, ","))
+	}
+	if len(o.Roles) > 0 {
+		roles := make([]string, len(o.Roles))
+		for i, role := range o.Roles {
+			roles[i] = string(role)
+		}
+		opts = opts.withFilter("role", strings.Join(roles, ","))
+	}
+	if !o.Since.IsZero() {
+		opts = opts.withFilter("since", o.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if !o.Until.IsZero() {
+		opts = opts.withFilter("until", o.Until.UTC().Format(time.RFC3339Nano))
+	}
+	if o.ContextMessages > 0 {
+		opts = opts.withFilter("context", strconv.Itoa(o.ContextMessages))
+	}
+	if o.Semantic {
+		opts = opts.withFilter("mode", "semantic")
+	}
+	return opts, nil
+}
+
+// SearchMessages searches the text of the caller's messages across
+// conversations. Hits carry a highlighted snippet and, with
+// ContextMessages, the surrounding messages, most relevant first.
+func (c *Client) SearchMessages(ctx context.Context, query string, opts *SearchOptions) (*models.PaginatedResponse[models.MessageSearchHit], error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("search query is empty")
+	}
+	if opts != nil && opts.Semantic && !c.FeatureEnabled(FeatureSemanticSearch) {
+		keyword := *opts
+		keyword.Semantic = false
+		opts = &keyword
+	}
+
+	listOpts, err := opts.listOptions(query)
+	if err != nil {
+		return nil, err
+	}
+	return List[models.MessageSearchHit](ctx, c, "/api/v1/messages/search", listOpts)
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestSearchMessages(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/messages/search" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.Query()
+		json.NewEncoder(w).Encode(models.PaginatedResponse[models.MessageSearchHit]{Items: []models.MessageSearchHit{{
+			Message:    models.Message{ID: "msg-1"},
+			Snippet:    "reset my password",
+			Highlights: []models.TextRange{{Start: 9, End: 17}},
+		}}})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	page, err := client.SearchMessages(ctx, "password", &SearchOptions{
+		ListOptions:     ListOptions{Limit: 20},
+		ConversationIDs: []models.ConversationID{"conv-1", "conv-2"},
+		Roles:           []models.MessageRole{models.RoleUser},
+		Since:           since,
+		ContextMessages: 2,
+		Semantic:        true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].HighlightedSnippet("<b>", "</b>") != "reset my <b>password</b>" {
+		t.Errorf("unexpected hits %+v", page.Items)
+	}
+	want := url.Values{
+		"q":               {"password"},
+		"limit":           {"20"},
+		"conversation_id": {"conv-1,conv-2"},
+		"role":            {"user"},
+		"since":           {"2024-03-01T00:00:00Z"},
+		"context":         {"2"},
+		"mode":            {"semantic"},
+	}
+	if query.Encode() != want.Encode() {
+		t.Errorf("unexpected query %v, want %v", query, want)
+	}
+
+	if _, err := client.SearchMessages(ctx, "password", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Encode() != "q=password" {
+		t.Errorf("unexpected query %v", query)
+	}
+
+	if _, err := client.SearchMessages(ctx, " ", nil); err == nil {
+		t.Error("expected an error for an empty query")
+	}
+
+	// Without semantic search the query falls back to matching terms.
+	client.DisableFeature(FeatureSemanticSearch)
+	opts := &SearchOptions{Semantic: true}
+	if _, err := client.SearchMessages(ctx, "password", opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query.Has("mode") || !opts.Semantic {
+		t.Errorf("expected a term search without changing the options, got %v", query)
+	}
+}
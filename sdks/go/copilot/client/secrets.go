@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// secretsPath returns the path of a workflow's secrets, or of one of them.
+func secretsPath(workflowID models.WorkflowID, name ...string) (string, error) {
+	if err := workflowID.Validate(); err != nil {
+		return "", err
+	}
+	path := "/api/v1/workflows/" + workflowID.String() + "/secrets"
+	for _, n := range name {
+		if err := models.ValidateSecretName(n); err != nil {
+			return "", err
+		}
+		path += "/" + n
+	}
+	return path, nil
+}
+
+// isSecretsPath reports whether path is under a workflow's secrets.
+func isSecretsPath(path string) bool {
+	rest, ok := strings.CutPrefix(path, "/api/v1/workflows/")
+	if !ok {
+		return false
+	}
+	_, rest, _ = strings.Cut(rest, "/")
+	return rest == "secrets" || strings.HasPrefix(rest, "secrets/")
+}
+
+// SetWorkflowSecret stores a credential that the workflow's steps
+// reference by name with models.SecretRef, replacing any value already
+// stored under the name. The value is masked in debug transcripts, never
+// written to an offline queue and never returned.
+func (c *Client) SetWorkflowSecret(ctx context.Context, workflowID models.WorkflowID, name, value string) (*models.WorkflowSecret, error) {
+	path, err := secretsPath(workflowID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req := map[string]string{"secret": value}
+
+	var secret models.WorkflowSecret
+	if err := c.request(ctx, http.MethodPut, path, req, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// ListWorkflowSecrets returns a page of the names of a workflow's secrets.
+func (c *Client) ListWorkflowSecrets(ctx context.Context, workflowID models.WorkflowID, opts *ListOptions) (*models.PaginatedResponse[models.WorkflowSecret], error) {
+	path, err := secretsPath(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	return List[models.WorkflowSecret](ctx, c, path, opts)
+}
+
+// DeleteWorkflowSecret deletes a workflow secret.
+func (c *Client) DeleteWorkflowSecret(ctx context.Context, workflowID models.WorkflowID, name string) error {
+	path, err := secretsPath(workflowID, name)
+	if err != nil {
+		return err
+	}
+	return c.delete(ctx, path)
+}
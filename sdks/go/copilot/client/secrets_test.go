@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestWorkflowSecretLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/workflows/wf-1/secrets/API_KEY":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["secret"] != "tok-123" {
+				t.Errorf("unexpected secret request %v", req)
+			}
+			json.NewEncoder(w).Encode(models.WorkflowSecret{Name: "API_KEY"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/workflows/wf-1/secrets":
+			if got := r.URL.Query().Get("cursor"); got != "next" {
+				t.Errorf("unexpected cursor %q", got)
+			}
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.WorkflowSecret]{Items: []models.WorkflowSecret{{Name: "API_KEY"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/workflows/wf-1/secrets/API_KEY":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	secret, err := client.SetWorkflowSecret(ctx, "wf-1", "API_KEY", "tok-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret.Name != "API_KEY" {
+		t.Errorf("unexpected secret %+v", secret)
+	}
+	page, err := client.ListWorkflowSecrets(ctx, "wf-1", &ListOptions{Cursor: "next"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Name != "API_KEY" {
+		t.Errorf("unexpected secrets %+v", page.Items)
+	}
+	if err := client.DeleteWorkflowSecret(ctx, "wf-1", "API_KEY"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.SetWorkflowSecret(ctx, "wf-1", "../other", "x"); !errors.Is(err, models.ErrInvalidSecretName) {
+		t.Errorf("expected ErrInvalidSecretName, got %v", err)
+	}
+}
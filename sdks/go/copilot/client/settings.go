@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// GetConversationSettings returns a conversation's generation settings.
+func (c *Client) GetConversationSettings(ctx context.Context, conversationID models.ConversationID) (*models.ConversationSettings, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+
+	var settings models.ConversationSettings
+	path := fmt.Sprintf("/api/v1/conversations/%s/settings", conversationID)
+	if err := c.get(ctx, path, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateConversationSettings changes a conversation's generation settings
+// and returns them. The changes apply to replies generated from then on.
+func (c *Client) UpdateConversationSettings(ctx context.Context, conversationID models.ConversationID, req models.ConversationSettingsUpdate) (*models.ConversationSettings, error) {
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+
+	var settings models.ConversationSettings
+	path := fmt.Sprintf("/api/v1/conversations/%s/settings", conversationID)
+	if err := c.patch(ctx, path, req, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
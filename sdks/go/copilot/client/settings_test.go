@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestConversationSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/conversations/conv-1/settings" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(models.ConversationSettings{ConversationID: "conv-1", Model: "default", ToolsEnabled: true})
+		case http.MethodPatch:
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req) != 2 || req["tools_enabled"] != false || req["allowed_tools"] == nil {
+				t.Errorf("unexpected settings update %v", req)
+			}
+			json.NewEncoder(w).Encode(models.ConversationSettings{ConversationID: "conv-1", Model: "default"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	settings, err := client.GetConversationSettings(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.Model != "default" || !settings.ToolsEnabled {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+
+	toolsEnabled := false
+	allowed := []string{}
+	settings, err = client.UpdateConversationSettings(ctx, "conv-1", models.ConversationSettingsUpdate{ToolsEnabled: &toolsEnabled, AllowedTools: &allowed})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.ToolsEnabled {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+
+	if _, err := client.GetConversationSettings(ctx, ""); err == nil {
+		t.Error("expected an error for an empty conversation ID")
+	}
+}
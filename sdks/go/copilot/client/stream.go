@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+	"github.com/llm-copilot-agent/sdk-go/copilot/streaming"
+)
+
+// StreamMessage sends a message and returns a stream of the assistant's response.
+// It returns ErrFeatureDisabled when streaming has been turned off.
+func (c *Client) StreamMessage(ctx context.Context, conversationID models.ConversationID, content string) (*streaming.Stream, error) {
+	if err := c.requireFeature(FeatureStreaming); err != nil {
+		return nil, err
+	}
+	if err := conversationID.Validate(); err != nil {
+		return nil, err
+	}
+
+	body := c.withMemory(&models.MessageCreate{
+		Role:    models.RoleUser,
+		Content: content,
+	})
+	path := fmt.Sprintf("/api/v1/conversations/%s/messages/stream", conversationID)
+
+	resp, err := c.openStream(ctx, http.MethodPost, path, body, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Resuming uses GET so the message is not sent a second time.
+	reconnect := func(ctx context.Context, lastEventID string) (*http.Response, error) {
+		return c.openStream(ctx, http.MethodGet, path, nil, lastEventID)
+	}
+
+	return streaming.NewStream(resp,
+		streaming.WithReconnect(reconnect, c.config.StreamReconnectAttempts),
+		streaming.WithIdleTimeout(c.config.StreamIdleTimeout),
+	), nil
+}
+
+// openStream opens a server-sent events connection.
+func (c *Client) openStream(ctx context.Context, method, path string, body interface{}, lastEventID string) (*http.Response, error) {
+	if err := c.ensureFreshToken(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", streaming.ContentTypeSSE+", "+streaming.ContentTypeNDJSON+";q=0.9")
+	// Compression buffers events on some servers, delaying delivery.
+	req.Header.Set("Accept-Encoding", "identity")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := c.connect(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if err := decompressResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(c.limitBody(resp.Body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, parseErrorResponse(resp, respBody)
+	}
+
+	return resp, nil
+}
+
+// SendMessageStreaming sends a message and reports response content to onContent
+// as it arrives. When streaming is disabled it falls back to SendMessage and
+// reports the complete response in a single call.
+func (c *Client) SendMessageStreaming(ctx context.Context, conversationID models.ConversationID, content string, onContent func(string)) (*models.Message, error) {
+	if !c.FeatureEnabled(FeatureStreaming) {
+		msg, err := c.SendMessage(ctx, conversationID, content)
+		if err != nil {
+			return nil, err
+		}
+		if onContent != nil {
+			onContent(msg.Content)
+		}
+		return msg, nil
+	}
+
+	stream, err := c.StreamMessage(ctx, conversationID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &models.Message{
+		ConversationID: conversationID,
+		Role:           models.RoleAssistant,
+		CreatedAt:      models.NewTimestamp(time.Now()),
+	}
+	err = stream.ForEach(ctx, func(event *streaming.Event) error {
+		if event.MessageID != "" {
+			msg.ID = models.MessageID(event.MessageID)
+		}
+		switch event.Type {
+		case streaming.EventContentDelta:
+			if onContent != nil {
+				onContent(event.Content())
+			}
+		case streaming.EventError:
+			return fmt.Errorf("stream error: %s", event.Error)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Content = stream.AccumulatedContent()
+	return msg, nil
+}
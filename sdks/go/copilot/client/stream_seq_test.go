@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStreamSeq(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"Hel\"}}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"lo\"}}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"message_end\"}\n\n")
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	stream, err := client.StreamMessage(context.Background(), "conv-123", "Hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var content string
+	for event, err := range stream.Seq(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		content += event.Content()
+	}
+	if content != "Hello" {
+		t.Errorf("expected content 'Hello', got %q", content)
+	}
+}
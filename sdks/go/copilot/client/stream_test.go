@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/streaming"
+)
+
+func TestStreamMessageReconnect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch r.Method {
+		case http.MethodPost:
+			// Drop the connection after the first delta.
+			fmt.Fprint(w, "id: 1\ndata: {\"type\":\"message_start\",\"message_id\":\"msg-1\"}\n\n")
+			fmt.Fprint(w, "id: 2\ndata: {\"type\":\"content_delta\",\"delta\":{\"text\":\"Hel\"}}\n\n")
+		case http.MethodGet:
+			if r.Header.Get("Last-Event-ID") != "2" {
+				t.Errorf("expected Last-Event-ID 2, got %q", r.Header.Get("Last-Event-ID"))
+			}
+			fmt.Fprint(w, "id: 3\ndata: {\"type\":\"content_delta\",\"delta\":{\"text\":\"lo\"}}\n\n")
+			fmt.Fprint(w, "id: 4\ndata: {\"type\":\"message_end\",\"message_id\":\"msg-1\"}\n\n")
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	stream, err := client.StreamMessage(context.Background(), "conv-123", "Hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := stream.CollectContent(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "Hello" {
+		t.Errorf("expected content 'Hello', got %q", content)
+	}
+	if stream.LastEventID() != "4" {
+		t.Errorf("expected last event ID 4, got %q", stream.LastEventID())
+	}
+}
+
+func TestStreamMessageIdleTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"type\":\"message_start\",\"message_id\":\"msg-1\"}\n\n")
+		w.(http.Flusher).Flush()
+		for i := 0; i < 3; i++ {
+			time.Sleep(30 * time.Millisecond)
+			fmt.Fprint(w, "data: {\"type\":\"ping\"}\n\n")
+			w.(http.Flusher).Flush()
+		}
+		// Stall until the test finishes.
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := New(&Config{
+		BaseURL:           server.URL,
+		StreamIdleTimeout: 50 * time.Millisecond,
+	})
+	stream, err := client.StreamMessage(context.Background(), "conv-123", "Hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, err := stream.Collect(context.Background())
+	if !errors.Is(err, streaming.ErrStreamStalled) {
+		t.Fatalf("expected ErrStreamStalled, got %v", err)
+	}
+	// Pings arriving within the timeout must keep the stream alive.
+	if len(events) != 4 {
+		t.Errorf("expected 4 events before stalling, got %d", len(events))
+	}
+}
+
+func TestStreamWriteToResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"type\":\"message_start\",\"message_id\":\"msg-1\"}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"Hel\"}}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"lo\"}}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"message_end\",\"message_id\":\"msg-1\"}\n\n")
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	stream, err := client.StreamMessage(context.Background(), "conv-123", "Hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	n, err := stream.WriteToResponse(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 5 || rec.Body.String() != "Hello" {
+		t.Errorf("expected 5 bytes 'Hello', got %d bytes %q", n, rec.Body.String())
+	}
+	if !rec.Flushed {
+		t.Error("expected response to be flushed")
+	}
+	if rec.Header().Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+}
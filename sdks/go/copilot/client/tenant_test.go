@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForTenant(t *testing.T) {
+	var mu sync.Mutex
+	tenants := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "shared-key" {
+			t.Errorf("expected shared API key, got %q", r.Header.Get("X-API-Key"))
+		}
+		mu.Lock()
+		tenants[r.Header.Get(TenantIDHeader)]++
+		mu.Unlock()
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	base := New(&Config{BaseURL: server.URL, APIKey: "shared-key", TenantID: "acme"})
+	globex := base.ForTenant("globex")
+	ctx := context.Background()
+
+	if _, err := base.HealthCheck(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := globex.HealthCheck(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tenants["acme"] != 1 || tenants["globex"] != 1 {
+		t.Errorf("unexpected tenant headers: %v", tenants)
+	}
+	if base.TenantID() != "acme" || globex.TenantID() != "globex" {
+		t.Errorf("scoped copy changed the original: %q, %q", base.TenantID(), globex.TenantID())
+	}
+
+	// Tokens are shared with scoped copies.
+	if err := globex.SetAccessToken("token-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token, _ := base.AccessToken(); token != "token-1" {
+		t.Errorf("expected shared token store, got %q", token)
+	}
+}
+
+func TestWith(t *testing.T) {
+	var mu sync.Mutex
+	var seen []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Clone())
+		mu.Unlock()
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+
+	base := New(&Config{BaseURL: server.URL, APIKey: "shared-key", TenantID: "acme", Timeout: time.Second})
+	slow := base.With(
+		func(c *Config) { c.Timeout = time.Minute },
+		func(c *Config) { c.Headers = http.Header{"X-Trace": {"on"}} },
+		func(c *Config) { c.TenantID = "globex" },
+	)
+	ctx := context.Background()
+
+	if _, err := slow.HealthCheck(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := base.HealthCheck(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen[0].Get("X-Trace") != "on" || seen[0].Get(TenantIDHeader) != "globex" {
+		t.Errorf("overrides not applied: %v", seen[0])
+	}
+	if seen[1].Get("X-Trace") != "" || seen[1].Get(TenantIDHeader) != "acme" {
+		t.Errorf("copy changed the original: %v", seen[1])
+	}
+
+	if slow.httpClient.Timeout != time.Minute || base.httpClient.Timeout != time.Second {
+		t.Errorf("unexpected timeouts: %v, %v", slow.httpClient.Timeout, base.httpClient.Timeout)
+	}
+	if slow.httpClient.Transport != base.httpClient.Transport {
+		t.Error("expected the copy to share the transport")
+	}
+	if slow.session != base.session {
+		t.Error("expected the copy to share tokens")
+	}
+}
+
+func TestWithLeavesOriginalUnchanged(t *testing.T) {
+	base := New(&Config{BaseURL: "http://localhost", APIKey: "key", TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12}})
+	pool := x509.NewCertPool()
+	copied := base.With(
+		func(c *Config) { c.TLSConfig.RootCAs = pool },
+		func(c *Config) { c.DisabledFeatures = append(c.DisabledFeatures, FeatureStreaming) },
+	)
+
+	if base.config.TLSConfig.RootCAs != nil || copied.config.TLSConfig.RootCAs != pool {
+		t.Error("expected TLS settings of the copy not to change the original")
+	}
+	if copied.FeatureEnabled(FeatureStreaming) {
+		t.Error("expected the copy to disable streaming")
+	}
+	if !base.FeatureEnabled(FeatureStreaming) || len(base.config.DisabledFeatures) != 0 {
+		t.Error("expected the original to keep streaming")
+	}
+	if _, err := copied.StreamMessage(context.Background(), "conv-1", "hi"); !errors.Is(err, ErrFeatureDisabled) {
+		t.Errorf("expected ErrFeatureDisabled, got %v", err)
+	}
+
+	// Runtime changes stay with the client they are made on.
+	base.DisableFeature(FeatureWebSockets)
+	if !copied.FeatureEnabled(FeatureWebSockets) {
+		t.Error("expected the copy not to follow the original's features")
+	}
+	if base.With().FeatureEnabled(FeatureWebSockets) {
+		t.Error("expected a new copy to inherit disabled features")
+	}
+}
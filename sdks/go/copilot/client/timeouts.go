@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// OperationClass groups requests that share a default timeout.
+type OperationClass string
+
+const (
+	// OperationRead covers GET and HEAD requests, such as health checks
+	// and listings.
+	OperationRead OperationClass = "read"
+	// OperationWrite covers requests that change state, such as sending a
+	// message or waiting on a workflow.
+	OperationWrite OperationClass = "write"
+	// OperationStreamConnect covers opening a stream or WebSocket, up to
+	// the response headers. The stream itself is bounded by its context.
+	OperationStreamConnect OperationClass = "stream_connect"
+)
+
+// operationClass returns the class of a request made with method.
+func operationClass(method string) OperationClass {
+	if method == http.MethodGet || method == http.MethodHead {
+		return OperationRead
+	}
+	return OperationWrite
+}
+
+// withOperationTimeout bounds ctx by the timeout configured for the class
+// of method, if any, and returns the HTTP client to send with. The
+// operation timeout replaces Config.Timeout, so it may be longer.
+func (c *Client) withOperationTimeout(ctx context.Context, method string) (context.Context, context.CancelFunc, *http.Client) {
+	timeout := c.config.OperationTimeouts[operationClass(method)]
+	if timeout <= 0 {
+		return ctx, func() {}, c.httpClient
+	}
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, &httpClient
+}
+
+// connect sends a request opening a long-lived connection. The overall
+// client timeout would cut it short, so only the wait for the response
+// headers is bounded, by the OperationStreamConnect timeout; the
+// connection itself is bounded by the request context.
+func (c *Client) connect(req *http.Request) (*http.Response, error) {
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	timeout := c.config.OperationTimeouts[OperationStreamConnect]
+	if timeout <= 0 {
+		return httpClient.Do(req)
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(timeout, cancel)
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if !timer.Stop() {
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		return nil, fmt.Errorf("connect timed out after %s: %w", timeout, context.DeadlineExceeded)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	body := &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	if rwc, ok := resp.Body.(io.ReadWriteCloser); ok {
+		// Upgraded connections stay writable.
+		resp.Body = &cancelConn{cancelBody: body, Writer: rwc}
+	} else {
+		resp.Body = body
+	}
+	return resp, nil
+}
+
+// cancelBody releases the context of a connection when its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// cancelConn is a cancelBody over an upgraded, writable connection.
+type cancelConn struct {
+	*cancelBody
+	io.Writer
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOperationTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"id":"conv-1"}`))
+	}))
+	defer server.Close()
+
+	client := New(&Config{
+		BaseURL:    server.URL,
+		Timeout:    20 * time.Millisecond,
+		MaxRetries: -1,
+		OperationTimeouts: map[OperationClass]time.Duration{
+			OperationRead:  20 * time.Millisecond,
+			OperationWrite: time.Second,
+		},
+	})
+	ctx := context.Background()
+
+	if _, err := client.GetConversation(ctx, "conv-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the read to time out, got %v", err)
+	}
+	// The write timeout is longer than the overall one and replaces it.
+	if _, err := client.CreateConversation(ctx, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestStreamConnectTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		// Events arrive after the connect timeout has passed.
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"Hi\"}}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"message_end\",\"message_id\":\"msg-1\"}\n\n")
+	}))
+	defer server.Close()
+
+	client := New(&Config{
+		BaseURL:           server.URL,
+		Timeout:           20 * time.Millisecond,
+		OperationTimeouts: map[OperationClass]time.Duration{OperationStreamConnect: 50 * time.Millisecond},
+	})
+	ctx := context.Background()
+
+	if _, err := client.openStream(ctx, http.MethodGet, "/stream?slow=1", nil, ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the connect to time out, got %v", err)
+	}
+
+	stream, err := client.StreamMessage(ctx, "conv-1", "Hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := stream.CollectContent(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "Hi" {
+		t.Errorf("expected content 'Hi', got %q", content)
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// W3C Trace Context headers.
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+// TraceContext is the W3C trace context of the operation a request is made
+// for, so that the API joins the caller's distributed trace.
+type TraceContext struct {
+	// Traceparent is "version-traceid-parentid-flags", e.g.
+	// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+	Traceparent string
+	// Tracestate carries vendor-specific trace data. Optional.
+	Tracestate string
+}
+
+// Valid reports whether Traceparent is well formed with non-zero IDs.
+func (tc TraceContext) Valid() bool {
+	parts := strings.Split(tc.Traceparent, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return false
+	}
+	for _, part := range parts[:4] {
+		if strings.Trim(part, "0123456789abcdef") != "" {
+			return false
+		}
+	}
+	return strings.Trim(parts[1], "0") != "" && strings.Trim(parts[2], "0") != ""
+}
+
+type traceContextKey struct{}
+
+// WithTraceContext returns a context whose requests carry tc in the
+// traceparent and tracestate headers.
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// WithTraceContextFrom returns a context carrying the trace context of an
+// incoming request's headers, if any, so that a service calling the API
+// while handling the request continues its trace.
+func WithTraceContextFrom(ctx context.Context, header http.Header) context.Context {
+	tc := TraceContext{
+		Traceparent: header.Get(TraceparentHeader),
+		Tracestate:  strings.Join(header.Values(TracestateHeader), ","),
+	}
+	if !tc.Valid() {
+		return ctx
+	}
+	return WithTraceContext(ctx, tc)
+}
+
+// TraceContextFromContext returns the trace context stored in the context.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	return tc, ok && tc.Valid()
+}
+
+// traceContext returns the trace context to send a request with,
+// consulting Config.TraceContext first.
+func (c *Client) traceContext(ctx context.Context) (TraceContext, bool) {
+	if c.config.TraceContext != nil {
+		if tc, ok := c.config.TraceContext(ctx); ok && tc.Valid() {
+			return tc, true
+		}
+	}
+	return TraceContextFromContext(ctx)
+}
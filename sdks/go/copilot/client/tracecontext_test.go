@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTraceContextValid(t *testing.T) {
+	tests := []struct {
+		traceparent string
+		valid       bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := (TraceContext{Traceparent: tt.traceparent}).Valid(); got != tt.valid {
+			t.Errorf("Valid(%q) = %v, want %v", tt.traceparent, got, tt.valid)
+		}
+	}
+}
+
+func TestTraceContextPropagation(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	defer server.Close()
+	client := NewWithAPIKey(server.URL, "test-key")
+
+	incoming := http.Header{}
+	incoming.Set(TraceparentHeader, traceparent)
+	incoming.Add(TracestateHeader, "vendor=a")
+	incoming.Add(TracestateHeader, "other=b")
+	ctx := WithTraceContextFrom(context.Background(), incoming)
+	if _, err := client.HealthCheck(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get(TraceparentHeader) != traceparent || got.Get(TracestateHeader) != "vendor=a,other=b" {
+		t.Errorf("unexpected trace headers: %q, %q", got.Get(TraceparentHeader), got.Get(TracestateHeader))
+	}
+
+	// Without a trace context no headers are sent.
+	if _, err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get(TraceparentHeader) != "" {
+		t.Errorf("unexpected traceparent %q", got.Get(TraceparentHeader))
+	}
+
+	// A configured function takes precedence.
+	client.config.TraceContext = func(ctx context.Context) (TraceContext, bool) {
+		return TraceContext{Traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}, true
+	}
+	if _, err := client.HealthCheck(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get(TraceparentHeader) != "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01" {
+		t.Errorf("expected the configured trace context, got %q", got.Get(TraceparentHeader))
+	}
+}
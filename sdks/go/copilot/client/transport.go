@@ -0,0 +1,45 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// newHTTPClient builds the HTTP client used when Config.HTTPClient is not set.
+func newHTTPClient(config *Config) *http.Client {
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: newTransport(config),
+	}
+}
+
+// newTransport builds the default transport from the config's transport settings.
+func newTransport(config *Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig.Clone()
+	}
+	if config.Proxy != nil {
+		transport.Proxy = config.Proxy
+	}
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+	}
+
+	// Custom TLS settings would otherwise silently disable HTTP/2.
+	transport.ForceAttemptHTTP2 = !config.DisableHTTP2
+	if config.DisableHTTP2 {
+		// A non-nil empty map disables the transport's HTTP/2 support.
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport
+}
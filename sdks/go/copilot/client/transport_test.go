@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestTransportTuning(t *testing.T) {
+	transport := newTransport(&Config{
+		MaxIdleConns:        500,
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     2 * time.Minute,
+		TLSHandshakeTimeout: 3 * time.Second,
+	})
+
+	if transport.MaxIdleConns != 500 || transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("unexpected pool size %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 2*time.Minute || transport.TLSHandshakeTimeout != 3*time.Second {
+		t.Errorf("unexpected timeouts %v/%v", transport.IdleConnTimeout, transport.TLSHandshakeTimeout)
+	}
+
+	defaults := newTransport(&Config{})
+	if defaults.MaxIdleConns != http.DefaultTransport.(*http.Transport).MaxIdleConns {
+		t.Errorf("expected zero values to keep net/http defaults, got %d", defaults.MaxIdleConns)
+	}
+}
+
+func TestHTTP2Negotiation(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.User{ID: r.Proto})
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	for _, tt := range []struct {
+		disable bool
+		proto   string
+	}{
+		{false, "HTTP/2.0"},
+		{true, "HTTP/1.1"},
+	} {
+		client := New(&Config{
+			BaseURL:      server.URL,
+			TLSConfig:    &tls.Config{RootCAs: rootCAs},
+			DisableHTTP2: tt.disable,
+		})
+		user, err := client.GetCurrentUser(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.ID != tt.proto {
+			t.Errorf("DisableHTTP2=%v: expected %s, got %s", tt.disable, tt.proto, user.ID)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// CreateTrigger creates a trigger that runs a workflow on matching events.
+// The request is validated first, failing with models.ErrInvalidTrigger.
+func (c *Client) CreateTrigger(ctx context.Context, req models.TriggerCreate) (*models.Trigger, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var trigger models.Trigger
+	if err := c.post(ctx, "/api/v1/triggers", req, &trigger); err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// GetTrigger retrieves a trigger.
+func (c *Client) GetTrigger(ctx context.Context, id string) (*models.Trigger, error) {
+	var trigger models.Trigger
+	if err := c.get(ctx, "/api/v1/triggers/"+id, &trigger); err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// ListTriggers returns a page of triggers, limited to one workflow when
+// workflowID is set.
+func (c *Client) ListTriggers(ctx context.Context, workflowID models.WorkflowID, opts *ListOptions) (*models.PaginatedResponse[models.Trigger], error) {
+	if workflowID != "" {
+		opts = opts.withFilter("workflow_id", workflowID.String())
+	}
+	return List[models.Trigger](ctx, c, "/api/v1/triggers", opts)
+}
+
+// UpdateTrigger updates a trigger, e.g. to pause it by setting Active to
+// false.
+func (c *Client) UpdateTrigger(ctx context.Context, id string, req models.TriggerUpdate) (*models.Trigger, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var trigger models.Trigger
+	if err := c.patch(ctx, "/api/v1/triggers/"+id, req, &trigger); err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// DeleteTrigger deletes a trigger.
+func (c *Client) DeleteTrigger(ctx context.Context, id string) error {
+	return c.delete(ctx, "/api/v1/triggers/"+id)
+}
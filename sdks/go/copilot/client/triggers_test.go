@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestTriggerLifecycle(t *testing.T) {
+	filter := models.Var("event.context.tags").Eq("billing")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/triggers":
+			var req models.TriggerCreate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.WorkflowID != "wf-1" || req.EventType != models.TriggerEventContextCreated || req.Filter != filter {
+				t.Errorf("unexpected trigger request %+v", req)
+			}
+			json.NewEncoder(w).Encode(models.Trigger{ID: "trg-1", WorkflowID: req.WorkflowID, EventType: req.EventType, Filter: req.Filter, Active: true})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/triggers/trg-1":
+			json.NewEncoder(w).Encode(models.Trigger{ID: "trg-1", Active: true})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/triggers":
+			if got := r.URL.Query().Get("workflow_id"); got != "wf-1" {
+				t.Errorf("unexpected workflow filter %q", got)
+			}
+			json.NewEncoder(w).Encode(models.PaginatedResponse[models.Trigger]{Items: []models.Trigger{{ID: "trg-1"}}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/triggers/trg-1":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req) != 1 || req["active"] != false {
+				t.Errorf("expected only active=false in the update, got %v", req)
+			}
+			json.NewEncoder(w).Encode(models.Trigger{ID: "trg-1", Active: false})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/triggers/trg-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	trigger, err := client.CreateTrigger(ctx, models.TriggerCreate{WorkflowID: "wf-1", EventType: models.TriggerEventContextCreated, Filter: filter})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trigger.ID != "trg-1" || !trigger.Active {
+		t.Errorf("unexpected trigger %+v", trigger)
+	}
+	if trigger, err = client.GetTrigger(ctx, "trg-1"); err != nil || trigger.ID != "trg-1" {
+		t.Errorf("unexpected trigger %+v, error %v", trigger, err)
+	}
+	page, err := client.ListTriggers(ctx, "wf-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Errorf("expected 1 trigger, got %d", len(page.Items))
+	}
+	active := false
+	if trigger, err = client.UpdateTrigger(ctx, "trg-1", models.TriggerUpdate{Active: &active}); err != nil || trigger.Active {
+		t.Errorf("unexpected trigger %+v, error %v", trigger, err)
+	}
+	if err := client.DeleteTrigger(ctx, "trg-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Invalid triggers are rejected before a request is made.
+	if _, err := client.CreateTrigger(ctx, models.TriggerCreate{WorkflowID: "wf-1"}); !errors.Is(err, models.ErrInvalidTrigger) {
+		t.Errorf("expected ErrInvalidTrigger, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// GetUsage returns token, request, and cost usage for the given time range.
+func (c *Client) GetUsage(ctx context.Context, query models.UsageQuery) (*models.UsageReport, error) {
+	params := url.Values{}
+	if !query.From.IsZero() {
+		params.Set("from", query.From.UTC().Format(time.RFC3339))
+	}
+	if !query.To.IsZero() {
+		params.Set("to", query.To.UTC().Format(time.RFC3339))
+	}
+	if query.GroupBy != "" {
+		params.Set("group_by", string(query.GroupBy))
+	}
+
+	path := "/api/v1/usage"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var report models.UsageReport
+	if err := c.get(ctx, path, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
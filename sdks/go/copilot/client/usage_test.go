@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestGetUsage(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/usage" {
+			t.Errorf("expected path /api/v1/usage, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("from") != "2024-01-01T00:00:00Z" || q.Get("to") != "2024-02-01T00:00:00Z" {
+			t.Errorf("unexpected time range: %s", r.URL.RawQuery)
+		}
+		if q.Get("group_by") != "model" {
+			t.Errorf("expected group_by=model, got %s", q.Get("group_by"))
+		}
+
+		json.NewEncoder(w).Encode(models.UsageReport{
+			From:     models.NewTimestamp(from),
+			To:       models.NewTimestamp(to),
+			GroupBy:  models.UsageGroupByModel,
+			Currency: "USD",
+			Totals:   models.UsageTotals{TotalTokens: 1500, Requests: 3, Cost: 0.75},
+			Breakdown: []models.UsageBreakdown{
+				{Key: "claude-3-opus", UsageTotals: models.UsageTotals{TotalTokens: 1000, Requests: 2, Cost: 0.6}},
+				{Key: "claude-3-haiku", UsageTotals: models.UsageTotals{TotalTokens: 500, Requests: 1, Cost: 0.15}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	report, err := client.GetUsage(context.Background(), models.UsageQuery{
+		From:    from,
+		To:      to,
+		GroupBy: models.UsageGroupByModel,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Totals.TotalTokens != 1500 {
+		t.Errorf("expected 1500 total tokens, got %d", report.Totals.TotalTokens)
+	}
+	if len(report.Breakdown) != 2 || report.Breakdown[0].Requests != 2 {
+		t.Errorf("unexpected breakdown: %+v", report.Breakdown)
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// CreateWebhook subscribes a URL to the given events.
+func (c *Client) CreateWebhook(ctx context.Context, req models.WebhookCreate) (*models.Webhook, error) {
+	var wh models.Webhook
+	if err := c.post(ctx, "/api/v1/webhooks", req, &wh); err != nil {
+		return nil, err
+	}
+	return &wh, nil
+}
+
+// GetWebhook retrieves a webhook subscription.
+func (c *Client) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	var wh models.Webhook
+	if err := c.get(ctx, "/api/v1/webhooks/"+id, &wh); err != nil {
+		return nil, err
+	}
+	return &wh, nil
+}
+
+// ListWebhooks returns a page of webhook subscriptions.
+func (c *Client) ListWebhooks(ctx context.Context, opts *ListOptions) (*models.PaginatedResponse[models.Webhook], error) {
+	return List[models.Webhook](ctx, c, "/api/v1/webhooks", opts)
+}
+
+// UpdateWebhook updates a webhook subscription.
+func (c *Client) UpdateWebhook(ctx context.Context, id string, req models.WebhookUpdate) (*models.Webhook, error) {
+	var wh models.Webhook
+	if err := c.patch(ctx, "/api/v1/webhooks/"+id, req, &wh); err != nil {
+		return nil, err
+	}
+	return &wh, nil
+}
+
+// DeleteWebhook deletes a webhook subscription.
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.delete(ctx, "/api/v1/webhooks/"+id)
+}
+
+// TestWebhook sends a ping event to the webhook and returns the delivery result.
+func (c *Client) TestWebhook(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := c.post(ctx, "/api/v1/webhooks/"+id+"/test", nil, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestWebhookLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/webhooks":
+			var req models.WebhookCreate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Secret != "s3cret" {
+				t.Errorf("expected secret to be sent")
+			}
+			json.NewEncoder(w).Encode(models.Webhook{ID: "wh-1", URL: req.URL, Events: req.Events, Active: true})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/webhooks/wh-1":
+			var req models.WebhookUpdate
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Active == nil || *req.Active {
+				t.Errorf("expected active=false in update")
+			}
+			json.NewEncoder(w).Encode(models.Webhook{ID: "wh-1", Active: false})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/webhooks/wh-1/test":
+			json.NewEncoder(w).Encode(models.WebhookDelivery{
+				WebhookID:  "wh-1",
+				EventType:  models.WebhookEventPing,
+				StatusCode: 200,
+				Success:    true,
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/webhooks/wh-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithAPIKey(server.URL, "test-key")
+	ctx := context.Background()
+
+	wh, err := client.CreateWebhook(ctx, models.WebhookCreate{
+		URL:    "https://example.com/hooks",
+		Events: []models.WebhookEventType{models.WebhookEventWorkflowRunCompleted},
+		Secret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wh.ID != "wh-1" || len(wh.Events) != 1 {
+		t.Errorf("unexpected webhook: %+v", wh)
+	}
+
+	active := false
+	wh, err = client.UpdateWebhook(ctx, "wh-1", models.WebhookUpdate{Active: &active})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if wh.Active {
+		t.Errorf("expected webhook to be inactive")
+	}
+
+	delivery, err := client.TestWebhook(ctx, "wh-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !delivery.Success {
+		t.Errorf("expected successful delivery")
+	}
+
+	if err := client.DeleteWebhook(ctx, "wh-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
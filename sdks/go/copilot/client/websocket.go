@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/internal/websocket"
+)
+
+// dialWebSocket opens a WebSocket connection to path, authenticated like
+// any other request. It returns ErrFeatureDisabled when WebSockets have
+// been turned off.
+func (c *Client) dialWebSocket(ctx context.Context, path string) (*websocket.Conn, error) {
+	if err := c.requireFeature(FeatureWebSockets); err != nil {
+		return nil, err
+	}
+	if err := c.ensureFreshToken(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("Content-Type")
+	req.Header.Set("Accept-Encoding", "identity")
+	key, err := websocket.PrepareRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.connect(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		if err := decompressResponse(resp); err != nil {
+			return nil, err
+		}
+		respBody, err := io.ReadAll(c.limitBody(resp.Body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, parseErrorResponse(resp, respBody)
+	}
+
+	conn, err := websocket.NewClientConn(resp, key)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// eventChannel delivers the JSON events of type T read from a WebSocket
+// connection. It ends when closed, when the server closes the connection,
+// or when the context it was opened with is done.
+type eventChannel[T any] struct {
+	conn   *websocket.Conn
+	events chan T
+	done   chan struct{}
+
+	mu        sync.Mutex
+	err       error
+	closeOnce sync.Once
+}
+
+func newEventChannel[T any](ctx context.Context, conn *websocket.Conn) *eventChannel[T] {
+	ch := &eventChannel[T]{
+		conn:   conn,
+		events: make(chan T, 100),
+		done:   make(chan struct{}),
+	}
+	go ch.readEvents()
+	go func() {
+		select {
+		case <-ctx.Done():
+			ch.close(ctx.Err())
+		case <-ch.done:
+		}
+	}()
+	return ch
+}
+
+// Events returns the events. The channel is closed when the connection
+// ends; Err then reports why.
+func (ch *eventChannel[T]) Events() <-chan T {
+	return ch.events
+}
+
+// Err returns the error that ended the connection, or nil if it was
+// closed normally.
+func (ch *eventChannel[T]) Err() error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	return ch.err
+}
+
+// Close closes the connection.
+func (ch *eventChannel[T]) Close() error {
+	ch.close(nil)
+	return nil
+}
+
+func (ch *eventChannel[T]) readEvents() {
+	defer close(ch.events)
+	for {
+		_, data, err := ch.conn.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) && (closeErr.Code == websocket.CloseNormal || closeErr.Code == websocket.CloseNoStatus) {
+				err = nil
+			}
+			ch.close(err)
+			return
+		}
+
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			ch.close(fmt.Errorf("failed to parse event: %w", err))
+			return
+		}
+		select {
+		case ch.events <- event:
+		case <-ch.done:
+			return
+		}
+	}
+}
+
+// send writes v as a JSON text message.
+func (ch *eventChannel[T]) send(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return ch.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// close records err as the reason the connection ended, on first call.
+func (ch *eventChannel[T]) close(err error) {
+	ch.closeOnce.Do(func() {
+		ch.mu.Lock()
+		ch.err = err
+		ch.mu.Unlock()
+		close(ch.done)
+		ch.conn.Close()
+	})
+}
@@ -0,0 +1,37 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/anthropic"
+)
+
+// anthropicTranscript is an exported Anthropic Messages conversation.
+type anthropicTranscript struct {
+	System   string                   `json:"system,omitempty"`
+	Messages []anthropic.MessageParam `json:"messages"`
+}
+
+// FromAnthropic reads a transcript in the Anthropic Messages format: an
+// object with an optional "system" prompt and a "messages" list.
+func FromAnthropic(data []byte) (*Transcript, error) {
+	var in anthropicTranscript
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("convert: invalid Anthropic transcript: %w", err)
+	}
+	msgs, err := anthropic.ToMessages(in.System, in.Messages)
+	if err != nil {
+		return nil, err
+	}
+	return fromMessages(msgs), nil
+}
+
+// ToAnthropic writes t in the Anthropic Messages format.
+func ToAnthropic(t *Transcript) ([]byte, error) {
+	system, params, err := anthropic.FromMessages(t.messages())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(anthropicTranscript{System: system, Messages: params})
+}
@@ -0,0 +1,54 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// ChatML delimiters.
+const (
+	chatMLStart = "<|im_start|>"
+	chatMLEnd   = "<|im_end|>"
+)
+
+// FromChatML reads a ChatML transcript, in which each message is written
+// as "<|im_start|>role\ncontent<|im_end|>".
+func FromChatML(data string) (*Transcript, error) {
+	var msgs []models.Message
+	rest := data
+	for {
+		start := strings.Index(rest, chatMLStart)
+		if start < 0 {
+			break
+		}
+		rest = rest[start+len(chatMLStart):]
+		end := strings.Index(rest, chatMLEnd)
+		if end < 0 {
+			return nil, fmt.Errorf("convert: unterminated ChatML message")
+		}
+		role, content, _ := strings.Cut(rest[:end], "\n")
+		rest = rest[end+len(chatMLEnd):]
+
+		switch r := models.MessageRole(strings.TrimSpace(role)); r {
+		case models.RoleSystem, models.RoleUser, models.RoleAssistant:
+			msgs = append(msgs, models.Message{Role: r, Content: content})
+		default:
+			return nil, fmt.Errorf("convert: unsupported ChatML role %q", role)
+		}
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("convert: no ChatML messages found")
+	}
+	return fromMessages(msgs), nil
+}
+
+// ToChatML writes t as ChatML. Only the text of each message is kept.
+func ToChatML(t *Transcript) string {
+	var b strings.Builder
+	for _, msg := range t.messages() {
+		fmt.Fprintf(&b, "%s%s\n%s%s\n", chatMLStart, msg.Role, msg.Content, chatMLEnd)
+	}
+	return b.String()
+}
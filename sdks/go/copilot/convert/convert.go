@@ -0,0 +1,67 @@
+// Package convert translates conversation transcripts exported from other
+// chat APIs into CoPilot request payloads and back, for migration tooling.
+//
+// Supported formats are OpenAI chat messages (including tool calls),
+// Anthropic Messages and ChatML. Each is read into a Transcript: the
+// ConversationCreate to create the conversation with, carrying the system
+// prompt, and the MessageCreate batch of its messages in order.
+package convert
+
+import (
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// Transcript is a conversation ready to be created through the API.
+type Transcript struct {
+	Conversation models.ConversationCreate
+	Messages     []models.MessageCreate
+}
+
+// FromConversation returns the transcript of a stored conversation, for
+// exporting it to another format. The conversation's system prompt and
+// any system messages become the system prompt.
+func FromConversation(conv *models.ConversationWithMessages) *Transcript {
+	t := fromMessages(conv.Messages)
+	if conv.SystemPrompt != "" {
+		t.Conversation.SystemPrompt = strings.TrimSpace(conv.SystemPrompt + "\n\n" + t.Conversation.SystemPrompt)
+	}
+	t.Conversation.Title = conv.Title
+	t.Conversation.Metadata = conv.Metadata
+	return t
+}
+
+// fromMessages builds a transcript from messages, joining system messages
+// into the system prompt.
+func fromMessages(msgs []models.Message) *Transcript {
+	t := &Transcript{}
+	var system []string
+	for _, msg := range msgs {
+		if msg.Role == models.RoleSystem {
+			system = append(system, msg.Content)
+			continue
+		}
+		t.Messages = append(t.Messages, models.MessageCreate{
+			Role:     msg.Role,
+			Content:  msg.Content,
+			Metadata: msg.Metadata,
+			Parts:    msg.Parts,
+		})
+	}
+	t.Conversation.SystemPrompt = strings.Join(system, "\n\n")
+	return t
+}
+
+// messages returns the transcript as messages, led by a system message
+// holding the system prompt, if any.
+func (t *Transcript) messages() []models.Message {
+	msgs := make([]models.Message, 0, len(t.Messages)+1)
+	if t.Conversation.SystemPrompt != "" {
+		msgs = append(msgs, models.Message{Role: models.RoleSystem, Content: t.Conversation.SystemPrompt})
+	}
+	for _, m := range t.Messages {
+		msgs = append(msgs, models.Message{Role: m.Role, Content: m.Content, Metadata: m.Metadata, Parts: m.Parts})
+	}
+	return msgs
+}
@@ -0,0 +1,122 @@
+package convert
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+const openAITranscript = `{"messages": [
+	{"role": "system", "content": "You are a travel agent."},
+	{"role": "user", "content": [
+		{"type": "text", "text": "Where is this?"},
+		{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGk="}}
+	]},
+	{"role": "assistant", "content": null, "tool_calls": [
+		{"id": "call_1", "type": "function", "function": {"name": "locate", "arguments": "{\"image\":0}"}}
+	]},
+	{"role": "tool", "tool_call_id": "call_1", "content": "Paris"},
+	{"role": "assistant", "content": "That is Paris."}
+]}`
+
+func TestFromOpenAI(t *testing.T) {
+	tr, err := FromOpenAI([]byte(openAITranscript))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Conversation.SystemPrompt != "You are a travel agent." || len(tr.Messages) != 4 {
+		t.Fatalf("unexpected transcript %+v", tr)
+	}
+
+	user := tr.Messages[0]
+	if user.Content != "Where is this?" || len(user.Parts) != 2 || user.Parts[1].MediaType != "image/png" || user.Parts[1].Data != "aGk=" {
+		t.Errorf("unexpected user message %+v", user)
+	}
+	call := tr.Messages[1].Parts[0]
+	if call.Type != models.ContentPartToolUse || call.ToolName != "locate" || string(call.Input) != `{"image":0}` {
+		t.Errorf("unexpected tool call %+v", call)
+	}
+	result := tr.Messages[2]
+	if result.Role != models.RoleUser || result.Parts[0].Type != models.ContentPartToolResult || result.Parts[0].Text != "Paris" {
+		t.Errorf("unexpected tool result %+v", result)
+	}
+
+	out, err := ToOpenAI(tr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, err := FromOpenAI(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(tr, again) {
+		t.Errorf("round trip changed transcript:\n%s", out)
+	}
+}
+
+func TestAnthropicRoundTrip(t *testing.T) {
+	tr, err := FromOpenAI([]byte(openAITranscript))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := ToAnthropic(tr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var raw map[string]interface{}
+	json.Unmarshal(out, &raw)
+	if raw["system"] != "You are a travel agent." {
+		t.Errorf("expected system prompt, got %s", out)
+	}
+
+	again, err := FromAnthropic(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(tr, again) {
+		t.Errorf("round trip changed transcript:\n%s", out)
+	}
+}
+
+func TestChatML(t *testing.T) {
+	in := "<|im_start|>system\nBe brief.<|im_end|>\n<|im_start|>user\nHi\nthere<|im_end|>\n<|im_start|>assistant\nHello!<|im_end|>\n"
+	tr, err := FromChatML(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Conversation.SystemPrompt != "Be brief." || len(tr.Messages) != 2 || tr.Messages[0].Content != "Hi\nthere" {
+		t.Fatalf("unexpected transcript %+v", tr)
+	}
+	if out := ToChatML(tr); out != in {
+		t.Errorf("unexpected ChatML:\n%s", out)
+	}
+
+	if _, err := FromChatML("<|im_start|>user\nunterminated"); err == nil || !strings.Contains(err.Error(), "unterminated") {
+		t.Errorf("expected unterminated error, got %v", err)
+	}
+}
+
+func TestFromConversation(t *testing.T) {
+	tr := FromConversation(&models.ConversationWithMessages{
+		Conversation: models.Conversation{Title: "Trip"},
+		Messages: []models.Message{
+			{Role: models.RoleSystem, Content: "Be brief."},
+			{Role: models.RoleUser, Content: "Hi"},
+		},
+	})
+	if tr.Conversation.Title != "Trip" || tr.Conversation.SystemPrompt != "Be brief." || len(tr.Messages) != 1 {
+		t.Errorf("unexpected transcript %+v", tr)
+	}
+
+	tr = FromConversation(&models.ConversationWithMessages{
+		Conversation: models.Conversation{SystemPrompt: "You plan trips."},
+		Messages:     []models.Message{{Role: models.RoleSystem, Content: "Be brief."}},
+	})
+	if want := "You plan trips.\n\nBe brief."; tr.Conversation.SystemPrompt != want {
+		t.Errorf("expected system prompt %q, got %q", want, tr.Conversation.SystemPrompt)
+	}
+}
@@ -0,0 +1,216 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// openAIMessage is a message of an OpenAI chat transcript.
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    json.RawMessage  `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAIContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL *struct {
+		URL string `json:"url"`
+	} `json:"image_url,omitempty"`
+}
+
+// FromOpenAI reads a transcript of OpenAI chat messages, given either as a
+// list of messages or as an object with a "messages" list, as in
+// fine-tuning files. Tool calls and tool results become tool_use and
+// tool_result parts; tool messages are attributed to the user.
+func FromOpenAI(data []byte) (*Transcript, error) {
+	var in []openAIMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapped struct {
+			Messages []openAIMessage `json:"messages"`
+		}
+		if err := json.Unmarshal(data, &wrapped); err != nil {
+			return nil, fmt.Errorf("convert: invalid OpenAI transcript: %w", err)
+		}
+		in = wrapped.Messages
+	} else if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("convert: invalid OpenAI transcript: %w", err)
+	}
+
+	msgs := make([]models.Message, 0, len(in))
+	for _, m := range in {
+		msg, err := fromOpenAIMessage(m)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return fromMessages(msgs), nil
+}
+
+func fromOpenAIMessage(m openAIMessage) (models.Message, error) {
+	parts, err := openAIContentParts(m.Content)
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	var role models.MessageRole
+	switch m.Role {
+	case "system", "developer":
+		role = models.RoleSystem
+	case "user":
+		role = models.RoleUser
+	case "assistant":
+		role = models.RoleAssistant
+		for _, call := range m.ToolCalls {
+			input := json.RawMessage(call.Function.Arguments)
+			if !json.Valid(input) {
+				input, _ = json.Marshal(call.Function.Arguments)
+			}
+			parts = append(parts, models.ContentPart{
+				Type:      models.ContentPartToolUse,
+				ToolUseID: call.ID,
+				ToolName:  call.Function.Name,
+				Input:     input,
+			})
+		}
+	case "tool":
+		role = models.RoleUser
+		parts = []models.ContentPart{{
+			Type:      models.ContentPartToolResult,
+			ToolUseID: m.ToolCallID,
+			Text:      models.TextParts(parts),
+		}}
+	default:
+		return models.Message{}, fmt.Errorf("convert: unsupported OpenAI role %q", m.Role)
+	}
+
+	msg := models.Message{Role: role, Content: models.TextParts(parts)}
+	if len(parts) > 1 || len(parts) == 1 && parts[0].Type != models.ContentPartText {
+		msg.Parts = parts
+	}
+	return msg, nil
+}
+
+// openAIContentParts decodes message content given as a string, null, or
+// a list of text and image_url parts.
+func openAIContentParts(raw json.RawMessage) ([]models.ContentPart, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []models.ContentPart{{Type: models.ContentPartText, Text: text}}, nil
+	}
+
+	var in []openAIContentPart
+	if err := json.Unmarshal(raw, &in); err != nil {
+		return nil, fmt.Errorf("convert: invalid OpenAI message content: %w", err)
+	}
+	parts := make([]models.ContentPart, 0, len(in))
+	for _, p := range in {
+		switch {
+		case p.Type == "text":
+			parts = append(parts, models.ContentPart{Type: models.ContentPartText, Text: p.Text})
+		case p.Type == "image_url" && p.ImageURL != nil:
+			parts = append(parts, imagePart(p.ImageURL.URL))
+		default:
+			return nil, fmt.Errorf("convert: unsupported OpenAI content part %q", p.Type)
+		}
+	}
+	return parts, nil
+}
+
+// imagePart returns an image part for url, unpacking base64 data URLs.
+func imagePart(url string) models.ContentPart {
+	if rest, ok := strings.CutPrefix(url, "data:"); ok {
+		if mediaType, data, ok := strings.Cut(rest, ";base64,"); ok {
+			return models.ContentPart{Type: models.ContentPartImage, MediaType: mediaType, Data: data}
+		}
+	}
+	return models.ContentPart{Type: models.ContentPartImage, URL: url}
+}
+
+// ToOpenAI writes t as an object with a "messages" list of OpenAI chat
+// messages. Tool results become tool messages.
+func ToOpenAI(t *Transcript) ([]byte, error) {
+	var out []openAIMessage
+	for _, msg := range t.messages() {
+		converted, err := toOpenAIMessages(msg)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, converted...)
+	}
+	return json.Marshal(struct {
+		Messages []openAIMessage `json:"messages"`
+	}{out})
+}
+
+func toOpenAIMessages(msg models.Message) ([]openAIMessage, error) {
+	if len(msg.Parts) == 0 {
+		content, _ := json.Marshal(msg.Content)
+		return []openAIMessage{{Role: string(msg.Role), Content: content}}, nil
+	}
+
+	var out []openAIMessage
+	var content []openAIContentPart
+	var calls []openAIToolCall
+	hasImage := false
+	for _, part := range msg.Parts {
+		switch part.Type {
+		case models.ContentPartText:
+			content = append(content, openAIContentPart{Type: "text", Text: part.Text})
+		case models.ContentPartImage:
+			url := part.URL
+			if url == "" {
+				url = "data:" + part.MediaType + ";base64," + part.Data
+			}
+			p := openAIContentPart{Type: "image_url"}
+			p.ImageURL = &struct {
+				URL string `json:"url"`
+			}{url}
+			content = append(content, p)
+			hasImage = true
+		case models.ContentPartToolUse:
+			call := openAIToolCall{ID: part.ToolUseID, Type: "function"}
+			call.Function.Name = part.ToolName
+			call.Function.Arguments = string(part.Input)
+			calls = append(calls, call)
+		case models.ContentPartToolResult:
+			text, _ := json.Marshal(part.Text)
+			out = append(out, openAIMessage{Role: "tool", Content: text, ToolCallID: part.ToolUseID})
+		default:
+			return nil, fmt.Errorf("convert: unsupported content part type %q", part.Type)
+		}
+	}
+
+	if len(content) == 0 && len(calls) == 0 {
+		return out, nil
+	}
+	m := openAIMessage{Role: string(msg.Role), ToolCalls: calls}
+	switch {
+	case hasImage:
+		m.Content, _ = json.Marshal(content)
+	case len(content) > 0:
+		m.Content, _ = json.Marshal(msg.Content)
+	default:
+		m.Content = json.RawMessage("null")
+	}
+	return append(out, m), nil
+}
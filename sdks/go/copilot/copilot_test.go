@@ -0,0 +1,141 @@
+package copilot
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestCertificate returns a self-signed certificate and key as PEM.
+func newTestCertificate(t *testing.T, name string) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestMutualTLS(t *testing.T) {
+	certPEM, keyPEM := newTestCertificate(t, "sdk-client")
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"id": r.TLS.PeerCertificates[0].Subject.CommonName})
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	ctx := context.Background()
+
+	client := NewClient(server.URL, WithRootCAs(rootCAs), WithClientCertificate(certPEM, keyPEM))
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != "sdk-client" {
+		t.Errorf("expected server to see client certificate, got %s", user.ID)
+	}
+
+	client = NewClient(server.URL, WithRootCAs(rootCAs), WithClientCertificate(certPEM, []byte("bogus")),
+		WithMaxRetries(0))
+	if _, err := client.GetCurrentUser(ctx); err == nil || !strings.Contains(err.Error(), "invalid client certificate") {
+		t.Errorf("expected invalid certificate error, got %v", err)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "copilot.invalid" {
+			t.Errorf("expected request for copilot.invalid, got %s", r.URL.Host)
+		}
+		want := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+		if r.Header.Get("Proxy-Authorization") != want {
+			t.Errorf("expected proxy credentials, got %q", r.Header.Get("Proxy-Authorization"))
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "via-proxy"})
+	}))
+	defer proxy.Close()
+
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://user:secret@", 1)
+	client := NewClient("http://copilot.invalid", WithProxy(proxyURL))
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != "via-proxy" {
+		t.Errorf("expected response from proxy, got %s", user.ID)
+	}
+
+	client = NewClient("http://copilot.invalid", WithProxy("http://%zz"), WithMaxRetries(0))
+	if _, err := client.GetCurrentUser(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid proxy URL") {
+		t.Errorf("expected invalid proxy error, got %v", err)
+	}
+}
+
+func TestUserAgentAndDefaultHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		json.NewEncoder(w).Encode(map[string]string{"id": "user-123"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL,
+		WithAPIKey("key"),
+		WithUserAgent("my-app/1.2.0"),
+		WithDefaultHeader("X-Tenant", "acme"),
+		WithDefaultHeader("X-API-Key", "overridden"),
+	)
+	if _, err := client.GetCurrentUser(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ua := got.Get("User-Agent"); ua != "my-app/1.2.0 llm-copilot-sdk-go/"+Version {
+		t.Errorf("unexpected User-Agent %q", ua)
+	}
+	if got.Get("X-Tenant") != "acme" {
+		t.Errorf("expected default header, got %q", got.Get("X-Tenant"))
+	}
+	if got.Get("X-API-Key") != "key" {
+		t.Errorf("expected client headers to take precedence, got %q", got.Get("X-API-Key"))
+	}
+}
@@ -0,0 +1,105 @@
+package copilottest
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// serveEnvironments manages the environments runs bind, by name.
+func (s *FakeServer) serveEnvironments(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.EnvironmentCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		if err := req.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		env := &models.Environment{
+			Name:        req.Name,
+			Description: req.Description,
+			Variables:   req.Variables,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if env.Variables == nil {
+			env.Variables = map[string]string{}
+		}
+		s.mu.Lock()
+		if _, ok := s.environments[req.Name]; ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusConflict, "conflict", "environment already exists")
+			return
+		}
+		s.environments[env.Name] = env
+		resp := *env
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		envs := make([]models.Environment, 0, len(s.environments))
+		for _, env := range s.environments {
+			envs = append(envs, *env)
+		}
+		s.mu.Unlock()
+		sort.Slice(envs, func(i, j int) bool { return envs[i].Name < envs[j].Name })
+		writePage(w, r, envs)
+
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		var req models.EnvironmentUpdate
+		if !decode(w, r, &req) {
+			return
+		}
+		if err := req.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		s.mu.Lock()
+		env, ok := s.environments[parts[0]]
+		if !ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "not_found", "environment not found")
+			return
+		}
+		if req.Description != nil {
+			env.Description = *req.Description
+		}
+		if req.Variables != nil {
+			env.Variables = req.Variables
+		}
+		env.UpdatedAt = models.NewTimestamp(time.Now().UTC())
+		resp := *env
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, resp)
+
+	case len(parts) == 1 && (r.Method == http.MethodGet || r.Method == http.MethodDelete):
+		s.mu.Lock()
+		env, ok := s.environments[parts[0]]
+		var resp models.Environment
+		if ok {
+			resp = *env
+			if r.Method == http.MethodDelete {
+				delete(s.environments, parts[0])
+			}
+		}
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			writeError(w, http.StatusNotFound, "not_found", "environment not found")
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, resp)
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
@@ -0,0 +1,1371 @@
+package copilottest
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// Default credentials accepted by a FakeServer.
+const (
+	FakeAPIKey   = "test-api-key"
+	FakeUsername = "test-user"
+	FakePassword = "test-password"
+)
+
+// FakeServer is an in-memory implementation of the CoPilot API covering
+// authentication, conversations with streaming, workflows, context items
+// and realtime subscriptions. It is safe for concurrent use.
+type FakeServer struct {
+	*httptest.Server
+
+	// Responder produces the assistant's reply to a user message. It
+	// defaults to echoing the message. Set it before sending messages.
+	Responder func(conversationID models.ConversationID, content string) string
+	// RunHandler produces a workflow run's output. It defaults to echoing
+	// the input; returning an error fails the run.
+	RunHandler func(workflow *models.WorkflowDefinition, input map[string]interface{}) (map[string]interface{}, error)
+
+	mu            sync.Mutex
+	seq           int
+	user          models.User
+	tokens        map[string]bool
+	refreshTokens map[string]bool
+	conversations map[models.ConversationID]*models.Conversation
+	messages      map[models.ConversationID][]models.Message
+	pins          map[models.ConversationID][]models.PinnedMessage
+	drafts        map[models.ConversationID]models.Draft
+	settings      map[models.ConversationID]models.ConversationSettings
+	labels        map[string]*models.Label
+	prompts       map[string][]models.PromptTemplate
+	memories      map[string]map[string]models.Memory
+	workflows     map[models.WorkflowID]*models.WorkflowDefinition
+	runs          map[models.RunID]*models.WorkflowRun
+	runTokens     map[string]models.RunID
+	triggers      map[string]*models.Trigger
+	secrets       map[models.WorkflowID]map[string]*storedSecret
+	environments  map[string]*models.Environment
+	contextItems  map[string]*models.ContextItem
+	uploads       map[string]*pendingUpload
+	subscribers   map[*subscriber]bool
+	signingKey    string
+}
+
+// NewFakeServer starts a FakeServer. Close it when done.
+func NewFakeServer() *FakeServer {
+	s := &FakeServer{
+		Responder: func(_ models.ConversationID, content string) string { return "You said: " + content },
+		RunHandler: func(_ *models.WorkflowDefinition, input map[string]interface{}) (map[string]interface{}, error) {
+			return input, nil
+		},
+		user: models.User{
+			ID:            "user-1",
+			Username:      FakeUsername,
+			Email:         FakeUsername + "@example.com",
+			Roles:         []string{"user"},
+			IsActive:      true,
+			EmailVerified: true,
+			CreatedAt:     models.NewTimestamp(time.Now().UTC()),
+		},
+		tokens:        make(map[string]bool),
+		refreshTokens: make(map[string]bool),
+		conversations: make(map[models.ConversationID]*models.Conversation),
+		messages:      make(map[models.ConversationID][]models.Message),
+		pins:          make(map[models.ConversationID][]models.PinnedMessage),
+		drafts:        make(map[models.ConversationID]models.Draft),
+		settings:      make(map[models.ConversationID]models.ConversationSettings),
+		labels:        make(map[string]*models.Label),
+		prompts:       make(map[string][]models.PromptTemplate),
+		memories:      make(map[string]map[string]models.Memory),
+		workflows:     make(map[models.WorkflowID]*models.WorkflowDefinition),
+		runs:          make(map[models.RunID]*models.WorkflowRun),
+		runTokens:     make(map[string]models.RunID),
+		triggers:      make(map[string]*models.Trigger),
+		secrets:       make(map[models.WorkflowID]map[string]*storedSecret),
+		environments:  make(map[string]*models.Environment),
+		contextItems:  make(map[string]*models.ContextItem),
+		uploads:       make(map[string]*pendingUpload),
+		subscribers:   make(map[*subscriber]bool),
+		signingKey:    strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a client for the server authenticated with FakeAPIKey.
+func (s *FakeServer) Client(opts ...copilot.Option) *copilot.Client {
+	opts = append([]copilot.Option{copilot.WithAPIKey(FakeAPIKey)}, opts...)
+	return copilot.NewClient(s.URL, opts...)
+}
+
+// Messages returns a copy of the messages stored for a conversation.
+func (s *FakeServer) Messages(conversationID models.ConversationID) []models.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.Message(nil), s.messages[conversationID]...)
+}
+
+// newID returns a unique ID with the given prefix. s.mu must be held.
+func (s *FakeServer) newID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%d", prefix, s.seq)
+}
+
+func (s *FakeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, storagePrefix) {
+		s.serveStorage(w, r)
+		return
+	}
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "health" || path == "health/ready" || path == "health/live" {
+		writeJSON(w, http.StatusOK, models.HealthStatus{Status: models.HealthHealthy, Version: "fake"})
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, "api/v1/"), "/")
+	if parts[0] == "auth" && len(parts) == 2 && parts[1] != "me" {
+		s.serveAuth(w, r, parts[1])
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid credentials")
+		return
+	}
+
+	switch {
+	case parts[0] == "auth":
+		writeJSON(w, http.StatusOK, s.user)
+	case parts[0] == "conversations":
+		s.serveConversations(w, r, parts[1:])
+	case parts[0] == "workflows" && len(parts) > 1 && parts[1] == "runs":
+		s.serveRuns(w, r, parts[2:])
+	case parts[0] == "workflows":
+		s.serveWorkflows(w, r, parts[1:])
+	case parts[0] == "triggers":
+		s.serveTriggers(w, r, parts[1:])
+	case parts[0] == "environments":
+		s.serveEnvironments(w, r, parts[1:])
+	case parts[0] == "context":
+		s.serveContext(w, r, parts[1:])
+	case parts[0] == "labels":
+		s.serveLabels(w, r, parts[1:])
+	case parts[0] == "prompts":
+		s.servePrompts(w, r, parts[1:])
+	case parts[0] == "memory":
+		s.serveMemory(w, r, parts[1:])
+	case parts[0] == "messages" && len(parts) == 2 && parts[1] == "search":
+		s.serveSearch(w, r)
+	case parts[0] == "messages" && len(parts) == 3 && parts[2] == "thread":
+		s.serveThread(w, r, models.MessageID(parts[1]))
+	case parts[0] == "realtime":
+		s.serveRealtime(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// authorized checks the API key or bearer token.
+func (s *FakeServer) authorized(r *http.Request) bool {
+	if r.Header.Get("X-API-Key") == FakeAPIKey {
+		return true
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[token]
+}
+
+func (s *FakeServer) serveAuth(w http.ResponseWriter, r *http.Request, action string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use POST")
+		return
+	}
+
+	switch action {
+	case "login":
+		var req models.LoginRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if req.UsernameOrEmail != FakeUsername && req.UsernameOrEmail != s.user.Email || req.Password != FakePassword {
+			writeError(w, http.StatusUnauthorized, "invalid_credentials", "invalid username or password")
+			return
+		}
+		pair := s.issueTokens()
+		writeJSON(w, http.StatusOK, models.LoginResponse{
+			AccessToken:      pair.AccessToken,
+			RefreshToken:     pair.RefreshToken,
+			TokenType:        pair.TokenType,
+			ExpiresIn:        pair.ExpiresIn,
+			RefreshExpiresIn: pair.RefreshExpiresIn,
+			User:             s.user,
+		})
+	case "refresh":
+		var req map[string]string
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		valid := s.refreshTokens[req["refresh_token"]]
+		delete(s.refreshTokens, req["refresh_token"])
+		s.mu.Unlock()
+		if !valid {
+			writeError(w, http.StatusUnauthorized, "invalid_token", "invalid refresh token")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.issueTokens())
+	case "logout":
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.Lock()
+		delete(s.tokens, token)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+func (s *FakeServer) issueTokens() models.TokenPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pair := models.TokenPair{
+		AccessToken:      s.newID("access"),
+		RefreshToken:     s.newID("refresh"),
+		TokenType:        "bearer",
+		ExpiresIn:        3600,
+		RefreshExpiresIn: 86400,
+	}
+	s.tokens[pair.AccessToken] = true
+	s.refreshTokens[pair.RefreshToken] = true
+	return pair
+}
+
+func (s *FakeServer) serveConversations(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.ConversationCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		s.mu.Lock()
+		if req.PromptTemplate != nil {
+			if _, ok := s.findPrompt(req.PromptTemplate); !ok {
+				s.mu.Unlock()
+				writeError(w, http.StatusNotFound, "not_found", "prompt template not found")
+				return
+			}
+		}
+		conv := &models.Conversation{
+			ID:            models.ConversationID(s.newID("conv")),
+			Title:         req.Title,
+			SystemPrompt:  req.SystemPrompt,
+			UserID:        s.user.ID,
+			Metadata:      req.Metadata,
+			CorrelationID: req.CorrelationID,
+			HandoffStatus: models.HandoffStatusNone,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		s.conversations[conv.ID] = conv
+		resp := *conv
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		labels := querySet(r, "label")
+		s.mu.Lock()
+		convs := make([]models.Conversation, 0, len(s.conversations))
+		for _, conv := range s.conversations {
+			if hasLabels(conv, labels) {
+				convs = append(convs, *conv)
+			}
+		}
+		s.mu.Unlock()
+		sort.Slice(convs, func(i, j int) bool { return convs[i].CreatedAt.After(convs[j].CreatedAt.Time) })
+		writePage(w, r, convs)
+
+	case len(parts) == 1 && parts[0] == "bulk-delete" && r.Method == http.MethodPost:
+		s.serveBulkDelete(w, r, func(id string) bool {
+			if _, ok := s.conversations[models.ConversationID(id)]; !ok {
+				return false
+			}
+			delete(s.conversations, models.ConversationID(id))
+			delete(s.messages, models.ConversationID(id))
+			delete(s.pins, models.ConversationID(id))
+			delete(s.drafts, models.ConversationID(id))
+			delete(s.settings, models.ConversationID(id))
+			return true
+		})
+
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		var req struct {
+			SystemPrompt *string `json:"system_prompt"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		id := models.ConversationID(parts[0])
+		s.mu.Lock()
+		conv, ok := s.conversations[id]
+		var resp models.Conversation
+		if ok {
+			if req.SystemPrompt != nil {
+				conv.SystemPrompt = *req.SystemPrompt
+			}
+			conv.UpdatedAt = models.NewTimestamp(time.Now().UTC())
+			resp = *conv
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+			return
+		}
+		s.publish(models.ChangeEvent{Type: models.ChangeConversationUpdated, ConversationID: id, Conversation: &resp})
+		writeJSON(w, http.StatusOK, resp)
+
+	case len(parts) == 1:
+		s.mu.Lock()
+		id := models.ConversationID(parts[0])
+		conv, ok := s.conversations[id]
+		if ok && r.Method == http.MethodDelete {
+			delete(s.conversations, id)
+			delete(s.messages, id)
+			delete(s.pins, id)
+			delete(s.drafts, id)
+			delete(s.settings, id)
+		}
+		var resp models.ConversationWithMessages
+		if ok {
+			resp.Conversation = *conv
+		}
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("include") == "messages":
+			resp.Messages = append([]models.Message{}, s.Messages(id)...)
+			if limit, _ := strconv.Atoi(r.URL.Query().Get("message_limit")); limit > 0 && limit < len(resp.Messages) {
+				resp.Messages = resp.Messages[:limit]
+			}
+			writeJSON(w, http.StatusOK, resp)
+		default:
+			writeJSON(w, http.StatusOK, resp.Conversation)
+		}
+
+	case len(parts) == 2 && parts[1] == "typing" && r.Method == http.MethodPost:
+		var req struct {
+			Typing bool `json:"typing"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		id := models.ConversationID(parts[0])
+		s.mu.Lock()
+		_, ok := s.conversations[id]
+		presence := s.presence(true, req.Typing)
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+			return
+		}
+		s.publish(models.ChangeEvent{Type: models.ChangeTyping, ConversationID: id, Presence: &presence})
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(parts) == 2 && parts[1] == "settings":
+		s.serveSettings(w, r, models.ConversationID(parts[0]))
+
+	case len(parts) == 2 && parts[1] == "draft":
+		s.serveDraft(w, r, models.ConversationID(parts[0]))
+
+	case len(parts) >= 2 && parts[1] == "labels":
+		s.serveConversationLabels(w, r, models.ConversationID(parts[0]), parts[2:])
+
+	case len(parts) >= 2 && parts[1] == "pins":
+		s.servePins(w, r, models.ConversationID(parts[0]), parts[2:])
+
+	case len(parts) >= 2 && parts[1] == "messages":
+		s.serveMessages(w, r, models.ConversationID(parts[0]), len(parts) == 3 && parts[2] == "stream")
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+func (s *FakeServer) serveMessages(w http.ResponseWriter, r *http.Request, conversationID models.ConversationID, stream bool) {
+	s.mu.Lock()
+	_, ok := s.conversations[conversationID]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+		return
+	}
+
+	if r.Method == http.MethodGet && !stream {
+		// Thread replies stay out of the main conversation.
+		var main []models.Message
+		for _, msg := range s.Messages(conversationID) {
+			if msg.ParentMessageID == "" {
+				main = append(main, msg)
+			}
+		}
+		msgs, err := filterMessages(main, r.URL.Query())
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		writePage(w, r, msgs)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use POST")
+		return
+	}
+
+	var req models.MessageCreate
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.ParentMessageID != "" {
+		if parent, ok := s.findMessage(req.ParentMessageID); !ok || parent.ConversationID != conversationID {
+			writeError(w, http.StatusNotFound, "not_found", "parent message not found")
+			return
+		}
+	}
+	reply := s.Responder(conversationID, req.Content)
+
+	s.mu.Lock()
+	now := models.NewTimestamp(time.Now().UTC())
+	user := models.Message{
+		ID:              models.MessageID(s.newID("msg")),
+		ConversationID:  conversationID,
+		Role:            models.RoleUser,
+		Content:         req.Content,
+		Parts:           req.Parts,
+		Metadata:        req.Metadata,
+		CorrelationID:   req.CorrelationID,
+		CreatedAt:       now,
+		ParentMessageID: req.ParentMessageID,
+	}
+	assistant := models.Message{
+		ID:              models.MessageID(s.newID("msg")),
+		ConversationID:  conversationID,
+		Role:            models.RoleAssistant,
+		Content:         reply,
+		CorrelationID:   req.CorrelationID,
+		CreatedAt:       now,
+		ParentMessageID: req.ParentMessageID,
+	}
+	if req.Retrieval != nil {
+		assistant.RetrievedContext = s.retrieve(conversationID, req.Retrieval, req.Content)
+	}
+	if req.Memory != nil {
+		// Recalled memories are reported in the reply's metadata.
+		assistant.Metadata = map[string]interface{}{"recalled_memories": s.recall(req.Memory, req.Content)}
+	}
+	s.messages[conversationID] = append(s.messages[conversationID], user, assistant)
+	conv := s.conversations[conversationID]
+	conv.MessageCount += 2
+	conv.UpdatedAt = now
+	s.mu.Unlock()
+
+	for _, msg := range []models.Message{user, assistant} {
+		msg := msg
+		s.publish(models.ChangeEvent{Type: models.ChangeMessageCreated, ConversationID: conversationID, Message: &msg})
+	}
+
+	if !stream {
+		writeJSON(w, http.StatusCreated, assistant)
+		return
+	}
+	writeStream(w, assistant)
+}
+
+// serveDraft saves, returns and deletes a conversation's draft.
+func (s *FakeServer) serveDraft(w http.ResponseWriter, r *http.Request, conversationID models.ConversationID) {
+	s.mu.Lock()
+	_, ok := s.conversations[conversationID]
+	draft, hasDraft := s.drafts[conversationID]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var req models.DraftSave
+		if !decode(w, r, &req) {
+			return
+		}
+		draft = models.Draft{
+			ConversationID:  conversationID,
+			Content:         req.Content,
+			Parts:           req.Parts,
+			ParentMessageID: req.ParentMessageID,
+			UpdatedAt:       models.NewTimestamp(time.Now().UTC()),
+		}
+		s.mu.Lock()
+		s.drafts[conversationID] = draft
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, draft)
+	case http.MethodGet:
+		if !hasDraft {
+			writeError(w, http.StatusNotFound, "not_found", "no draft saved")
+			return
+		}
+		writeJSON(w, http.StatusOK, draft)
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.drafts, conversationID)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use GET, PUT or DELETE")
+	}
+}
+
+// serveSettings returns and updates a conversation's settings.
+func (s *FakeServer) serveSettings(w http.ResponseWriter, r *http.Request, conversationID models.ConversationID) {
+	var req models.ConversationSettingsUpdate
+	if r.Method == http.MethodPatch && !decode(w, r, &req) {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPatch {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use GET or PATCH")
+		return
+	}
+	if t := req.Temperature; t != nil && (*t < 0 || *t > 2) {
+		writeError(w, http.StatusBadRequest, "invalid_request", "temperature must be between 0 and 2")
+		return
+	}
+	if scope := req.ContextScope; scope != nil {
+		switch *scope {
+		case models.ContextScopeAll, models.ContextScopeSelected, models.ContextScopeNone:
+		default:
+			writeError(w, http.StatusBadRequest, "invalid_request", "unknown context scope")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	_, ok := s.conversations[conversationID]
+	settings, hasSettings := s.settings[conversationID]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+		return
+	}
+	if !hasSettings {
+		temperature := 0.7
+		settings = models.ConversationSettings{
+			ConversationID: conversationID,
+			Model:          "copilot-default",
+			Temperature:    &temperature,
+			ToolsEnabled:   true,
+			ContextScope:   models.ContextScopeAll,
+		}
+	}
+	if r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, settings)
+		return
+	}
+
+	if req.Model != nil {
+		settings.Model = *req.Model
+	}
+	if req.Temperature != nil {
+		settings.Temperature = req.Temperature
+	}
+	if req.MaxTokens != nil {
+		settings.MaxTokens = *req.MaxTokens
+	}
+	if req.ToolsEnabled != nil {
+		settings.ToolsEnabled = *req.ToolsEnabled
+	}
+	if req.AllowedTools != nil {
+		settings.AllowedTools = *req.AllowedTools
+	}
+	if req.ContextScope != nil {
+		settings.ContextScope = *req.ContextScope
+	}
+	if req.ContextItemIDs != nil {
+		settings.ContextItemIDs = *req.ContextItemIDs
+	}
+	settings.UpdatedAt = models.NewTimestamp(time.Now().UTC())
+	s.mu.Lock()
+	s.settings[conversationID] = settings
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, settings)
+}
+
+// servePins pins, unpins and lists a conversation's pinned messages.
+func (s *FakeServer) servePins(w http.ResponseWriter, r *http.Request, conversationID models.ConversationID, parts []string) {
+	s.mu.Lock()
+	_, ok := s.conversations[conversationID]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+		return
+	}
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.PinCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		msg, ok := s.findMessage(req.MessageID)
+		if !ok || msg.ConversationID != conversationID {
+			writeError(w, http.StatusNotFound, "not_found", "message not found")
+			return
+		}
+		pin := models.PinnedMessage{
+			Message:          msg,
+			IncludeInContext: req.IncludeInContext,
+			PinnedAt:         models.NewTimestamp(time.Now().UTC()),
+		}
+		s.mu.Lock()
+		pin.PinnedBy = s.user.ID
+		pins := []models.PinnedMessage{pin}
+		for _, p := range s.pins[conversationID] {
+			if p.Message.ID != msg.ID {
+				pins = append(pins, p)
+			}
+		}
+		s.pins[conversationID] = pins
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, pin)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		pins := append([]models.PinnedMessage{}, s.pins[conversationID]...)
+		s.mu.Unlock()
+		writePage(w, r, pins)
+
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.mu.Lock()
+		var pins []models.PinnedMessage
+		for _, p := range s.pins[conversationID] {
+			if p.Message.ID != models.MessageID(parts[0]) {
+				pins = append(pins, p)
+			}
+		}
+		found := len(pins) < len(s.pins[conversationID])
+		s.pins[conversationID] = pins
+		s.mu.Unlock()
+		if !found {
+			writeError(w, http.StatusNotFound, "not_found", "message is not pinned")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// serveLabels creates, lists, updates and deletes labels.
+func (s *FakeServer) serveLabels(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.LabelCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		if req.Name == "" {
+			writeError(w, http.StatusBadRequest, "invalid_request", "name is required")
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		s.mu.Lock()
+		if _, ok := s.labels[req.ParentID]; req.ParentID != "" && !ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "not_found", "parent label not found")
+			return
+		}
+		label := &models.Label{
+			ID:        s.newID("label"),
+			Name:      req.Name,
+			Color:     req.Color,
+			ParentID:  req.ParentID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		s.labels[label.ID] = label
+		resp := *label
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		labels := make([]models.Label, 0, len(s.labels))
+		for _, label := range s.labels {
+			labels = append(labels, *label)
+		}
+		s.mu.Unlock()
+		sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+		writePage(w, r, labels)
+
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		var req models.LabelUpdate
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		label, ok := s.labels[parts[0]]
+		if !ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "not_found", "label not found")
+			return
+		}
+		if p := req.ParentID; p != nil && *p != "" {
+			if _, ok := s.labels[*p]; !ok || *p == label.ID {
+				s.mu.Unlock()
+				writeError(w, http.StatusBadRequest, "invalid_request", "invalid parent label")
+				return
+			}
+		}
+		if req.Name != nil {
+			label.Name = *req.Name
+		}
+		if req.Color != nil {
+			label.Color = *req.Color
+		}
+		if req.ParentID != nil {
+			label.ParentID = *req.ParentID
+		}
+		label.UpdatedAt = models.NewTimestamp(time.Now().UTC())
+		resp := *label
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, resp)
+
+	case len(parts) == 1:
+		s.mu.Lock()
+		label, ok := s.labels[parts[0]]
+		if ok && r.Method == http.MethodDelete {
+			delete(s.labels, label.ID)
+			// Nested labels move up to the deleted label's parent.
+			for _, l := range s.labels {
+				if l.ParentID == label.ID {
+					l.ParentID = label.ParentID
+				}
+			}
+			for _, conv := range s.conversations {
+				conv.Labels = removeLabel(conv.Labels, label.ID)
+			}
+		}
+		var resp models.Label
+		if ok {
+			resp = *label
+		}
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			writeError(w, http.StatusNotFound, "not_found", "label not found")
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, resp)
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// serveConversationLabels adds labels to and removes them from a
+// conversation.
+func (s *FakeServer) serveConversationLabels(w http.ResponseWriter, r *http.Request, conversationID models.ConversationID, parts []string) {
+	var labelID string
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req struct {
+			LabelID string `json:"label_id"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		labelID = req.LabelID
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		labelID = parts[0]
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+		return
+	}
+
+	s.mu.Lock()
+	conv, ok := s.conversations[conversationID]
+	_, labelOK := s.labels[labelID]
+	if ok && labelOK {
+		conv.Labels = removeLabel(conv.Labels, labelID)
+		if r.Method == http.MethodPost {
+			conv.Labels = append(conv.Labels, labelID)
+		}
+		conv.UpdatedAt = models.NewTimestamp(time.Now().UTC())
+	}
+	var resp models.Conversation
+	if ok {
+		resp = *conv
+		resp.Labels = append([]string(nil), conv.Labels...)
+	}
+	s.mu.Unlock()
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, "not_found", "conversation not found")
+		return
+	case !labelOK:
+		writeError(w, http.StatusNotFound, "not_found", "label not found")
+		return
+	}
+
+	s.publish(models.ChangeEvent{Type: models.ChangeConversationUpdated, ConversationID: conversationID, Conversation: &resp})
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// hasLabels reports whether conv carries every label in the set.
+func hasLabels(conv *models.Conversation, labels map[string]bool) bool {
+	for label := range labels {
+		found := false
+		for _, l := range conv.Labels {
+			if l == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// removeLabel returns labels without id, in a new slice.
+func removeLabel(labels []string, id string) []string {
+	var out []string
+	for _, l := range labels {
+		if l != id {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// serveThread lists the replies to a message.
+func (s *FakeServer) serveThread(w http.ResponseWriter, r *http.Request, messageID models.MessageID) {
+	parent, ok := s.findMessage(messageID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "message not found")
+		return
+	}
+	replies := []models.Message{}
+	for _, msg := range s.Messages(parent.ConversationID) {
+		if msg.ParentMessageID == messageID {
+			replies = append(replies, msg)
+		}
+	}
+	writePage(w, r, replies)
+}
+
+// findMessage looks a message up by ID across conversations.
+func (s *FakeServer) findMessage(id models.MessageID) (models.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, msgs := range s.messages {
+		for _, msg := range msgs {
+			if msg.ID == id {
+				return msg, true
+			}
+		}
+	}
+	return models.Message{}, false
+}
+
+// writeStream sends a message as server-sent events, one delta per word.
+func writeStream(w http.ResponseWriter, msg models.Message) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher, _ := w.(http.Flusher)
+
+	id := 0
+	send := func(event map[string]interface{}) {
+		id++
+		event["message_id"] = msg.ID
+		data, _ := json.Marshal(event)
+		fmt.Fprintf(w, "id: %d\ndata: %s\n\n", id, data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	send(map[string]interface{}{"type": "message_start"})
+	words := strings.SplitAfter(msg.Content, " ")
+	for i, word := range words {
+		send(map[string]interface{}{"type": "content_delta", "index": i, "delta": map[string]string{"text": word}})
+	}
+	send(map[string]interface{}{"type": "message_end"})
+}
+
+func (s *FakeServer) serveWorkflows(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.WorkflowDefinitionCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		s.mu.Lock()
+		wf := &models.WorkflowDefinition{
+			ID:           models.WorkflowID(s.newID("wf")),
+			Name:         req.Name,
+			Description:  req.Description,
+			Version:      req.Version,
+			Steps:        req.Steps,
+			EntryPoint:   req.EntryPoint,
+			Metadata:     req.Metadata,
+			InputSchema:  req.InputSchema,
+			OutputSchema: req.OutputSchema,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if wf.Version == "" {
+			wf.Version = "1.0.0"
+		}
+		s.workflows[wf.ID] = wf
+		resp := *wf
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		wfs := make([]models.WorkflowDefinition, 0, len(s.workflows))
+		for _, wf := range s.workflows {
+			wfs = append(wfs, *wf)
+		}
+		s.mu.Unlock()
+		sort.Slice(wfs, func(i, j int) bool { return wfs[i].ID < wfs[j].ID })
+		writePage(w, r, wfs)
+
+	case len(parts) == 2 && parts[1] == "simulate" && r.Method == http.MethodPost:
+		var req models.WorkflowSimulateRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		wf, ok := s.workflows[models.WorkflowID(parts[0])]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "workflow not found")
+			return
+		}
+		if validInput(w, wf, req.InputData) {
+			writeJSON(w, http.StatusOK, simulate(wf, req.InputData))
+		}
+
+	case len(parts) >= 2 && parts[1] == "secrets":
+		s.serveSecrets(w, r, models.WorkflowID(parts[0]), parts[2:])
+
+	case len(parts) == 1:
+		s.mu.Lock()
+		id := models.WorkflowID(parts[0])
+		wf, ok := s.workflows[id]
+		if ok && r.Method == http.MethodDelete {
+			delete(s.workflows, id)
+			delete(s.secrets, id)
+		}
+		var resp models.WorkflowDefinition
+		if ok {
+			resp = *wf
+		}
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			writeError(w, http.StatusNotFound, "not_found", "workflow not found")
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, resp)
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// validInput checks input against the input schema of wf, writing a
+// validation error if it does not match.
+func validInput(w http.ResponseWriter, wf *models.WorkflowDefinition, input map[string]interface{}) bool {
+	var schemaErr *models.SchemaError
+	if err := wf.ValidateInput(input); !errors.As(err, &schemaErr) {
+		return true
+	}
+	apiErr := models.APIError{Code: "validation_error", Message: "input_data does not match the workflow's input schema"}
+	for _, fe := range schemaErr.Violations {
+		fe.Field = strings.TrimSuffix("input_data."+fe.Field, ".")
+		apiErr.FieldErrors = append(apiErr.FieldErrors, fe)
+	}
+	writeJSON(w, http.StatusUnprocessableEntity, apiErr)
+	return false
+}
+
+// tokenRun returns the run started with a client token. s.mu must be held.
+func (s *FakeServer) tokenRun(token string) (models.WorkflowRun, bool) {
+	id, ok := s.runTokens[token]
+	if !ok || token == "" {
+		return models.WorkflowRun{}, false
+	}
+	return *s.runs[id], true
+}
+
+func (s *FakeServer) serveRuns(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.WorkflowRunCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		if existing, ok := s.tokenRun(req.ClientToken); ok {
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, existing)
+			return
+		}
+		wf, ok := s.workflows[req.WorkflowID]
+		_, envOK := s.environments[req.Environment]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "workflow not found")
+			return
+		}
+		if req.Environment != "" && !envOK {
+			writeError(w, http.StatusNotFound, "not_found", "environment not found")
+			return
+		}
+		if !validInput(w, wf, req.InputData) {
+			return
+		}
+
+		// Runs execute synchronously, so they are finished when returned.
+		start := time.Now()
+		output, err := s.RunHandler(wf, req.InputData)
+		if err == nil {
+			err = wf.ValidateOutput(output)
+		}
+		// Runs have no cost, so only the timeout is enforced.
+		if timeout := time.Duration(req.TimeoutSeconds) * time.Second; err == nil && timeout > 0 && time.Since(start) > timeout {
+			output, err = nil, fmt.Errorf("run timed out after %s", timeout)
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		run := &models.WorkflowRun{
+			WorkflowID:     wf.ID,
+			Status:         models.WorkflowStatusCompleted,
+			InputData:      req.InputData,
+			OutputData:     output,
+			CorrelationID:  req.CorrelationID,
+			ConcurrencyKey: req.ConcurrencyKey,
+			Environment:    req.Environment,
+			StartedAt:      models.NewTimestamp(start.UTC()),
+			CompletedAt:    &now,
+			Metrics:        &models.Metrics{DurationMS: time.Since(start).Milliseconds()},
+		}
+		if err != nil {
+			run.Status = models.WorkflowStatusFailed
+			run.Error = err.Error()
+		}
+		s.mu.Lock()
+		// A concurrent request with the same token may have won the race.
+		if existing, ok := s.tokenRun(req.ClientToken); ok {
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, existing)
+			return
+		}
+		run.ID = models.RunID(s.newID("run"))
+		s.runs[run.ID] = run
+		if req.ClientToken != "" {
+			s.runTokens[req.ClientToken] = run.ID
+		}
+		resp := *run
+		s.mu.Unlock()
+		s.publish(models.ChangeEvent{Type: models.ChangeRunStatusChanged, RunID: resp.ID, Run: &resp})
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		workflowID := models.WorkflowID(r.URL.Query().Get("workflow_id"))
+		s.mu.Lock()
+		runs := make([]models.WorkflowRun, 0, len(s.runs))
+		for _, run := range s.runs {
+			if workflowID == "" || run.WorkflowID == workflowID {
+				runs = append(runs, *run)
+			}
+		}
+		s.mu.Unlock()
+		sort.Slice(runs, func(i, j int) bool { return runs[i].ID < runs[j].ID })
+		writePage(w, r, runs)
+
+	case len(parts) >= 1:
+		cancel := len(parts) == 2 && parts[1] == "cancel" && r.Method == http.MethodPost
+		var opts models.CancelOptions
+		if cancel && r.ContentLength != 0 && !decode(w, r, &opts) {
+			return
+		}
+		s.mu.Lock()
+		run, ok := s.runs[models.RunID(parts[0])]
+		cancelled := false
+		if ok && cancel {
+			// Runs have no steps in flight, so the grace period is moot.
+			if run.Status == models.WorkflowStatusPending || run.Status == models.WorkflowStatusRunning {
+				now := models.NewTimestamp(time.Now().UTC())
+				run.Status = models.WorkflowStatusCancelled
+				run.CancelReason = opts.Reason
+				run.CompletedAt = &now
+				cancelled = true
+			}
+		}
+		var resp models.WorkflowRun
+		if ok {
+			resp = *run
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "run not found")
+			return
+		}
+		if cancelled {
+			s.publish(models.ChangeEvent{Type: models.ChangeRunStatusChanged, RunID: resp.ID, Run: &resp})
+		}
+		writeJSON(w, http.StatusOK, resp)
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+func (s *FakeServer) serveContext(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.ContextItemCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		item := &models.ContextItem{
+			ID:        s.newID("ctx"),
+			Type:      req.Type,
+			Name:      req.Name,
+			Content:   req.Content,
+			URL:       req.URL,
+			Metadata:  req.Metadata,
+			Tags:      req.Tags,
+			CreatedAt: models.NewTimestamp(time.Now().UTC()),
+		}
+		s.contextItems[item.ID] = item
+		resp := *item
+		s.mu.Unlock()
+		s.publish(models.ChangeEvent{Type: models.ChangeContextUpdated, ContextItem: &resp})
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		items := make([]models.ContextItem, 0, len(s.contextItems))
+		for _, item := range s.contextItems {
+			items = append(items, *item)
+		}
+		s.mu.Unlock()
+		sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+		writePage(w, r, items)
+
+	case len(parts) == 1 && parts[0] == "bulk-delete" && r.Method == http.MethodPost:
+		s.serveBulkDelete(w, r, func(id string) bool {
+			if _, ok := s.contextItems[id]; !ok {
+				return false
+			}
+			delete(s.contextItems, id)
+			return true
+		})
+
+	case len(parts) >= 1 && parts[0] == "uploads":
+		s.serveUploads(w, r, parts[1:])
+
+	case len(parts) == 2 && parts[1] == "download-url" && r.Method == http.MethodGet:
+		s.mu.Lock()
+		_, ok := s.contextItems[parts[0]]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "context item not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.presign(http.MethodGet, "context/"+parts[0]))
+
+	case len(parts) == 2 && parts[1] == "content" && r.Method == http.MethodGet:
+		s.mu.Lock()
+		item, ok := s.contextItems[parts[0]]
+		var content string
+		var modified time.Time
+		if ok {
+			content, modified = item.Content, item.CreatedAt.Time
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "context item not found")
+			return
+		}
+		sum := sha256.Sum256([]byte(content))
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:8])+`"`)
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, "", modified, strings.NewReader(content))
+
+	case len(parts) == 1:
+		s.mu.Lock()
+		item, ok := s.contextItems[parts[0]]
+		if ok && r.Method == http.MethodDelete {
+			delete(s.contextItems, parts[0])
+		}
+		var resp models.ContextItem
+		if ok {
+			resp = *item
+		}
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			writeError(w, http.StatusNotFound, "not_found", "context item not found")
+		case r.Method == http.MethodDelete:
+			s.publish(models.ChangeEvent{Type: models.ChangeContextDeleted, ContextItem: &resp})
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, resp)
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// serveBulkDelete deletes each requested ID with remove, which is called
+// with s.mu held and reports whether the ID existed.
+func (s *FakeServer) serveBulkDelete(w http.ResponseWriter, r *http.Request, remove func(id string) bool) {
+	var req models.BulkDeleteRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	resp := models.BulkDeleteResponse{Results: make([]models.BulkDeleteResult, 0, len(req.IDs))}
+	s.mu.Lock()
+	for _, id := range req.IDs {
+		result := models.BulkDeleteResult{ID: id, Deleted: remove(id)}
+		if !result.Deleted {
+			result.Error = &models.APIError{Code: "not_found", Message: "resource not found"}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// filterMessages applies the since, until and order parameters.
+func filterMessages(msgs []models.Message, query url.Values) ([]models.Message, error) {
+	var since, until time.Time
+	for name, bound := range map[string]*time.Time{"since": &since, "until": &until} {
+		if v := query.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*bound = t
+		}
+	}
+
+	filtered := msgs[:0]
+	for _, msg := range msgs {
+		if !since.IsZero() && !msg.CreatedAt.After(since) {
+			continue
+		}
+		if !until.IsZero() && !msg.CreatedAt.Before(until) {
+			continue
+		}
+		filtered = append(filtered, msg)
+	}
+	if query.Get("order") == string(models.OrderDesc) {
+		for i, j := 0, len(filtered)-1; i < j; i, j = i+1, j-1 {
+			filtered[i], filtered[j] = filtered[j], filtered[i]
+		}
+	}
+	return filtered, nil
+}
+
+// writePage writes items as a paginated response honoring limit and either
+// cursor or offset. Cursors are opaque to clients but encode the offset.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	total := len(items)
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if cursor := query.Get("cursor"); cursor != "" {
+		offset, _ = strconv.Atoi(strings.TrimPrefix(cursor, "c"))
+	}
+	if offset > total {
+		offset = total
+	}
+	if offset > 0 {
+		items = items[offset:]
+	}
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	page := models.PaginatedResponse[T]{
+		Items:    items,
+		Total:    total,
+		PageSize: len(items),
+		HasMore:  offset+len(items) < total,
+	}
+	if page.HasMore {
+		page.NextCursor = "c" + strconv.Itoa(offset+len(items))
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return false
+		}
+		body = zr
+	}
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, models.APIError{Code: code, Message: message})
+}
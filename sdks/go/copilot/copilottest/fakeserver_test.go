@@ -0,0 +1,1062 @@
+package copilottest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestFakeServerConversations(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	server.Responder = func(_ copilot.ConversationID, content string) string { return "Echo: " + strings.ToUpper(content) }
+
+	client := server.Client()
+	ctx := context.Background()
+
+	conv, err := client.CreateConversation(ctx, &copilot.ConversationCreate{Title: "Test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg, err := client.SendMessage(ctx, conv.ID, "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Role != copilot.RoleAssistant || msg.Content != "Echo: HELLO" {
+		t.Errorf("unexpected reply %+v", msg)
+	}
+
+	stream, err := client.StreamMessage(ctx, conv.ID, "stream me please")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := stream.CollectContent(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "Echo: STREAM ME PLEASE" {
+		t.Errorf("unexpected streamed content %q", content)
+	}
+
+	page, err := client.ListMessages(ctx, conv.ID, &copilot.MessageListOptions{ListOptions: copilot.ListOptions{Limit: 10}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 4 {
+		t.Errorf("expected 4 stored messages, got %d", len(page.Items))
+	}
+
+	pager := copilot.NewPager(func(ctx context.Context, opts *copilot.ListOptions) (*models.PaginatedResponse[models.Message], error) {
+		return client.ListMessages(ctx, conv.ID, &copilot.MessageListOptions{ListOptions: *opts})
+	}, &copilot.ListOptions{Limit: 3})
+	msgs, err := pager.All(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 4 || msgs[3].ID != page.Items[3].ID {
+		t.Errorf("expected pager to return all 4 messages in order, got %d", len(msgs))
+	}
+
+	if err := client.DeleteConversation(ctx, conv.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var apiErr *copilot.CoPilotError
+	if _, err := client.GetConversation(ctx, conv.ID); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+}
+
+func TestFakeServerAuthAndWorkflows(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	client := copilot.NewClient(server.URL, copilot.WithMaxRetries(0))
+	if _, err := client.GetCurrentUser(ctx); err == nil {
+		t.Error("expected unauthenticated request to fail")
+	}
+	if _, err := client.Login(ctx, FakeUsername, FakePassword); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user, err := client.GetCurrentUser(ctx); err != nil || user.Username != FakeUsername {
+		t.Fatalf("expected logged-in user, got %+v, %v", user, err)
+	}
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{Name: "echo", EntryPoint: "start"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	run, err := client.RunWorkflow(ctx, &copilot.WorkflowRunCreate{
+		WorkflowID: wf.ID,
+		InputData:  map[string]interface{}{"x": "y"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Status != copilot.WorkflowStatusCompleted || run.OutputData["x"] != "y" {
+		t.Errorf("unexpected run %+v", run)
+	}
+
+	item, err := client.CreateContextItem(ctx, &copilot.ContextItemCreate{Type: copilot.ContextTypeText, Name: "note", Content: "hi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items, err := client.ListContextItems(ctx, nil); err != nil || len(items.Items) != 1 || items.Items[0].ID != item.ID {
+		t.Errorf("expected one context item, got %+v, %v", items, err)
+	}
+
+	resp, err := client.DeleteContextItems(ctx, []string{item.ID, "ctx-missing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failed := resp.Failed(); len(failed) != 1 || failed[0].ID != "ctx-missing" {
+		t.Errorf("expected only the missing item to fail, got %+v", resp.Results)
+	}
+}
+
+func TestFakeServerRealtime(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := server.Client()
+
+	conv, err := client.CreateConversation(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub, err := client.Subscribe(ctx, copilot.SubscribeOptions{ConversationIDs: []copilot.ConversationID{conv.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sub.Close()
+	all, err := client.Subscribe(ctx, copilot.SubscribeOptions{EventTypes: []copilot.ChangeEventType{copilot.ChangeContextUpdated}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer all.Close()
+
+	// Wait for both subscriptions to register before changing anything.
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		server.mu.Lock()
+		n := len(server.subscribers)
+		server.mu.Unlock()
+		if n == 2 || time.Now().After(deadline) {
+			break
+		}
+	}
+
+	if event := <-sub.Events(); event.Type != copilot.ChangePresence || event.Presence == nil || !event.Presence.Active {
+		t.Errorf("expected presence event on joining, got %+v", event)
+	}
+	if err := client.SetTyping(ctx, conv.ID, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event := <-sub.Events(); event.Type != copilot.ChangeTyping || event.Presence == nil || !event.Presence.Typing || event.Presence.UserID == "" {
+		t.Errorf("expected typing event, got %+v", event)
+	}
+
+	if _, err := client.CreateContextItem(ctx, &copilot.ContextItemCreate{Type: copilot.ContextTypeText, Name: "note"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SendMessage(ctx, conv.ID, "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []copilot.MessageRole{copilot.RoleUser, copilot.RoleAssistant} {
+		event := <-sub.Events()
+		if event.Type != copilot.ChangeMessageCreated || event.ConversationID != conv.ID || event.Message == nil || event.Message.Role != want {
+			t.Errorf("expected %s message.created event, got %+v", want, event)
+		}
+	}
+	if event := <-all.Events(); event.Type != copilot.ChangeContextUpdated || event.ContextItem == nil || event.ContextItem.Name != "note" {
+		t.Errorf("expected context.updated event, got %+v", event)
+	}
+
+	sub.Close()
+	if _, ok := <-sub.Events(); ok {
+		t.Error("expected no further events after Close")
+	}
+}
+
+func TestFakeServerThreads(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	conv, err := client.CreateConversation(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	answer, err := client.SendMessage(ctx, conv.ID, "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reply, err := client.ReplyInThread(ctx, conv.ID, answer.ID, "why?")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reply.ParentMessageID != answer.ID {
+		t.Errorf("expected reply in thread of %s, got %q", answer.ID, reply.ParentMessageID)
+	}
+
+	thread, err := client.ListThread(ctx, answer.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(thread) != 2 || thread[0].Content != "why?" || thread[1].ID != reply.ID {
+		t.Errorf("unexpected thread %+v", thread)
+	}
+
+	page, err := client.ListMessages(ctx, conv.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Errorf("expected thread replies to stay out of the conversation, got %d messages", len(page.Items))
+	}
+
+	_, err = client.ReplyInThread(ctx, conv.ID, "msg-missing", "hi")
+	var apiErr *copilot.CoPilotError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected not found for a missing parent, got %v", err)
+	}
+}
+
+func TestFakeServerPins(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	conv, err := client.CreateConversation(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, _ := client.SendMessage(ctx, conv.ID, "always answer in French")
+	second, _ := client.SendMessage(ctx, conv.ID, "hello")
+
+	pin, err := client.PinMessage(ctx, conv.ID, first.ID, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pin.Message.ID != first.ID || !pin.IncludeInContext || pin.PinnedBy == "" {
+		t.Errorf("unexpected pin %+v", pin)
+	}
+	if _, err := client.PinMessage(ctx, conv.ID, second.ID, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pins, err := client.ListPinnedMessages(ctx, conv.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pins) != 2 || pins[0].Message.ID != second.ID || pins[1].Message.ID != first.ID {
+		t.Errorf("expected most recent pin first, got %+v", pins)
+	}
+
+	if err := client.UnpinMessage(ctx, conv.ID, second.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pins, _ := client.ListPinnedMessages(ctx, conv.ID); len(pins) != 1 || pins[0].Message.ID != first.ID {
+		t.Errorf("expected one pin left, got %+v", pins)
+	}
+	if err := client.UnpinMessage(ctx, conv.ID, second.ID); err == nil {
+		t.Error("expected unpinning twice to fail")
+	}
+}
+
+func TestFakeServerDrafts(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	conv, err := server.Client().CreateConversation(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Drafts saved on one device are visible on another.
+	laptop, phone := server.Client(), server.Client()
+	if draft, err := phone.GetDraft(ctx, conv.ID); err != nil || draft != nil {
+		t.Fatalf("expected no draft, got %+v, %v", draft, err)
+	}
+	if _, err := laptop.SaveDraft(ctx, conv.ID, copilot.DraftSave{Content: "half a thought"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	draft, err := phone.GetDraft(ctx, conv.ID)
+	if err != nil || draft == nil || draft.Content != "half a thought" || draft.UpdatedAt.IsZero() {
+		t.Fatalf("expected synced draft, got %+v, %v", draft, err)
+	}
+
+	if err := phone.DeleteDraft(ctx, conv.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draft, err := laptop.GetDraft(ctx, conv.ID); err != nil || draft != nil {
+		t.Errorf("expected draft to be deleted, got %+v, %v", draft, err)
+	}
+}
+
+func TestFakeServerLabels(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	work, err := client.CreateLabel(ctx, copilot.LabelCreate{Name: "work"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	urgent, err := client.CreateLabel(ctx, copilot.LabelCreate{Name: "urgent", Color: "#ff0000", ParentID: work.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if urgent.ParentID != work.ID {
+		t.Errorf("expected nested label, got %+v", urgent)
+	}
+
+	a, _ := client.CreateConversation(ctx, nil)
+	b, _ := client.CreateConversation(ctx, nil)
+	conv, err := client.AddLabel(ctx, a.ID, work.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conv.Labels) != 1 || conv.Labels[0] != work.ID {
+		t.Errorf("unexpected labels %v", conv.Labels)
+	}
+	client.AddLabel(ctx, a.ID, urgent.ID)
+	client.AddLabel(ctx, b.ID, work.ID)
+
+	page, err := client.ListConversationsByLabel(ctx, []string{work.ID, urgent.ID}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != a.ID {
+		t.Errorf("expected only %s, got %+v", a.ID, page.Items)
+	}
+
+	if err := client.RemoveLabel(ctx, a.ID, urgent.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page, _ := client.ListConversationsByLabel(ctx, []string{urgent.ID}, nil); len(page.Items) != 0 {
+		t.Errorf("expected no conversations, got %+v", page.Items)
+	}
+
+	name := "clients"
+	if work, err = client.UpdateLabel(ctx, work.ID, copilot.LabelUpdate{Name: &name}); err != nil || work.Name != name {
+		t.Fatalf("unexpected update %+v, %v", work, err)
+	}
+
+	// Deleting a label takes it off its conversations.
+	if err := client.DeleteLabel(ctx, work.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := client.GetConversation(ctx, b.ID); len(got.Labels) != 0 {
+		t.Errorf("expected label removed, got %v", got.Labels)
+	}
+	labels, err := client.ListLabels(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != 1 || labels[0].ID != urgent.ID || labels[0].ParentID != "" {
+		t.Errorf("expected urgent moved to the top level, got %+v", labels)
+	}
+}
+
+func TestFakeServerSearch(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	billing, _ := client.CreateConversation(ctx, &copilot.ConversationCreate{Title: "Billing"})
+	other, _ := client.CreateConversation(ctx, nil)
+	client.SendMessage(ctx, billing.ID, "How do I update my invoice address?")
+	client.SendMessage(ctx, billing.ID, "Thanks")
+	client.SendMessage(ctx, other.ID, "Unrelated question")
+
+	page, err := client.SearchMessages(ctx, "INVOICE address", &copilot.SearchOptions{
+		Roles:           []copilot.MessageRole{copilot.RoleUser},
+		ContextMessages: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 {
+		t.Fatalf("expected one hit, got %+v", page.Items)
+	}
+	hit := page.Items[0]
+	if hit.Message.ConversationID != billing.ID || hit.ConversationTitle != "Billing" {
+		t.Errorf("unexpected hit %+v", hit)
+	}
+	if got := hit.HighlightedSnippet("[", "]"); got != "How do I update my [invoice] [address]?" {
+		t.Errorf("unexpected snippet %q", got)
+	}
+	if len(hit.Context) != 1 || hit.Context[0].Role != copilot.RoleAssistant {
+		t.Errorf("expected the reply as context, got %+v", hit.Context)
+	}
+
+	page, err = client.SearchMessages(ctx, "invoice", &copilot.SearchOptions{ConversationIDs: []copilot.ConversationID{other.ID}})
+	if err != nil || len(page.Items) != 0 {
+		t.Errorf("expected no hits in other conversation, got %+v, %v", page, err)
+	}
+	if _, err := client.SearchMessages(ctx, " ", nil); err == nil {
+		t.Error("expected empty query to fail")
+	}
+
+	// Without semantic search the query falls back to matching terms.
+	client.DisableFeature(copilot.FeatureSemanticSearch)
+	page, err = client.SearchMessages(ctx, "invoice", &copilot.SearchOptions{Semantic: true})
+	if err != nil || len(page.Items) == 0 {
+		t.Errorf("expected term search hits, got %+v, %v", page, err)
+	}
+}
+
+func TestFakeServerPromptTemplates(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	tmpl, err := client.CreatePromptTemplate(ctx, copilot.PromptTemplateCreate{
+		Name:      "support",
+		Content:   "You help customers of {{product}}.",
+		Variables: []copilot.PromptVariable{{Name: "product", Required: true}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.Version != 1 {
+		t.Errorf("expected version 1, got %d", tmpl.Version)
+	}
+
+	content := "You help customers of {{product}}. Be brief."
+	v2, err := client.UpdatePromptTemplate(ctx, tmpl.ID, copilot.PromptTemplateUpdate{Content: &content})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v2.Version != 2 || v2.Content != content || v2.Name != "support" {
+		t.Errorf("unexpected new version %+v", v2)
+	}
+
+	if got, err := client.GetPromptTemplate(ctx, tmpl.ID, 1); err != nil || got.Content != tmpl.Content {
+		t.Errorf("expected version 1 to be kept, got %+v, %v", got, err)
+	}
+	if got, err := client.GetPromptTemplate(ctx, tmpl.ID, 0); err != nil || got.Version != 2 {
+		t.Errorf("expected latest version, got %+v, %v", got, err)
+	}
+	versions, err := client.ListPromptTemplateVersions(ctx, tmpl.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 {
+		t.Errorf("expected newest version first, got %+v", versions)
+	}
+	if page, _ := client.ListPromptTemplates(ctx, nil); len(page.Items) != 1 || page.Items[0].Version != 2 {
+		t.Errorf("expected the latest version listed, got %+v", page.Items)
+	}
+
+	ref := &copilot.PromptTemplateRef{ID: tmpl.ID, Version: 1, Variables: map[string]interface{}{"product": "Acme"}}
+	if _, err := client.CreateConversation(ctx, &copilot.ConversationCreate{PromptTemplate: ref}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := client.DeletePromptTemplate(ctx, tmpl.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.CreateConversation(ctx, &copilot.ConversationCreate{PromptTemplate: ref}); err == nil {
+		t.Error("expected a deleted template to be rejected")
+	}
+}
+
+func TestFakeServerSystemPrompt(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	conv, err := client.CreateConversation(ctx, &copilot.ConversationCreate{SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conv.SystemPrompt != "Be brief." {
+		t.Errorf("expected the initial system prompt, got %q", conv.SystemPrompt)
+	}
+
+	updated, err := client.SetSystemPrompt(ctx, conv.ID, "Answer in French.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.SystemPrompt != "Answer in French." {
+		t.Errorf("expected the new system prompt, got %q", updated.SystemPrompt)
+	}
+	if got, _ := client.GetConversation(ctx, conv.ID); got.SystemPrompt != "Answer in French." {
+		t.Errorf("expected the new system prompt to be stored, got %q", got.SystemPrompt)
+	}
+	if _, err := client.SetSystemPrompt(ctx, "conv-missing", "x"); err == nil {
+		t.Error("expected an unknown conversation to fail")
+	}
+}
+
+func TestFakeServerConversationSettings(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	conv, err := client.CreateConversation(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	settings, err := client.GetConversationSettings(ctx, conv.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.Model == "" || !settings.ToolsEnabled || settings.ContextScope != copilot.ContextScopeAll {
+		t.Errorf("unexpected defaults %+v", settings)
+	}
+
+	model, temperature := "copilot-large", 0.2
+	scope, items := copilot.ContextScopeSelected, []string{"ctx-1"}
+	settings, err = client.UpdateConversationSettings(ctx, conv.ID, copilot.ConversationSettingsUpdate{
+		Model:          &model,
+		Temperature:    &temperature,
+		ContextScope:   &scope,
+		ContextItemIDs: &items,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.Model != model || *settings.Temperature != temperature || settings.ContextItemIDs[0] != "ctx-1" || !settings.ToolsEnabled {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+
+	// An empty slice clears the selection; other fields are kept.
+	items = []string{}
+	if settings, err = client.UpdateConversationSettings(ctx, conv.ID, copilot.ConversationSettingsUpdate{ContextItemIDs: &items}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(settings.ContextItemIDs) != 0 || settings.Model != model {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+
+	temperature = 3
+	if _, err := client.UpdateConversationSettings(ctx, conv.ID, copilot.ConversationSettingsUpdate{Temperature: &temperature}); err == nil {
+		t.Error("expected an out-of-range temperature to fail")
+	}
+}
+
+func TestFakeServerMemory(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	if _, err := client.PutMemory(ctx, "user-1", "favorite/color", "blue"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.PutMemory(ctx, "user-1", "home", map[string]string{"city": "Lisbon"})
+	client.PutMemory(ctx, "user-2", "home", "Oslo")
+
+	mem, err := client.GetMemory(ctx, "user-1", "favorite/color")
+	if err != nil || mem.Value != "blue" {
+		t.Fatalf("unexpected memory %+v, %v", mem, err)
+	}
+
+	mems, err := client.QueryMemory(ctx, "user-1", "lisbon city")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mems) != 1 || mems[0].Key != "home" || mems[0].Score != 1 {
+		t.Errorf("expected the home memory, got %+v", mems)
+	}
+	if mems, _ := client.QueryMemory(ctx, "user-1", ""); len(mems) != 2 {
+		t.Errorf("expected every memory, got %+v", mems)
+	}
+
+	// Configured retrieval applies to every message.
+	agent := server.Client(copilot.WithMemoryRetrieval(1, "user-1"))
+	conv, _ := agent.CreateConversation(ctx, nil)
+	reply, err := agent.SendMessage(ctx, conv.ID, "What is my favorite color?")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recalled, _ := reply.Metadata["recalled_memories"].([]interface{}); len(recalled) != 1 || recalled[0] != "favorite/color" {
+		t.Errorf("expected the color to be recalled, got %v", reply.Metadata)
+	}
+
+	if err := client.DeleteMemory(ctx, "user-1", "home"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetMemory(ctx, "user-1", "home"); err == nil {
+		t.Error("expected the memory to be forgotten")
+	}
+}
+
+func TestFakeServerRetrieval(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	policy, _ := client.CreateContextItem(ctx, &copilot.ContextItemCreate{
+		Type:    copilot.ContextTypeDocument,
+		Name:    "policy.md",
+		Content: "Refunds are issued within 30 days.\n\nShipping takes a week.",
+		Tags:    []string{"support"},
+	})
+	client.CreateContextItem(ctx, &copilot.ContextItemCreate{
+		Type:    copilot.ContextTypeDocument,
+		Name:    "internal.md",
+		Content: "Refunds over $500 need approval.",
+		Tags:    []string{"finance"},
+	})
+	conv, _ := client.CreateConversation(ctx, nil)
+
+	reply, err := client.SendMessageWithRetrieval(ctx, conv.ID, "How long do refunds take?", copilot.RetrievalOptions{TopK: 3, Tags: []string{"support"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reply.RetrievedContext) != 1 {
+		t.Fatalf("expected one passage, got %+v", reply.RetrievedContext)
+	}
+	if chunk := reply.RetrievedContext[0]; chunk.ContextItemID != policy.ID || chunk.Content != "Refunds are issued within 30 days." || chunk.Score <= 0 {
+		t.Errorf("unexpected passage %+v", chunk)
+	}
+
+	// Conversation settings scope retrieval.
+	scope := copilot.ContextScopeNone
+	client.UpdateConversationSettings(ctx, conv.ID, copilot.ConversationSettingsUpdate{ContextScope: &scope})
+	if reply, _ := client.SendMessageWithRetrieval(ctx, conv.ID, "refunds", copilot.RetrievalOptions{}); len(reply.RetrievedContext) != 0 {
+		t.Errorf("expected no passages with retrieval scoped off, got %+v", reply.RetrievedContext)
+	}
+}
+
+func TestFakeServerContextDownload(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	content := strings.Repeat("line of a large document\n", 1000)
+	item, err := client.CreateContextItem(ctx, &copilot.ContextItemCreate{Type: copilot.ContextTypeDocument, Name: "doc.txt", Content: content})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out strings.Builder
+	if err := client.DownloadContextItem(ctx, item.ID, &out); err != nil || out.String() != content {
+		t.Fatalf("expected the full content, got %d bytes (%v)", out.Len(), err)
+	}
+	out.Reset()
+	if err := client.DownloadContextItemFrom(ctx, item.ID, &out, int64(len(content)-5)); err != nil || out.String() != "ment\n" {
+		t.Errorf("expected the last bytes, got %q (%v)", out.String(), err)
+	}
+
+	var apiErr *copilot.CoPilotError
+	if err := client.DownloadContextItem(ctx, "missing", &out); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestFakeServerPresignedURLs(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	content := strings.Repeat("a large file\n", 1000)
+	item, err := client.UploadContextItem(ctx, &copilot.UploadURLRequest{
+		Type:        copilot.ContextTypeFile,
+		Name:        "large.txt",
+		Size:        int64(len(content)),
+		ContentType: "text/plain",
+	}, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Name != "large.txt" || item.Content != content {
+		t.Errorf("unexpected item %q with %d bytes", item.Name, len(item.Content))
+	}
+	if server.Uploads() != 0 {
+		t.Errorf("expected no pending uploads, got %d", server.Uploads())
+	}
+
+	u, err := client.GetDownloadURL(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := http.Get(u.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(data) != content {
+		t.Errorf("expected the content from storage, got %s with %d bytes", resp.Status, len(data))
+	}
+	if resp, err := http.Get(strings.Replace(u.URL, "signature=", "signature=x", 1)); err != nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected a forged URL to be refused, got %v", err)
+	} else {
+		resp.Body.Close()
+	}
+
+	// A short upload is refused by storage and the upload aborted.
+	if _, err := client.UploadContextItem(ctx, &copilot.UploadURLRequest{Name: "short.txt", Size: 10}, strings.NewReader("abc")); err == nil {
+		t.Error("expected an error for a short upload")
+	}
+	if server.Uploads() != 0 {
+		t.Errorf("expected the failed upload to be aborted, got %d pending", server.Uploads())
+	}
+}
+
+func TestFakeServerWorkflowStepPolicy(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	policy := &copilot.StepRetryPolicy{MaxAttempts: 3, Backoff: &copilot.StepBackoff{Strategy: copilot.BackoffExponential, InitialSeconds: 1}}
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{
+		Name:       "fetch",
+		EntryPoint: "call",
+		Steps:      []copilot.WorkflowStep{{ID: "call", Type: copilot.StepTypeTool, RetryPolicy: policy, TimeoutSeconds: 10}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := client.GetWorkflow(ctx, wf.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if step := got.Steps[0]; step.TimeoutSeconds != 10 || step.RetryPolicy == nil || step.RetryPolicy.MaxAttempts != 3 || step.RetryPolicy.Backoff.Strategy != copilot.BackoffExponential {
+		t.Errorf("expected the step policy to round-trip, got %+v", step)
+	}
+
+	_, err = client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{
+		Name:  "bad",
+		Steps: []copilot.WorkflowStep{{ID: "call", RetryPolicy: &copilot.StepRetryPolicy{}}},
+	})
+	if !errors.Is(err, copilot.ErrInvalidWorkflow) {
+		t.Errorf("expected ErrInvalidWorkflow, got %v", err)
+	}
+}
+
+func TestFakeServerWorkflowSchemas(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{
+		Name:         "summarize",
+		EntryPoint:   "start",
+		InputSchema:  copilot.JSONSchema{"type": "object", "required": []string{"topic"}, "properties": map[string]interface{}{"topic": map[string]interface{}{"type": "string"}}},
+		OutputSchema: copilot.JSONSchema{"type": "object", "required": []string{"summary"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bad := &copilot.WorkflowRunCreate{WorkflowID: wf.ID, InputData: map[string]interface{}{"topic": 3}}
+	var schemaErr *copilot.SchemaError
+	if err := client.ValidateRun(ctx, bad); !errors.As(err, &schemaErr) || schemaErr.Violations[0].Field != "topic" {
+		t.Errorf("expected the client to reject topic, got %v", err)
+	}
+	var apiErr *copilot.CoPilotError
+	if _, err := client.RunWorkflow(ctx, bad); !errors.As(err, &apiErr) || apiErr.Field("input_data.topic") == nil {
+		t.Errorf("expected the server to reject topic, got %v", err)
+	}
+
+	good := &copilot.WorkflowRunCreate{WorkflowID: wf.ID, InputData: map[string]interface{}{"topic": "tides"}}
+	if err := client.ValidateRun(ctx, good); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The default handler echoes the input, which lacks a summary.
+	run, err := client.RunWorkflow(ctx, good)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Status != copilot.WorkflowStatusFailed || !strings.Contains(run.Error, "summary") {
+		t.Errorf("expected the output to fail its schema, got %s: %s", run.Status, run.Error)
+	}
+}
+
+func TestFakeServerSimulateWorkflow(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{
+		Name:       "triage",
+		EntryPoint: "draft",
+		Steps: []copilot.WorkflowStep{
+			{ID: "draft", Type: copilot.StepTypeLLM, Config: map[string]interface{}{"prompt": "Summarize {{input.topic}}"}, NextSteps: []string{"gate"}},
+			copilot.NewConditionStep("gate", copilot.Var("steps.draft.output.length").Gt(100), []string{"shorten"}, []string{"publish"}),
+			{ID: "shorten", Type: copilot.StepTypeLLM},
+			{ID: "publish", Type: copilot.StepTypeTool},
+			{ID: "orphan", Type: copilot.StepTypeTool},
+		},
+		InputSchema: copilot.JSONSchema{"type": "object", "required": []string{"topic"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sim, err := client.SimulateWorkflow(ctx, wf.ID, map[string]interface{}{"topic": "tides"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var order []string
+	for _, step := range sim.Steps {
+		order = append(order, fmt.Sprintf("%d:%s", step.Order, step.StepID))
+	}
+	if got := strings.Join(order, " "); got != "1:draft 2:gate 3:shorten 3:publish" {
+		t.Errorf("unexpected plan %s", got)
+	}
+	if sim.Steps[0].Config["prompt"] != "Summarize tides" {
+		t.Errorf("expected the prompt to be resolved, got %v", sim.Steps[0].Config["prompt"])
+	}
+	if sim.EstimatedCostUSD <= 0 || sim.Steps[3].EstimatedCostUSD != 0 {
+		t.Errorf("expected only LLM steps to cost, got %+v", sim)
+	}
+	if len(sim.Warnings) != 1 || !strings.Contains(sim.Warnings[0], "orphan") {
+		t.Errorf("expected a warning about the orphan step, got %v", sim.Warnings)
+	}
+	if runs, _ := client.ListWorkflowRuns(ctx, wf.ID, nil); len(runs.Items) != 0 {
+		t.Errorf("expected no run to be created, got %d", len(runs.Items))
+	}
+
+	var apiErr *copilot.CoPilotError
+	if _, err := client.SimulateWorkflow(ctx, wf.ID, nil); !errors.As(err, &apiErr) || apiErr.Field("input_data.topic") == nil {
+		t.Errorf("expected missing input to be rejected, got %v", err)
+	}
+}
+
+func TestFakeServerRunClientToken(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{Name: "sync", EntryPoint: "start"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req := &copilot.WorkflowRunCreate{WorkflowID: wf.ID, ClientToken: "sync-1"}
+	first, err := client.RunWorkflow(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := client.RunWorkflow(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("expected the same run for a repeated token, got %s and %s", first.ID, second.ID)
+	}
+	other, err := client.RunWorkflow(ctx, &copilot.WorkflowRunCreate{WorkflowID: wf.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other.ID == first.ID {
+		t.Errorf("expected a new run without the token")
+	}
+}
+
+func TestFakeServerTriggers(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{Name: "index", EntryPoint: "start"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	trigger, err := client.CreateTrigger(ctx, copilot.TriggerCreate{
+		WorkflowID: wf.ID,
+		EventType:  copilot.TriggerEventContextCreated,
+		Filter:     copilot.Var("event.data.type").Eq("file"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !trigger.Active || trigger.Filter != `event.data.type == "file"` {
+		t.Errorf("unexpected trigger %+v", trigger)
+	}
+
+	page, err := client.ListTriggers(ctx, wf.ID, nil)
+	if err != nil || len(page.Items) != 1 || page.Items[0].ID != trigger.ID {
+		t.Fatalf("expected the trigger to be listed, got %+v, %v", page, err)
+	}
+
+	active := false
+	updated, err := client.UpdateTrigger(ctx, trigger.ID, copilot.TriggerUpdate{Active: &active})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Active || updated.Filter != trigger.Filter {
+		t.Errorf("expected only Active to change, got %+v", updated)
+	}
+
+	bad := copilot.Condition("event.data.type ==")
+	if _, err := client.UpdateTrigger(ctx, trigger.ID, copilot.TriggerUpdate{Filter: &bad}); !errors.Is(err, copilot.ErrInvalidTrigger) {
+		t.Errorf("expected ErrInvalidTrigger, got %v", err)
+	}
+	var apiErr *copilot.CoPilotError
+	if _, err := client.CreateTrigger(ctx, copilot.TriggerCreate{WorkflowID: "wf-missing", EventType: copilot.TriggerEventMessageCreated}); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	if err := client.DeleteTrigger(ctx, trigger.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetTrigger(ctx, trigger.ID); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected the trigger to be deleted, got %v", err)
+	}
+}
+
+func TestFakeServerWorkflowSecrets(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	var transcript bytes.Buffer
+	client := server.Client(copilot.WithDebugWriter(&transcript))
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{
+		Name:       "deploy",
+		EntryPoint: "call",
+		Steps:      []copilot.WorkflowStep{{ID: "call", Type: copilot.StepTypeTool, Config: map[string]interface{}{"api_key": copilot.SecretRef("DEPLOY_TOKEN")}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secret, err := client.SetWorkflowSecret(ctx, wf.ID, "DEPLOY_TOKEN", "tok-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if secret.Name != "DEPLOY_TOKEN" {
+		t.Errorf("unexpected secret %+v", secret)
+	}
+	if value, ok := server.WorkflowSecret(wf.ID, "DEPLOY_TOKEN"); !ok || value != "tok-123" {
+		t.Errorf("expected the value to be stored, got %q", value)
+	}
+	if strings.Contains(transcript.String(), "tok-123") {
+		t.Errorf("expected the value to be masked in the transcript")
+	}
+
+	page, err := client.ListWorkflowSecrets(ctx, wf.ID, nil)
+	if err != nil || len(page.Items) != 1 || page.Items[0].Name != "DEPLOY_TOKEN" {
+		t.Fatalf("expected the secret to be listed, got %+v, %v", page, err)
+	}
+	if _, err := client.SetWorkflowSecret(ctx, wf.ID, "deploy-token", "x"); !errors.Is(err, copilot.ErrInvalidSecretName) {
+		t.Errorf("expected ErrInvalidSecretName, got %v", err)
+	}
+
+	if err := client.DeleteWorkflowSecret(ctx, wf.ID, "DEPLOY_TOKEN"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := server.WorkflowSecret(wf.ID, "DEPLOY_TOKEN"); ok {
+		t.Errorf("expected the secret to be deleted")
+	}
+}
+
+func TestFakeServerEnvironments(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	for _, name := range []string{copilot.EnvironmentDev, copilot.EnvironmentProd} {
+		_, err := client.CreateEnvironment(ctx, copilot.EnvironmentCreate{Name: name, Variables: map[string]string{"MODEL": name + "-model"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	envs, err := client.ListEnvironments(ctx)
+	if err != nil || len(envs) != 2 || envs[0].Name != copilot.EnvironmentDev {
+		t.Fatalf("expected both environments, got %+v, %v", envs, err)
+	}
+
+	description := "production"
+	env, err := client.UpdateEnvironment(ctx, copilot.EnvironmentProd, copilot.EnvironmentUpdate{Description: &description})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.Description != "production" || env.Variables["MODEL"] != "prod-model" {
+		t.Errorf("expected only the description to change, got %+v", env)
+	}
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{
+		Name:       "answer",
+		EntryPoint: "draft",
+		Steps:      []copilot.WorkflowStep{{ID: "draft", Type: copilot.StepTypeLLM, Config: map[string]interface{}{"model": copilot.EnvRef("MODEL")}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	run, err := client.RunWorkflow(ctx, &copilot.WorkflowRunCreate{WorkflowID: wf.ID, Environment: copilot.EnvironmentProd})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Environment != copilot.EnvironmentProd {
+		t.Errorf("expected the run to record its environment, got %q", run.Environment)
+	}
+
+	if err := client.DeleteEnvironment(ctx, copilot.EnvironmentProd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var apiErr *copilot.CoPilotError
+	if _, err := client.RunWorkflow(ctx, &copilot.WorkflowRunCreate{WorkflowID: wf.ID, Environment: copilot.EnvironmentProd}); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected an unknown environment to be rejected, got %v", err)
+	}
+}
+
+func TestFakeServerDiffWorkflowRuns(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	ctx := context.Background()
+	client := server.Client()
+
+	wf, err := client.CreateWorkflow(ctx, &copilot.WorkflowDefinitionCreate{Name: "echo", EntryPoint: "start"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	runA, err := client.RunWorkflow(ctx, &copilot.WorkflowRunCreate{WorkflowID: wf.ID, InputData: map[string]interface{}{"model": "small", "topic": "tides"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	runB, err := client.RunWorkflow(ctx, &copilot.WorkflowRunCreate{WorkflowID: wf.ID, InputData: map[string]interface{}{"model": "large", "topic": "tides"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	diff, err := client.DiffWorkflowRuns(ctx, runA.ID, runB.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.RunA != runA.ID || len(diff.Input) != 1 || diff.Input[0].Path != "model" || diff.Input[0].B != "large" {
+		t.Errorf("unexpected input diff %+v", diff.Input)
+	}
+	if len(diff.Output) != 1 || diff.Output[0].Kind != copilot.ChangeChanged {
+		t.Errorf("unexpected output diff %+v", diff.Output)
+	}
+
+	var apiErr *copilot.CoPilotError
+	if _, err := client.DiffWorkflowRuns(ctx, runA.ID, "run-missing"); !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,361 @@
+// Package fixtures provides builders that generate valid model instances
+// for tests:
+//
+//	conv, msgs := fixtures.Conversation().WithTitle("Support").WithMessages(3).BuildWithMessages()
+//	run := fixtures.WorkflowRun().WithStatus(models.WorkflowStatusFailed).Build()
+//
+// IDs are unique within a process and timestamps derive from Epoch, so
+// generated values are deterministic for a given sequence of calls.
+package fixtures
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// Epoch is the base time of generated timestamps.
+var Epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var seq atomic.Int64
+
+// id returns a unique ID with the given prefix.
+func id(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, seq.Add(1))
+}
+
+// ConversationBuilder builds a models.Conversation.
+type ConversationBuilder struct {
+	conv     models.Conversation
+	messages int
+}
+
+// Conversation starts building a conversation.
+func Conversation() *ConversationBuilder {
+	return &ConversationBuilder{conv: models.Conversation{
+		ID:            models.ConversationID(id("conv")),
+		Title:         "Test conversation",
+		UserID:        "user-1",
+		HandoffStatus: models.HandoffStatusNone,
+		CreatedAt:     models.NewTimestamp(Epoch),
+		UpdatedAt:     models.NewTimestamp(Epoch),
+	}}
+}
+
+// WithID sets the conversation ID.
+func (b *ConversationBuilder) WithID(id models.ConversationID) *ConversationBuilder {
+	b.conv.ID = id
+	return b
+}
+
+// WithTitle sets the title.
+func (b *ConversationBuilder) WithTitle(title string) *ConversationBuilder {
+	b.conv.Title = title
+	return b
+}
+
+// WithUserID sets the owning user.
+func (b *ConversationBuilder) WithUserID(userID string) *ConversationBuilder {
+	b.conv.UserID = userID
+	return b
+}
+
+// WithMetadata sets a metadata entry.
+func (b *ConversationBuilder) WithMetadata(key string, value interface{}) *ConversationBuilder {
+	if b.conv.Metadata == nil {
+		b.conv.Metadata = make(map[string]interface{})
+	}
+	b.conv.Metadata[key] = value
+	return b
+}
+
+// WithMessages gives the conversation n messages, alternating between user
+// and assistant.
+func (b *ConversationBuilder) WithMessages(n int) *ConversationBuilder {
+	b.messages = n
+	b.conv.MessageCount = n
+	b.conv.UpdatedAt = models.NewTimestamp(Epoch.Add(time.Duration(n) * time.Minute))
+	return b
+}
+
+// Build returns the conversation.
+func (b *ConversationBuilder) Build() *models.Conversation {
+	conv := b.conv
+	return &conv
+}
+
+// BuildWithMessages returns the conversation and its messages.
+func (b *ConversationBuilder) BuildWithMessages() (*models.Conversation, []models.Message) {
+	conv := b.Build()
+	msgs := make([]models.Message, b.messages)
+	for i := range msgs {
+		role := models.RoleUser
+		if i%2 == 1 {
+			role = models.RoleAssistant
+		}
+		msgs[i] = *Message().
+			WithConversationID(conv.ID).
+			WithRole(role).
+			WithContent(fmt.Sprintf("Message %d", i+1)).
+			WithCreatedAt(Epoch.Add(time.Duration(i+1) * time.Minute)).
+			Build()
+	}
+	return conv, msgs
+}
+
+// MessageBuilder builds a models.Message.
+type MessageBuilder struct {
+	msg models.Message
+}
+
+// Message starts building a user message.
+func Message() *MessageBuilder {
+	return &MessageBuilder{msg: models.Message{
+		ID:             models.MessageID(id("msg")),
+		ConversationID: "conv-0",
+		Role:           models.RoleUser,
+		Content:        "Hello",
+		CreatedAt:      models.NewTimestamp(Epoch),
+	}}
+}
+
+// WithID sets the message ID.
+func (b *MessageBuilder) WithID(id models.MessageID) *MessageBuilder {
+	b.msg.ID = id
+	return b
+}
+
+// WithConversationID sets the conversation the message belongs to.
+func (b *MessageBuilder) WithConversationID(id models.ConversationID) *MessageBuilder {
+	b.msg.ConversationID = id
+	return b
+}
+
+// WithRole sets the sender role.
+func (b *MessageBuilder) WithRole(role models.MessageRole) *MessageBuilder {
+	b.msg.Role = role
+	return b
+}
+
+// WithContent sets the content.
+func (b *MessageBuilder) WithContent(content string) *MessageBuilder {
+	b.msg.Content = content
+	return b
+}
+
+// WithCreatedAt sets the creation time.
+func (b *MessageBuilder) WithCreatedAt(t time.Time) *MessageBuilder {
+	b.msg.CreatedAt = models.NewTimestamp(t)
+	return b
+}
+
+// Build returns the message.
+func (b *MessageBuilder) Build() *models.Message {
+	msg := b.msg
+	return &msg
+}
+
+// WorkflowBuilder builds a models.WorkflowDefinition.
+type WorkflowBuilder struct {
+	wf models.WorkflowDefinition
+}
+
+// Workflow starts building a workflow with a single LLM step.
+func Workflow() *WorkflowBuilder {
+	return &WorkflowBuilder{wf: models.WorkflowDefinition{
+		ID:         models.WorkflowID(id("wf")),
+		Name:       "Test workflow",
+		Version:    "1.0.0",
+		Steps:      []models.WorkflowStep{{ID: "start", Name: "Start", Type: models.StepTypeLLM}},
+		EntryPoint: "start",
+		CreatedAt:  models.NewTimestamp(Epoch),
+		UpdatedAt:  models.NewTimestamp(Epoch),
+	}}
+}
+
+// WithID sets the workflow ID.
+func (b *WorkflowBuilder) WithID(id models.WorkflowID) *WorkflowBuilder {
+	b.wf.ID = id
+	return b
+}
+
+// WithName sets the name.
+func (b *WorkflowBuilder) WithName(name string) *WorkflowBuilder {
+	b.wf.Name = name
+	return b
+}
+
+// WithSteps replaces the steps; the entry point becomes the first step.
+func (b *WorkflowBuilder) WithSteps(steps ...models.WorkflowStep) *WorkflowBuilder {
+	b.wf.Steps = steps
+	if len(steps) > 0 {
+		b.wf.EntryPoint = steps[0].ID
+	}
+	return b
+}
+
+// Build returns the workflow.
+func (b *WorkflowBuilder) Build() *models.WorkflowDefinition {
+	wf := b.wf
+	wf.Steps = append([]models.WorkflowStep(nil), b.wf.Steps...)
+	return &wf
+}
+
+// WorkflowRunBuilder builds a models.WorkflowRun.
+type WorkflowRunBuilder struct {
+	run models.WorkflowRun
+}
+
+// WorkflowRun starts building a completed run.
+func WorkflowRun() *WorkflowRunBuilder {
+	completed := models.NewTimestamp(Epoch.Add(time.Minute))
+	return &WorkflowRunBuilder{run: models.WorkflowRun{
+		ID:          models.RunID(id("run")),
+		WorkflowID:  "wf-0",
+		Status:      models.WorkflowStatusCompleted,
+		StartedAt:   models.NewTimestamp(Epoch),
+		CompletedAt: &completed,
+	}}
+}
+
+// WithID sets the run ID.
+func (b *WorkflowRunBuilder) WithID(id models.RunID) *WorkflowRunBuilder {
+	b.run.ID = id
+	return b
+}
+
+// WithWorkflowID sets the workflow that was run.
+func (b *WorkflowRunBuilder) WithWorkflowID(id models.WorkflowID) *WorkflowRunBuilder {
+	b.run.WorkflowID = id
+	return b
+}
+
+// WithStatus sets the status. Pending and running runs have no completion
+// time; failed runs get an error message.
+func (b *WorkflowRunBuilder) WithStatus(status models.WorkflowStatus) *WorkflowRunBuilder {
+	b.run.Status = status
+	switch status {
+	case models.WorkflowStatusPending, models.WorkflowStatusRunning:
+		b.run.CompletedAt = nil
+	case models.WorkflowStatusFailed:
+		if b.run.Error == "" {
+			b.run.Error = "step failed"
+		}
+	}
+	return b
+}
+
+// WithInput sets the input data.
+func (b *WorkflowRunBuilder) WithInput(input map[string]interface{}) *WorkflowRunBuilder {
+	b.run.InputData = input
+	return b
+}
+
+// WithOutput sets the output data.
+func (b *WorkflowRunBuilder) WithOutput(output map[string]interface{}) *WorkflowRunBuilder {
+	b.run.OutputData = output
+	return b
+}
+
+// WithError sets the error message.
+func (b *WorkflowRunBuilder) WithError(err string) *WorkflowRunBuilder {
+	b.run.Error = err
+	return b
+}
+
+// Build returns the run.
+func (b *WorkflowRunBuilder) Build() *models.WorkflowRun {
+	run := b.run
+	if b.run.CompletedAt != nil {
+		completed := *b.run.CompletedAt
+		run.CompletedAt = &completed
+	}
+	return &run
+}
+
+// ContextItemBuilder builds a models.ContextItem.
+type ContextItemBuilder struct {
+	item models.ContextItem
+}
+
+// ContextItem starts building a text context item.
+func ContextItem() *ContextItemBuilder {
+	return &ContextItemBuilder{item: models.ContextItem{
+		ID:        id("ctx"),
+		Type:      models.ContextTypeText,
+		Name:      "notes.txt",
+		Content:   "Test content",
+		CreatedAt: models.NewTimestamp(Epoch),
+	}}
+}
+
+// WithID sets the item ID.
+func (b *ContextItemBuilder) WithID(id string) *ContextItemBuilder {
+	b.item.ID = id
+	return b
+}
+
+// WithType sets the item type.
+func (b *ContextItemBuilder) WithType(t models.ContextType) *ContextItemBuilder {
+	b.item.Type = t
+	return b
+}
+
+// WithName sets the name.
+func (b *ContextItemBuilder) WithName(name string) *ContextItemBuilder {
+	b.item.Name = name
+	return b
+}
+
+// WithContent sets the content.
+func (b *ContextItemBuilder) WithContent(content string) *ContextItemBuilder {
+	b.item.Content = content
+	return b
+}
+
+// Build returns the context item.
+func (b *ContextItemBuilder) Build() *models.ContextItem {
+	item := b.item
+	return &item
+}
+
+// UserBuilder builds a models.User.
+type UserBuilder struct {
+	user models.User
+}
+
+// User starts building an active, verified user.
+func User() *UserBuilder {
+	userID := id("user")
+	return &UserBuilder{user: models.User{
+		ID:            userID,
+		Username:      userID,
+		Email:         userID + "@example.com",
+		Roles:         []string{"user"},
+		IsActive:      true,
+		EmailVerified: true,
+		CreatedAt:     models.NewTimestamp(Epoch),
+	}}
+}
+
+// WithUsername sets the username and derives the email from it.
+func (b *UserBuilder) WithUsername(username string) *UserBuilder {
+	b.user.Username = username
+	b.user.Email = username + "@example.com"
+	return b
+}
+
+// WithRoles sets the roles.
+func (b *UserBuilder) WithRoles(roles ...string) *UserBuilder {
+	b.user.Roles = roles
+	return b
+}
+
+// Build returns the user.
+func (b *UserBuilder) Build() *models.User {
+	user := b.user
+	user.Roles = append([]string(nil), b.user.Roles...)
+	return &user
+}
@@ -0,0 +1,39 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestConversationWithMessages(t *testing.T) {
+	conv, msgs := Conversation().WithTitle("Support").WithMessages(3).BuildWithMessages()
+
+	if conv.Title != "Support" || conv.MessageCount != 3 || len(msgs) != 3 {
+		t.Fatalf("unexpected conversation %+v with %d messages", conv, len(msgs))
+	}
+	for i, msg := range msgs {
+		if msg.ConversationID != conv.ID {
+			t.Errorf("message %d: expected conversation %s, got %s", i, conv.ID, msg.ConversationID)
+		}
+	}
+	if msgs[0].Role != models.RoleUser || msgs[1].Role != models.RoleAssistant {
+		t.Errorf("expected alternating roles, got %s, %s", msgs[0].Role, msgs[1].Role)
+	}
+	if !msgs[2].CreatedAt.After(msgs[1].CreatedAt.Time) {
+		t.Error("expected increasing timestamps")
+	}
+
+	if other := Conversation().Build(); other.ID == conv.ID {
+		t.Error("expected unique IDs")
+	}
+}
+
+func TestWorkflowRunStatus(t *testing.T) {
+	if run := WorkflowRun().WithStatus(models.WorkflowStatusRunning).Build(); run.CompletedAt != nil {
+		t.Errorf("expected running run to have no completion time, got %v", run.CompletedAt)
+	}
+	if run := WorkflowRun().WithStatus(models.WorkflowStatusFailed).Build(); run.Error == "" {
+		t.Error("expected failed run to have an error")
+	}
+}
@@ -0,0 +1,128 @@
+package copilottest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// serveMemory stores, returns, deletes and queries agent memories. Keys
+// may contain slashes.
+func (s *FakeServer) serveMemory(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 || parts[0] == "" {
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+		return
+	}
+	namespace, key := parts[0], strings.Join(parts[1:], "/")
+
+	if key == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use GET")
+			return
+		}
+		s.mu.Lock()
+		mems := s.queryMemory(namespace, r.URL.Query().Get("q"))
+		s.mu.Unlock()
+		writePage(w, r, mems)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var req struct {
+			Value interface{} `json:"value"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		s.mu.Lock()
+		if s.memories[namespace] == nil {
+			s.memories[namespace] = make(map[string]models.Memory)
+		}
+		mem, ok := s.memories[namespace][key]
+		if !ok {
+			mem = models.Memory{Namespace: namespace, Key: key, CreatedAt: now}
+		}
+		mem.Value = req.Value
+		mem.UpdatedAt = now
+		s.memories[namespace][key] = mem
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, mem)
+	case http.MethodGet, http.MethodDelete:
+		s.mu.Lock()
+		mem, ok := s.memories[namespace][key]
+		if r.Method == http.MethodDelete {
+			delete(s.memories[namespace], key)
+		}
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			writeError(w, http.StatusNotFound, "not_found", "memory not found")
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, mem)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "use GET, PUT or DELETE")
+	}
+}
+
+// queryMemory scores the memories in a namespace by the share of the
+// query's terms found in their key or value, dropping those matching
+// none. An empty query matches every memory. s.mu must be held.
+func (s *FakeServer) queryMemory(namespace, query string) []models.Memory {
+	terms := strings.Fields(strings.ToLower(query))
+	mems := []models.Memory{}
+	for _, mem := range s.memories[namespace] {
+		if len(terms) > 0 {
+			value, _ := json.Marshal(mem.Value)
+			text := strings.ToLower(mem.Key + " " + string(value))
+			matched := 0
+			for _, term := range terms {
+				if strings.Contains(text, term) {
+					matched++
+				}
+			}
+			if matched == 0 {
+				continue
+			}
+			mem.Score = float64(matched) / float64(len(terms))
+		}
+		mems = append(mems, mem)
+	}
+	sort.Slice(mems, func(i, j int) bool {
+		if mems[i].Score != mems[j].Score {
+			return mems[i].Score > mems[j].Score
+		}
+		return mems[i].Key < mems[j].Key
+	})
+	return mems
+}
+
+// recall returns the keys of the memories a message recalls. s.mu must
+// be held.
+func (s *FakeServer) recall(retrieval *models.MemoryRetrieval, content string) []string {
+	var mems []models.Memory
+	for _, namespace := range retrieval.Namespaces {
+		for _, mem := range s.queryMemory(namespace, content) {
+			if mem.Score >= retrieval.MinScore {
+				mems = append(mems, mem)
+			}
+		}
+	}
+	sort.SliceStable(mems, func(i, j int) bool { return mems[i].Score > mems[j].Score })
+	if retrieval.TopK > 0 && len(mems) > retrieval.TopK {
+		mems = mems[:retrieval.TopK]
+	}
+	keys := make([]string, len(mems))
+	for i, mem := range mems {
+		keys[i] = mem.Key
+	}
+	return keys
+}
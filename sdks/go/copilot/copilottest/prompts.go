@@ -0,0 +1,136 @@
+package copilottest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// servePrompts creates, versions, lists and deletes prompt templates.
+func (s *FakeServer) servePrompts(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.PromptTemplateCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		if req.Name == "" || req.Content == "" {
+			writeError(w, http.StatusBadRequest, "invalid_request", "name and content are required")
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		s.mu.Lock()
+		tmpl := models.PromptTemplate{
+			ID:          s.newID("prompt"),
+			Name:        req.Name,
+			Description: req.Description,
+			Content:     req.Content,
+			Variables:   req.Variables,
+			Version:     1,
+			CreatedBy:   s.user.ID,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		s.prompts[tmpl.ID] = []models.PromptTemplate{tmpl}
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, tmpl)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		tmpls := make([]models.PromptTemplate, 0, len(s.prompts))
+		for _, versions := range s.prompts {
+			tmpls = append(tmpls, versions[len(versions)-1])
+		}
+		s.mu.Unlock()
+		sort.Slice(tmpls, func(i, j int) bool { return tmpls[i].Name < tmpls[j].Name })
+		writePage(w, r, tmpls)
+
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		var req models.PromptTemplateUpdate
+		if !decode(w, r, &req) {
+			return
+		}
+		s.mu.Lock()
+		versions, ok := s.prompts[parts[0]]
+		if !ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "not_found", "prompt template not found")
+			return
+		}
+		tmpl := versions[len(versions)-1]
+		if req.Name != nil {
+			tmpl.Name = *req.Name
+		}
+		if req.Description != nil {
+			tmpl.Description = *req.Description
+		}
+		if req.Content != nil {
+			tmpl.Content = *req.Content
+		}
+		if req.Variables != nil {
+			tmpl.Variables = req.Variables
+		}
+		tmpl.Version++
+		tmpl.UpdatedAt = models.NewTimestamp(time.Now().UTC())
+		s.prompts[tmpl.ID] = append(versions, tmpl)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, tmpl)
+
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.mu.Lock()
+		_, ok := s.prompts[parts[0]]
+		delete(s.prompts, parts[0])
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "prompt template not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case len(parts) >= 1 && len(parts) <= 3 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		versions := append([]models.PromptTemplate(nil), s.prompts[parts[0]]...)
+		s.mu.Unlock()
+		if len(versions) == 0 {
+			writeError(w, http.StatusNotFound, "not_found", "prompt template not found")
+			return
+		}
+		switch {
+		case len(parts) == 1:
+			writeJSON(w, http.StatusOK, versions[len(versions)-1])
+		case parts[1] != "versions":
+			writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+		case len(parts) == 2:
+			for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+				versions[i], versions[j] = versions[j], versions[i]
+			}
+			writePage(w, r, versions)
+		default:
+			version, _ := strconv.Atoi(parts[2])
+			if version < 1 || version > len(versions) {
+				writeError(w, http.StatusNotFound, "not_found", "prompt template version not found")
+				return
+			}
+			writeJSON(w, http.StatusOK, versions[version-1])
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// findPrompt resolves a prompt template reference. s.mu must be held.
+func (s *FakeServer) findPrompt(ref *models.PromptTemplateRef) (models.PromptTemplate, bool) {
+	versions := s.prompts[ref.ID]
+	switch {
+	case len(versions) == 0 || ref.Version > len(versions):
+		return models.PromptTemplate{}, false
+	case ref.Version > 0:
+		return versions[ref.Version-1], true
+	default:
+		return versions[len(versions)-1], true
+	}
+}
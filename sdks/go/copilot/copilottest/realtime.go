@@ -0,0 +1,126 @@
+package copilottest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/internal/websocket"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// subscriber is a realtime connection and the events it selected. Empty
+// sets match everything.
+type subscriber struct {
+	conn            *websocket.Conn
+	conversationIDs map[string]bool
+	runIDs          map[string]bool
+	eventTypes      map[string]bool
+}
+
+func (sub *subscriber) matches(event *models.ChangeEvent) bool {
+	return matchSet(sub.eventTypes, string(event.Type)) &&
+		matchSet(sub.conversationIDs, event.ConversationID.String()) &&
+		matchSet(sub.runIDs, event.RunID.String())
+}
+
+func matchSet(set map[string]bool, value string) bool {
+	return len(set) == 0 || set[value]
+}
+
+// querySet parses a comma-separated query parameter into a set.
+func querySet(r *http.Request, key string) map[string]bool {
+	set := make(map[string]bool)
+	for _, v := range strings.Split(r.URL.Query().Get(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
+
+// serveRealtime upgrades the request to a realtime subscription and holds
+// it until the client leaves.
+func (s *FakeServer) serveRealtime(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	sub := &subscriber{
+		conn:            conn,
+		conversationIDs: querySet(r, "conversation_id"),
+		runIDs:          querySet(r, "run_id"),
+		eventTypes:      querySet(r, "event_type"),
+	}
+	s.mu.Lock()
+	s.subscribers[sub] = true
+	s.mu.Unlock()
+	s.publishPresence(sub, true)
+
+	// Clients send nothing; reading serves pings and notices the close.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.subscribers, sub)
+	s.mu.Unlock()
+	conn.Close()
+	s.publishPresence(sub, false)
+}
+
+// publishPresence announces that the user joined or left the
+// conversations a subscriber selected.
+func (s *FakeServer) publishPresence(sub *subscriber, active bool) {
+	s.mu.Lock()
+	presence := s.presence(active, false)
+	s.mu.Unlock()
+	for id := range sub.conversationIDs {
+		presence := presence
+		s.publish(models.ChangeEvent{Type: models.ChangePresence, ConversationID: models.ConversationID(id), Presence: &presence})
+	}
+}
+
+// presence returns the user's presence. s.mu must be held.
+func (s *FakeServer) presence(active, typing bool) models.Presence {
+	return models.Presence{
+		UserID:     s.user.ID,
+		Username:   s.user.Username,
+		Active:     active,
+		Typing:     typing,
+		LastSeenAt: models.NewTimestamp(time.Now().UTC()),
+	}
+}
+
+// publish delivers a change event to matching subscribers. s.mu must not
+// be held.
+func (s *FakeServer) publish(event models.ChangeEvent) {
+	s.mu.Lock()
+	event.ID = s.newID("evt")
+	event.Timestamp = models.NewTimestamp(time.Now().UTC())
+	var subs []*subscriber
+	for sub := range s.subscribers {
+		if sub.matches(&event) {
+			subs = append(subs, sub)
+		}
+	}
+	s.mu.Unlock()
+
+	data, _ := json.Marshal(event)
+	for _, sub := range subs {
+		sub.conn.WriteMessage(websocket.TextMessage, data)
+	}
+}
+
+// Close closes realtime subscriptions and shuts the server down.
+func (s *FakeServer) Close() {
+	s.mu.Lock()
+	for sub := range s.subscribers {
+		sub.conn.Close()
+	}
+	s.mu.Unlock()
+	s.Server.Close()
+}
@@ -0,0 +1,387 @@
+// Package copilottest provides utilities for testing code built on the
+// CoPilot SDK without a live server.
+package copilottest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeAuto replays the cassette if it exists and records it otherwise.
+	ModeAuto Mode = iota
+	// ModeRecord sends requests to the server and records them.
+	ModeRecord
+	// ModeReplay serves responses from the cassette only.
+	ModeReplay
+)
+
+// EnvRecordMode overrides the mode of every Recorder: "record" or "replay".
+const EnvRecordMode = "COPILOT_RECORD_MODE"
+
+// Redacted replaces sanitized secrets in cassettes.
+const Redacted = "REDACTED"
+
+// ErrNoInteraction is returned in replay mode for a request that has no
+// matching recorded interaction.
+var ErrNoInteraction = errors.New("copilottest: no recorded interaction")
+
+// Headers whose values are redacted before a cassette is saved.
+var sensitiveHeaders = []string{"Authorization", "X-Api-Key", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// JSON body fields whose values are redacted before a cassette is saved.
+var sensitiveFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"password":      true,
+	"key":           true,
+	"secret":        true,
+	"client_secret": true,
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded part of a request.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded part of a response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	// BodyBase64 is set instead of Body for binary bodies.
+	BodyBase64 []byte `json:"body_base64,omitempty"`
+}
+
+// cassette is the on-disk fixture format.
+type cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// MatcherFunc reports whether a recorded interaction answers req.
+type MatcherFunc func(req *http.Request, body []byte, recorded *RecordedRequest) bool
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used when recording. Defaults to
+// http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithMatcher replaces the default matching on method, path and query.
+func WithMatcher(match MatcherFunc) Option {
+	return func(r *Recorder) {
+		r.match = match
+	}
+}
+
+// WithSanitizer adds a function that scrubs each interaction before it is
+// saved, after the built-in redaction of credentials.
+func WithSanitizer(sanitize func(*Interaction)) Option {
+	return func(r *Recorder) {
+		r.sanitizers = append(r.sanitizers, sanitize)
+	}
+}
+
+// Recorder is an http.RoundTripper that records interactions to a cassette
+// file and replays them deterministically. Secrets are redacted from
+// recorded headers and JSON bodies.
+type Recorder struct {
+	path       string
+	mode       Mode
+	transport  http.RoundTripper
+	match      MatcherFunc
+	sanitizers []func(*Interaction)
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewRecorder creates a Recorder for the cassette at path.
+func NewRecorder(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	switch os.Getenv(EnvRecordMode) {
+	case "record":
+		mode = ModeRecord
+	case "replay":
+		mode = ModeReplay
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		match:     matchMethodAndURL,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("copilottest: failed to read cassette: %w", err)
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("copilottest: malformed cassette %s: %w", path, err)
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns whether the recorder is recording or replaying.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// HTTPClient returns an HTTP client using the recorder as its transport.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		if decoded, err := gunzip(body); err == nil {
+			body = decoded
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !r.match(req, body, &interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		resp := interaction.Response
+		respBody := []byte(resp.Body)
+		if resp.BodyBase64 != nil {
+			respBody = resp.BodyBase64
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        resp.Headers.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fixtures are stored decoded so they stay readable and diffable.
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		if decoded, err := gunzip(respBody); err == nil {
+			respBody = decoded
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = int64(len(respBody))
+		}
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+			Body:    string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+		},
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = string(respBody)
+	} else {
+		interaction.Response.BodyBase64 = respBody
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save sanitizes the recorded interactions and writes the cassette. It is
+// a no-op in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, interaction := range r.interactions {
+		sanitize(interaction)
+		for _, fn := range r.sanitizers {
+			fn(interaction)
+		}
+	}
+
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("copilottest: failed to save cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("copilottest: failed to save cassette: %w", err)
+	}
+	return nil
+}
+
+// matchMethodAndURL matches requests on method, path and query, ignoring
+// the host so cassettes replay against any base URL.
+func matchMethodAndURL(req *http.Request, body []byte, recorded *RecordedRequest) bool {
+	if req.Method != recorded.Method {
+		return false
+	}
+	i := strings.Index(recorded.URL, "://")
+	if i < 0 {
+		return false
+	}
+	rest := recorded.URL[i+3:]
+	if j := strings.IndexByte(rest, '/'); j >= 0 {
+		return rest[j:] == req.URL.RequestURI()
+	}
+	return req.URL.RequestURI() == "/"
+}
+
+// sanitize redacts credentials from an interaction.
+func sanitize(interaction *Interaction) {
+	for _, name := range sensitiveHeaders {
+		for _, h := range []http.Header{interaction.Request.Headers, interaction.Response.Headers} {
+			if h.Get(name) != "" {
+				h.Set(name, Redacted)
+			}
+		}
+	}
+	interaction.Request.Body = redactJSON(interaction.Request.Body)
+	interaction.Response.Body = redactJSON(interaction.Response.Body)
+}
+
+// redactJSON redacts sensitive fields anywhere in a JSON document. Bodies
+// that are not JSON, such as event streams, are returned unchanged.
+func redactJSON(body string) string {
+	var v interface{}
+	if body == "" || json.Unmarshal([]byte(body), &v) != nil {
+		return body
+	}
+	if !redactValue(v) {
+		return body
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// redactValue redacts sensitive fields in place and reports whether any
+// were found.
+func redactValue(v interface{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if _, ok := field.(string); ok && sensitiveFields[strings.ToLower(k)] {
+				v[k] = Redacted
+				changed = true
+				continue
+			}
+			if redactValue(field) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if redactValue(item) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
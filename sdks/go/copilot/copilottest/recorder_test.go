@@ -0,0 +1,85 @@
+package copilottest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Setenv(EnvRecordMode, "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/login":
+			json.NewEncoder(w).Encode(copilot.LoginResponse{
+				AccessToken:  "live-access-token",
+				RefreshToken: "live-refresh-token",
+				User:         copilot.User{ID: "user-1"},
+			})
+		case "/api/v1/conversations/conv-1":
+			json.NewEncoder(w).Encode(copilot.Conversation{ID: "conv-1", Title: "Recorded"})
+		}
+	}))
+	path := filepath.Join(t.TempDir(), "fixtures", "session.json")
+	ctx := context.Background()
+
+	rec, err := NewRecorder(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode() != ModeRecord {
+		t.Fatalf("expected record mode without a cassette, got %v", rec.Mode())
+	}
+	client := copilot.NewClient(server.URL, copilot.WithHTTPClient(rec.HTTPClient()))
+	if _, err := client.Login(ctx, "alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetConversation(ctx, "conv-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "live-access-token", "live-refresh-token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("expected %q to be redacted from the cassette", secret)
+		}
+	}
+
+	rec, err = NewRecorder(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode() != ModeReplay {
+		t.Fatalf("expected replay mode with a cassette, got %v", rec.Mode())
+	}
+	client = copilot.NewClient("http://replay.invalid", copilot.WithHTTPClient(rec.HTTPClient()), copilot.WithMaxRetries(0))
+	if _, err := client.Login(ctx, "alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conv, err := client.GetConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conv.Title != "Recorded" {
+		t.Errorf("expected replayed conversation, got %+v", conv)
+	}
+
+	if _, err := client.GetConversation(ctx, "conv-2"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("expected ErrNoInteraction, got %v", err)
+	}
+}
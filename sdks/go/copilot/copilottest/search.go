@@ -0,0 +1,204 @@
+package copilottest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// snippetRadius is how much text a search snippet keeps before and after
+// the first match.
+const snippetRadius = 60
+
+// serveSearch searches messages for every term of the query, ignoring
+// case. Hits are ranked by the number of matches, then newest first.
+func (s *FakeServer) serveSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	terms := strings.Fields(strings.ToLower(query.Get("q")))
+	if len(terms) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "q is required")
+		return
+	}
+	conversationIDs := querySet(r, "conversation_id")
+	roles := querySet(r, "role")
+	contextSize, _ := strconv.Atoi(query.Get("context"))
+
+	s.mu.Lock()
+	hits := []models.MessageSearchHit{}
+	for convID, msgs := range s.messages {
+		if !matchSet(conversationIDs, convID.String()) {
+			continue
+		}
+		msgs, err := filterMessages(append([]models.Message(nil), msgs...), query)
+		if err != nil {
+			s.mu.Unlock()
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		for i, msg := range msgs {
+			if !matchSet(roles, string(msg.Role)) {
+				continue
+			}
+			hit, ok := searchMessage(msg, terms)
+			if !ok {
+				continue
+			}
+			hit.ConversationTitle = s.conversations[convID].Title
+			if contextSize > 0 {
+				for j := i - contextSize; j <= i+contextSize; j++ {
+					if j >= 0 && j < len(msgs) && j != i {
+						hit.Context = append(hit.Context, msgs[j])
+					}
+				}
+			}
+			hits = append(hits, hit)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Message.CreatedAt.After(hits[j].Message.CreatedAt.Time)
+	})
+	writePage(w, r, hits)
+}
+
+// searchMessage matches msg against lowercased terms, all of which must
+// occur in its content.
+func searchMessage(msg models.Message, terms []string) (models.MessageSearchHit, bool) {
+	content := strings.ToLower(msg.Content)
+	if len(content) != len(msg.Content) {
+		// Offsets into the lowered text would not map back.
+		content = msg.Content
+	}
+	first, score := -1, 0
+	for _, term := range terms {
+		i := strings.Index(content, term)
+		if i < 0 {
+			return models.MessageSearchHit{}, false
+		}
+		if first < 0 || i < first {
+			first = i
+		}
+		score += strings.Count(content, term)
+	}
+
+	start, end := first-snippetRadius, first+snippetRadius
+	if start < 0 {
+		start = 0
+	}
+	if end > len(content) {
+		end = len(content)
+	}
+	for start > 0 && !utf8.RuneStart(content[start]) {
+		start--
+	}
+	for end < len(content) && !utf8.RuneStart(content[end]) {
+		end++
+	}
+
+	hit := models.MessageSearchHit{
+		Message: msg,
+		Snippet: msg.Content[start:end],
+		Score:   float64(score),
+	}
+	lowered := content[start:end]
+	for _, term := range terms {
+		for off := 0; ; {
+			i := strings.Index(lowered[off:], term)
+			if i < 0 {
+				break
+			}
+			hit.Highlights = append(hit.Highlights, models.TextRange{Start: off + i, End: off + i + len(term)})
+			off += i + len(term)
+		}
+	}
+	sort.Slice(hit.Highlights, func(i, j int) bool { return hit.Highlights[i].Start < hit.Highlights[j].Start })
+	return hit, true
+}
+
+// retrieve returns the paragraphs of the context items in a
+// conversation's scope that share the most terms with content. s.mu must
+// be held.
+func (s *FakeServer) retrieve(conversationID models.ConversationID, opts *models.RetrievalOptions, content string) []models.RetrievedChunk {
+	scope := models.ContextScopeAll
+	selected := make(map[string]bool)
+	if settings, ok := s.settings[conversationID]; ok {
+		scope = settings.ContextScope
+		for _, id := range settings.ContextItemIDs {
+			selected[id] = true
+		}
+	}
+	if scope == models.ContextScopeNone {
+		return nil
+	}
+	tags := make(map[string]bool)
+	for _, tag := range opts.Tags {
+		tags[tag] = true
+	}
+
+	terms := words(content)
+	var chunks []models.RetrievedChunk
+	for _, item := range s.contextItems {
+		if scope == models.ContextScopeSelected && !selected[item.ID] || !hasTag(item.Tags, tags) {
+			continue
+		}
+		for _, para := range strings.Split(item.Content, "\n\n") {
+			paraWords := make(map[string]bool)
+			for _, w := range words(para) {
+				paraWords[w] = true
+			}
+			matched := 0
+			for _, term := range terms {
+				if paraWords[term] {
+					matched++
+				}
+			}
+			if matched == 0 {
+				continue
+			}
+			score := float64(matched) / float64(len(terms))
+			if score >= opts.MinScore {
+				chunks = append(chunks, models.RetrievedChunk{ContextItemID: item.ID, Name: item.Name, Content: strings.TrimSpace(para), Score: score})
+			}
+		}
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		if chunks[i].Score != chunks[j].Score {
+			return chunks[i].Score > chunks[j].Score
+		}
+		return chunks[i].ContextItemID < chunks[j].ContextItemID
+	})
+	if opts.TopK > 0 && len(chunks) > opts.TopK {
+		chunks = chunks[:opts.TopK]
+	}
+	return chunks
+}
+
+// words splits text into lowercased words.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// hasTag reports whether tags includes one in the set, or the set is
+// empty.
+func hasTag(tags []string, set map[string]bool) bool {
+	if len(set) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		if set[tag] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package copilottest
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// storedSecret is a workflow secret with its value.
+type storedSecret struct {
+	models.WorkflowSecret
+	value string
+}
+
+// serveSecrets handles the secrets of a workflow under
+// /api/v1/workflows/{id}/secrets. Values are accepted but never returned.
+func (s *FakeServer) serveSecrets(w http.ResponseWriter, r *http.Request, id models.WorkflowID, parts []string) {
+	s.mu.Lock()
+	_, ok := s.workflows[id]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "workflow not found")
+		return
+	}
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		secrets := make([]models.WorkflowSecret, 0, len(s.secrets[id]))
+		for _, secret := range s.secrets[id] {
+			secrets = append(secrets, secret.WorkflowSecret)
+		}
+		s.mu.Unlock()
+		sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+		writePage(w, r, secrets)
+
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		if err := models.ValidateSecretName(parts[0]); err != nil {
+			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		var req struct {
+			Secret string `json:"secret"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		s.mu.Lock()
+		if s.secrets[id] == nil {
+			s.secrets[id] = make(map[string]*storedSecret)
+		}
+		secret, ok := s.secrets[id][parts[0]]
+		if !ok {
+			secret = &storedSecret{WorkflowSecret: models.WorkflowSecret{Name: parts[0], CreatedAt: now}}
+			s.secrets[id][parts[0]] = secret
+		}
+		secret.value, secret.UpdatedAt = req.Secret, now
+		resp := secret.WorkflowSecret
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, resp)
+
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.mu.Lock()
+		_, ok := s.secrets[id][parts[0]]
+		delete(s.secrets[id], parts[0])
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "secret not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// WorkflowSecret returns the value of a workflow secret, which the API
+// itself never reveals.
+func (s *FakeServer) WorkflowSecret(id models.WorkflowID, name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secret, ok := s.secrets[id][name]
+	if !ok {
+		return "", false
+	}
+	return secret.value, true
+}
@@ -0,0 +1,123 @@
+package copilottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// fakeCostPerToken is the price the server estimates LLM steps at.
+const fakeCostPerToken = 0.00001
+
+// fakeCompletionTokens is the completion the server expects of each LLM
+// step.
+const fakeCompletionTokens = 256
+
+// inputVariable matches references such as {{input.topic}} in step
+// configuration.
+var inputVariable = regexp.MustCompile(`\{\{\s*input\.([A-Za-z0-9_]+)\s*\}\}`)
+
+// simulate plans a run of wf: steps are ordered by their distance from
+// the entry point, following next steps and both branches of conditions.
+func simulate(wf *models.WorkflowDefinition, input map[string]interface{}) models.WorkflowSimulation {
+	sim := models.WorkflowSimulation{WorkflowID: wf.ID, Steps: []models.SimulatedStep{}}
+	steps := make(map[string]*models.WorkflowStep, len(wf.Steps))
+	for i := range wf.Steps {
+		steps[wf.Steps[i].ID] = &wf.Steps[i]
+	}
+	entry := wf.EntryPoint
+	if entry == "" && len(wf.Steps) > 0 {
+		entry = wf.Steps[0].ID
+	}
+
+	visited := make(map[string]bool)
+	level := []string{entry}
+	for order := 1; len(level) > 0; order++ {
+		var next []string
+		for _, id := range level {
+			step, ok := steps[id]
+			if !ok {
+				sim.Warnings = append(sim.Warnings, fmt.Sprintf("unknown step %q", id))
+				continue
+			}
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+
+			planned := models.SimulatedStep{StepID: id, Name: step.Name, Type: step.Type, Order: order}
+			if step.Config != nil {
+				planned.Config = resolveConfig(step.Config, input).(map[string]interface{})
+			}
+			if step.Type == models.StepTypeLLM {
+				prompt, _ := json.Marshal(planned.Config)
+				planned.EstimatedTokens = int64(len(prompt)+3)/4 + fakeCompletionTokens
+				planned.EstimatedCostUSD = float64(planned.EstimatedTokens) * fakeCostPerToken
+			}
+			sim.Steps = append(sim.Steps, planned)
+			sim.EstimatedTokens += planned.EstimatedTokens
+			sim.EstimatedCostUSD += planned.EstimatedCostUSD
+
+			next = append(next, step.NextSteps...)
+			if step.Type == models.StepTypeCondition {
+				next = append(next, stringList(step.Config[models.ConditionTrueStepsKey])...)
+				next = append(next, stringList(step.Config[models.ConditionFalseStepsKey])...)
+			}
+		}
+		level = next
+	}
+
+	for _, step := range wf.Steps {
+		if !visited[step.ID] {
+			sim.Warnings = append(sim.Warnings, fmt.Sprintf("step %q is unreachable", step.ID))
+		}
+	}
+	return sim
+}
+
+// resolveConfig returns a copy of v with input references substituted in
+// its strings.
+func resolveConfig(v interface{}, input map[string]interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			resolved[key] = resolveConfig(value, input)
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, value := range v {
+			resolved[i] = resolveConfig(value, input)
+		}
+		return resolved
+	case string:
+		return inputVariable.ReplaceAllStringFunc(v, func(ref string) string {
+			name := inputVariable.FindStringSubmatch(ref)[1]
+			value, ok := input[name]
+			if !ok {
+				return ref
+			}
+			if s, ok := value.(string); ok {
+				return s
+			}
+			data, _ := json.Marshal(value)
+			return string(data)
+		})
+	}
+	return v
+}
+
+// stringList returns the strings of a decoded JSON array.
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var list []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -0,0 +1,120 @@
+package copilottest
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// serveTriggers manages workflow triggers. Triggers are stored but never
+// fire; tests start runs themselves.
+func (s *FakeServer) serveTriggers(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.TriggerCreate
+		if !decode(w, r, &req) {
+			return
+		}
+		if err := req.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		now := models.NewTimestamp(time.Now().UTC())
+		s.mu.Lock()
+		if _, ok := s.workflows[req.WorkflowID]; !ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "not_found", "workflow not found")
+			return
+		}
+		trigger := &models.Trigger{
+			ID:          s.newID("trigger"),
+			WorkflowID:  req.WorkflowID,
+			EventType:   req.EventType,
+			Filter:      req.Filter,
+			Description: req.Description,
+			Active:      true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		s.triggers[trigger.ID] = trigger
+		resp := *trigger
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		workflowID := models.WorkflowID(r.URL.Query().Get("workflow_id"))
+		s.mu.Lock()
+		triggers := make([]models.Trigger, 0, len(s.triggers))
+		for _, trigger := range s.triggers {
+			if workflowID == "" || trigger.WorkflowID == workflowID {
+				triggers = append(triggers, *trigger)
+			}
+		}
+		s.mu.Unlock()
+		sort.Slice(triggers, func(i, j int) bool { return triggers[i].ID < triggers[j].ID })
+		writePage(w, r, triggers)
+
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		trigger, ok := s.triggers[parts[0]]
+		var resp models.Trigger
+		if ok {
+			resp = *trigger
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "trigger not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		var req models.TriggerUpdate
+		if !decode(w, r, &req) {
+			return
+		}
+		if err := req.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		s.mu.Lock()
+		trigger, ok := s.triggers[parts[0]]
+		if !ok {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "not_found", "trigger not found")
+			return
+		}
+		if req.EventType != nil {
+			trigger.EventType = *req.EventType
+		}
+		if req.Filter != nil {
+			trigger.Filter = *req.Filter
+		}
+		if req.Description != nil {
+			trigger.Description = *req.Description
+		}
+		if req.Active != nil {
+			trigger.Active = *req.Active
+		}
+		trigger.UpdatedAt = models.NewTimestamp(time.Now().UTC())
+		resp := *trigger
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, resp)
+
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.mu.Lock()
+		_, ok := s.triggers[parts[0]]
+		delete(s.triggers, parts[0])
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "trigger not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
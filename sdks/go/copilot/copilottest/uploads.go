@@ -0,0 +1,189 @@
+package copilottest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// presignedURLLifetime is how long the server's presigned URLs are valid.
+const presignedURLLifetime = 15 * time.Minute
+
+// storagePrefix is the path under which the server emulates object
+// storage. Its requests are authorized by signature, not credentials.
+const storagePrefix = "/_storage/"
+
+// pendingUpload is a presigned upload not yet completed.
+type pendingUpload struct {
+	req      models.UploadURLRequest
+	content  []byte
+	uploaded bool
+}
+
+// serveUploads handles the uploads under /api/v1/context/uploads.
+func (s *FakeServer) serveUploads(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req models.UploadURLRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if req.Name == "" || req.Size < 0 {
+			writeError(w, http.StatusBadRequest, "validation_error", "name and a non-negative size are required")
+			return
+		}
+		s.mu.Lock()
+		id := s.newID("upload")
+		s.uploads[id] = &pendingUpload{req: req}
+		s.mu.Unlock()
+		u := s.presign(http.MethodPut, "uploads/"+id)
+		if req.ContentType != "" {
+			u.Headers = map[string]string{"Content-Type": req.ContentType}
+		}
+		writeJSON(w, http.StatusCreated, models.PresignedUpload{UploadID: id, PresignedURL: u})
+
+	case len(parts) == 2 && parts[1] == "complete" && r.Method == http.MethodPost:
+		s.mu.Lock()
+		upload, ok := s.uploads[parts[0]]
+		if !ok || !upload.uploaded {
+			s.mu.Unlock()
+			if !ok {
+				writeError(w, http.StatusNotFound, "not_found", "upload not found")
+			} else {
+				writeError(w, http.StatusConflict, "invalid_state", "content has not been uploaded")
+			}
+			return
+		}
+		delete(s.uploads, parts[0])
+		item := &models.ContextItem{
+			ID:        s.newID("ctx"),
+			Type:      upload.req.Type,
+			Name:      upload.req.Name,
+			Content:   string(upload.content),
+			Metadata:  upload.req.Metadata,
+			Tags:      upload.req.Tags,
+			CreatedAt: models.NewTimestamp(time.Now().UTC()),
+		}
+		s.contextItems[item.ID] = item
+		resp := *item
+		s.mu.Unlock()
+		s.publish(models.ChangeEvent{Type: models.ChangeContextUpdated, ContextItem: &resp})
+		writeJSON(w, http.StatusCreated, resp)
+
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.mu.Lock()
+		_, ok := s.uploads[parts[0]]
+		delete(s.uploads, parts[0])
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", "upload not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "no such endpoint")
+	}
+}
+
+// Uploads returns the number of uploads neither completed nor aborted.
+func (s *FakeServer) Uploads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+// presign returns a URL for method on key in the emulated storage.
+func (s *FakeServer) presign(method, key string) models.PresignedURL {
+	expires := time.Now().Add(presignedURLLifetime).Truncate(time.Second)
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return models.PresignedURL{
+		URL:       s.URL + storagePrefix + key + "?expires=" + exp + "&signature=" + s.signature(method, key, exp),
+		Method:    method,
+		ExpiresAt: models.NewTimestamp(expires.UTC()),
+	}
+}
+
+// signature signs a storage request.
+func (s *FakeServer) signature(method, key, expires string) string {
+	sum := sha256.Sum256([]byte(s.signingKey + "\n" + method + "\n" + key + "\n" + expires))
+	return hex.EncodeToString(sum[:16])
+}
+
+// serveStorage emulates object storage: uploads are stored with PUT and
+// context item content is read with GET, both through presigned URLs.
+// Errors are plain text, as storage services do not speak the API's
+// format.
+func (s *FakeServer) serveStorage(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, storagePrefix)
+	query := r.URL.Query()
+	exp := query.Get("expires")
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || query.Get("signature") != s.signature(r.Method, key, exp) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+	if time.Now().Unix() >= expires {
+		http.Error(w, "Request has expired", http.StatusForbidden)
+		return
+	}
+
+	kind, id, _ := strings.Cut(key, "/")
+	switch {
+	case kind == "uploads" && r.Method == http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		upload, ok := s.uploads[id]
+		var mismatch error
+		if ok {
+			switch {
+			case int64(len(content)) != upload.req.Size:
+				mismatch = fmt.Errorf("got %d bytes, want %d", len(content), upload.req.Size)
+			case upload.req.ContentType != "" && r.Header.Get("Content-Type") != upload.req.ContentType:
+				mismatch = errors.New("content type does not match the signed one")
+			default:
+				upload.content, upload.uploaded = content, true
+			}
+		}
+		s.mu.Unlock()
+		switch {
+		case !ok:
+			http.Error(w, "NoSuchUpload", http.StatusNotFound)
+		case mismatch != nil:
+			http.Error(w, mismatch.Error(), http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+
+	case kind == "context" && r.Method == http.MethodGet:
+		s.mu.Lock()
+		item, ok := s.contextItems[id]
+		var content string
+		var modified time.Time
+		if ok {
+			content, modified = item.Content, item.CreatedAt.Time
+		}
+		s.mu.Unlock()
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, "", modified, strings.NewReader(content))
+
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,93 @@
+package copilot
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/auth"
+	"github.com/llm-copilot-agent/sdk-go/copilot/client"
+)
+
+// Environment variables read by NewFromEnv.
+const (
+	EnvBaseURL      = "COPILOT_BASE_URL"
+	EnvAPIKey       = auth.EnvAPIKey
+	EnvAccessToken  = auth.EnvAccessToken
+	EnvTenantID     = "COPILOT_TENANT_ID"
+	EnvTimeout      = "COPILOT_TIMEOUT"
+	EnvMaxRetries   = "COPILOT_MAX_RETRIES"
+	EnvRetryWaitMin = "COPILOT_RETRY_WAIT_MIN"
+	EnvRetryWaitMax = "COPILOT_RETRY_WAIT_MAX"
+)
+
+// NewFromEnv creates a client configured from COPILOT_* environment
+// variables. Durations accept Go syntax ("45s") or whole seconds ("45").
+// Options are applied after the environment and take precedence.
+func NewFromEnv(opts ...Option) (*Client, error) {
+	config := client.DefaultConfig()
+	if err := applyEnv(config); err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return client.New(config), nil
+}
+
+// applyEnv overrides config fields with the environment variables that are set.
+func applyEnv(config *client.Config) error {
+	if v := os.Getenv(EnvBaseURL); v != "" {
+		config.BaseURL = v
+	}
+	if v := os.Getenv(EnvAPIKey); v != "" {
+		config.APIKey = v
+	}
+	if v := os.Getenv(EnvAccessToken); v != "" {
+		config.AccessToken = v
+	}
+	if v := os.Getenv(EnvTenantID); v != "" {
+		config.TenantID = v
+	}
+
+	durations := []struct {
+		name   string
+		target *time.Duration
+	}{
+		{EnvTimeout, &config.Timeout},
+		{EnvRetryWaitMin, &config.RetryWaitMin},
+		{EnvRetryWaitMax, &config.RetryWaitMax},
+	}
+	for _, d := range durations {
+		v := os.Getenv(d.name)
+		if v == "" {
+			continue
+		}
+		parsed, err := parseEnvDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		*d.target = parsed
+	}
+
+	if v := os.Getenv(EnvMaxRetries); v != "" {
+		retries, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvMaxRetries, err)
+		}
+		config.MaxRetries = retries
+	}
+
+	return nil
+}
+
+// parseEnvDuration parses a Go duration string or a number of seconds.
+func parseEnvDuration(v string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(v)
+}
@@ -0,0 +1,45 @@
+package copilot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/client"
+)
+
+func TestApplyEnv(t *testing.T) {
+	t.Setenv(EnvBaseURL, "https://copilot.example.com")
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvTimeout, "45")
+	t.Setenv(EnvRetryWaitMax, "2m")
+	t.Setenv(EnvMaxRetries, "5")
+
+	config := client.DefaultConfig()
+	if err := applyEnv(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.BaseURL != "https://copilot.example.com" {
+		t.Errorf("expected base URL from env, got %s", config.BaseURL)
+	}
+	if config.APIKey != "env-key" {
+		t.Errorf("expected API key from env, got %s", config.APIKey)
+	}
+	if config.Timeout != 45*time.Second {
+		t.Errorf("expected 45s timeout, got %v", config.Timeout)
+	}
+	if config.RetryWaitMax != 2*time.Minute {
+		t.Errorf("expected 2m retry wait max, got %v", config.RetryWaitMax)
+	}
+	if config.MaxRetries != 5 {
+		t.Errorf("expected 5 retries, got %d", config.MaxRetries)
+	}
+}
+
+func TestNewFromEnvInvalid(t *testing.T) {
+	t.Setenv(EnvMaxRetries, "lots")
+
+	if _, err := NewFromEnv(); err == nil {
+		t.Error("expected error for invalid COPILOT_MAX_RETRIES")
+	}
+}
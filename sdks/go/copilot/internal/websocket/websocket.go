@@ -0,0 +1,368 @@
+// Package websocket implements the parts of the WebSocket protocol
+// (RFC 6455) used by the SDK's realtime channels: the opening handshake,
+// text and binary messages, ping/pong and the closing handshake.
+// Extensions and subprotocols are not supported.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Message types.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+)
+
+// Control and continuation opcodes.
+const (
+	opContinuation = 0
+	opClose        = 8
+	opPing         = 9
+	opPong         = 10
+)
+
+// Close status codes.
+const (
+	CloseNormal    = 1000
+	CloseGoingAway = 1001
+	CloseProtocol  = 1002
+	CloseNoStatus  = 1005
+	CloseTooLarge  = 1009
+)
+
+// DefaultMaxMessageSize bounds the size of a received message.
+const DefaultMaxMessageSize = 16 << 20
+
+// acceptGUID is appended to the handshake key to compute the accept value.
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// ErrClosed is returned when writing to a connection that is closing.
+var ErrClosed = errors.New("websocket: connection closed")
+
+// ErrBadHandshake is returned when the server does not complete the
+// opening handshake.
+var ErrBadHandshake = errors.New("websocket: bad handshake")
+
+// CloseError is returned by ReadMessage once the peer has closed the
+// connection.
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("websocket: closed with status %d", e.Code)
+	}
+	return fmt.Sprintf("websocket: closed with status %d: %s", e.Code, e.Reason)
+}
+
+// Conn is a WebSocket connection. One goroutine may read while others
+// write.
+type Conn struct {
+	rwc    io.ReadWriteCloser
+	br     *bufio.Reader
+	client bool
+
+	// MaxMessageSize bounds the size of a received message.
+	MaxMessageSize int64
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+	closeSent bool
+}
+
+// PrepareRequest turns req into an opening handshake request and returns
+// the key to verify the response with.
+func PrepareRequest(req *http.Request) (string, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", fmt.Errorf("websocket: failed to generate key: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	return key, nil
+}
+
+// NewClientConn completes the client side of the handshake from the
+// response to a request prepared with PrepareRequest. The response must
+// come from a net/http client, whose body is then the raw connection.
+func NewClientConn(resp *http.Response, key string) (*Conn, error) {
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("%w: status %d", ErrBadHandshake, resp.StatusCode)
+	}
+	if !headerContains(resp.Header, "Upgrade", "websocket") ||
+		resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		resp.Body.Close()
+		return nil, ErrBadHandshake
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: connection is not writable", ErrBadHandshake)
+	}
+	return newConn(rwc, bufio.NewReader(rwc), true), nil
+}
+
+// Upgrade completes the server side of the handshake.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket handshake expected", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, ErrBadHandshake
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, fmt.Errorf("%w: connection cannot be hijacked", ErrBadHandshake)
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return newConn(conn, rw.Reader, false), nil
+}
+
+func newConn(rwc io.ReadWriteCloser, br *bufio.Reader, client bool) *Conn {
+	return &Conn{rwc: rwc, br: br, client: client, MaxMessageSize: DefaultMaxMessageSize}
+}
+
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerContains reports whether the comma-separated header contains
+// token, ignoring case.
+func headerContains(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadMessage returns the next text or binary message, answering pings
+// along the way. Once the peer closes the connection it returns a
+// *CloseError.
+func (c *Conn) ReadMessage() (messageType int, data []byte, err error) {
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			return 0, nil, c.handleClose(payload)
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, c.fail(CloseProtocol, "expected continuation frame")
+			}
+			messageType = op
+		case opContinuation:
+			if messageType == 0 {
+				return 0, nil, c.fail(CloseProtocol, "unexpected continuation frame")
+			}
+		default:
+			return 0, nil, c.fail(CloseProtocol, fmt.Sprintf("unknown opcode %d", op))
+		}
+
+		if int64(len(data))+int64(len(payload)) > c.MaxMessageSize {
+			return 0, nil, c.fail(CloseTooLarge, "message too large")
+		}
+		data = append(data, payload...)
+		if fin {
+			return messageType, data, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (fin bool, op int, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	op = int(header[0] & 0x0f)
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, c.fail(CloseProtocol, "reserved bits set")
+	}
+	masked := header[1]&0x80 != 0
+	if masked == c.client {
+		return false, 0, nil, c.fail(CloseProtocol, "bad frame masking")
+	}
+
+	n := uint64(header[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if op >= opClose && (n > 125 || !fin) {
+		return false, 0, nil, c.fail(CloseProtocol, "bad control frame")
+	}
+	if n > uint64(c.MaxMessageSize) {
+		return false, 0, nil, c.fail(CloseTooLarge, "message too large")
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		maskBytes(mask, payload)
+	}
+	return fin, op, payload, nil
+}
+
+// handleClose answers a close frame and returns the resulting error.
+func (c *Conn) handleClose(payload []byte) error {
+	closeErr := &CloseError{Code: CloseNoStatus}
+	if len(payload) >= 2 {
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Reason = string(payload[2:])
+	}
+	c.writeClose(closeErr.Code, "")
+	c.rwc.Close()
+	return closeErr
+}
+
+// fail closes the connection after a protocol violation.
+func (c *Conn) fail(code int, reason string) error {
+	c.writeClose(code, reason)
+	c.rwc.Close()
+	return fmt.Errorf("websocket: %s", reason)
+}
+
+// WriteMessage sends a text or binary message in a single frame.
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("websocket: invalid message type %d", messageType)
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// Ping sends a ping. The peer's pong is consumed by ReadMessage.
+func (c *Conn) Ping(data []byte) error {
+	return c.writeFrame(opPing, data)
+}
+
+func (c *Conn) writeFrame(op int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closeSent {
+		return ErrClosed
+	}
+	if op == opClose {
+		c.closeSent = true
+	}
+
+	frame := make([]byte, 0, len(payload)+14)
+	frame = append(frame, 0x80|byte(op))
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = binary.BigEndian.AppendUint16(append(frame, maskBit|126), uint16(n))
+	default:
+		frame = binary.BigEndian.AppendUint64(append(frame, maskBit|127), uint64(n))
+	}
+	if c.client {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		maskBytes(mask, frame[start:])
+	} else {
+		frame = append(frame, payload...)
+	}
+	_, err := c.rwc.Write(frame)
+	return err
+}
+
+func (c *Conn) writeClose(code int, reason string) error {
+	var payload []byte
+	if code != CloseNoStatus {
+		payload = binary.BigEndian.AppendUint16(nil, uint16(code))
+		payload = append(payload, reason...)
+	}
+	return c.writeFrame(opClose, payload)
+}
+
+// Close sends a normal close frame and closes the connection without
+// waiting for the peer's reply.
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.writeClose(CloseNormal, "")
+		err = c.rwc.Close()
+	})
+	return err
+}
+
+func maskBytes(mask [4]byte, b []byte) {
+	for i := range b {
+		b[i] ^= mask[i&3]
+	}
+}
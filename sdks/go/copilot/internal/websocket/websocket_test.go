@@ -0,0 +1,136 @@
+package websocket
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// dial connects to an httptest server running handler.
+func dial(t *testing.T, handler func(*Conn)) *Conn {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		handler(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	key, err := PrepareRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := NewClientConn(resp, key)
+	if err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestEcho(t *testing.T) {
+	conn := dial(t, func(conn *Conn) {
+		defer conn.Close()
+		for {
+			op, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			conn.WriteMessage(op, data)
+		}
+	})
+
+	large := bytes.Repeat([]byte("x"), 70000)
+	for _, msg := range [][]byte{[]byte("hello"), bytes.Repeat([]byte("y"), 300), large} {
+		if err := conn.WriteMessage(TextMessage, msg); err != nil {
+			t.Fatal(err)
+		}
+		op, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op != TextMessage || !bytes.Equal(data, msg) {
+			t.Errorf("expected echo of %d bytes, got op %d and %d bytes", len(msg), op, len(data))
+		}
+	}
+}
+
+func TestFragmentsPingsAndClose(t *testing.T) {
+	conn := dial(t, func(conn *Conn) {
+		// A fragmented message interleaved with a ping, then a close.
+		conn.writeMu.Lock()
+		conn.rwc.Write([]byte{0x01, 0x03, 'a', 'b', 'c'})
+		conn.rwc.Write([]byte{0x89, 0x01, 'p'})
+		conn.rwc.Write([]byte{0x80, 0x02, 'd', 'e'})
+		conn.writeMu.Unlock()
+		conn.writeClose(CloseGoingAway, "bye")
+		// Wait for the pong and the close reply.
+		conn.ReadMessage()
+	})
+
+	op, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op != TextMessage || string(data) != "abcde" {
+		t.Errorf("expected reassembled text 'abcde', got op %d %q", op, data)
+	}
+
+	_, _, err = conn.ReadMessage()
+	var closeErr *CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != CloseGoingAway || closeErr.Reason != "bye" {
+		t.Errorf("expected close error 1001 'bye', got %v", err)
+	}
+	if err := conn.WriteMessage(TextMessage, []byte("late")); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after close, got %v", err)
+	}
+}
+
+func TestMessageTooLarge(t *testing.T) {
+	conn := dial(t, func(conn *Conn) {
+		conn.WriteMessage(BinaryMessage, make([]byte, 200))
+		conn.ReadMessage()
+	})
+	conn.MaxMessageSize = 100
+
+	if _, _, err := conn.ReadMessage(); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("expected message too large error, got %v", err)
+	}
+}
+
+func TestBadHandshake(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	key, _ := PrepareRequest(req)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if _, err := NewClientConn(resp, key); !errors.Is(err, ErrBadHandshake) {
+		t.Errorf("expected ErrBadHandshake, got %v", err)
+	}
+
+	// A server rejects plain requests.
+	rec := httptest.NewRecorder()
+	if _, err := Upgrade(rec, httptest.NewRequest(http.MethodGet, "/", nil)); !errors.Is(err, ErrBadHandshake) {
+		t.Errorf("expected ErrBadHandshake, got %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// TimeRange represents a half-open time interval. Zero bounds are unbounded.
+type TimeRange struct {
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+// AuditEvent represents an entry in the audit log.
+type AuditEvent struct {
+	ID            string                 `json:"id"`
+	Actor         string                 `json:"actor"`
+	ActorType     string                 `json:"actor_type,omitempty"`
+	Action        string                 `json:"action"`
+	Resource      string                 `json:"resource"`
+	ResourceID    string                 `json:"resource_id,omitempty"`
+	Outcome       string                 `json:"outcome,omitempty"`
+	IPAddress     string                 `json:"ip_address,omitempty"`
+	UserAgent     string                 `json:"user_agent,omitempty"`
+	TenantID      string                 `json:"tenant_id,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp     Timestamp              `json:"timestamp"`
+}
+
+// AuditQuery filters audit log listings.
+type AuditQuery struct {
+	Actor     string
+	Action    string
+	Resource  string
+	TimeRange TimeRange
+	// Cursor is the NextCursor of a previous page; empty for the first page.
+	Cursor string
+	Limit  int
+}
@@ -0,0 +1,29 @@
+package models
+
+// BulkDeleteRequest represents a request to delete several resources.
+type BulkDeleteRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// BulkDeleteResult reports the outcome for one resource of a bulk delete.
+type BulkDeleteResult struct {
+	ID      string    `json:"id"`
+	Deleted bool      `json:"deleted"`
+	Error   *APIError `json:"error,omitempty"`
+}
+
+// BulkDeleteResponse represents the per-item results of a bulk delete.
+type BulkDeleteResponse struct {
+	Results []BulkDeleteResult `json:"results"`
+}
+
+// Failed returns the results for resources that were not deleted.
+func (r *BulkDeleteResponse) Failed() []BulkDeleteResult {
+	var failed []BulkDeleteResult
+	for _, result := range r.Results {
+		if !result.Deleted {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
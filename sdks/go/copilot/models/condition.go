@@ -0,0 +1,328 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidCondition is returned when a condition expression does not
+// parse.
+var ErrInvalidCondition = errors.New("copilot: invalid condition")
+
+// Config keys of a condition step.
+const (
+	ConditionExpressionKey = "expression"
+	ConditionTrueStepsKey  = "true_steps"
+	ConditionFalseStepsKey = "false_steps"
+)
+
+// Condition is a boolean expression over run variables, evaluated by a
+// condition step. Variables are dotted paths such as input.score or
+// steps.review.output.approved; they are compared with ==, !=, <, <=, >
+// and >= to other variables or to JSON literals, and combined with &&, ||,
+// ! and parentheses:
+//
+//	input.score >= 0.8 && (input.lang == "en" || !steps.detect.output.ok)
+//
+// Build conditions with Var, And, Or and Not, or check a hand-written one
+// with ParseCondition.
+type Condition string
+
+// String returns the expression.
+func (c Condition) String() string { return string(c) }
+
+// Validate reports whether the expression is well formed.
+func (c Condition) Validate() error {
+	_, err := parseCondition(string(c))
+	return err
+}
+
+// ParseCondition checks the syntax of expr.
+func ParseCondition(expr string) (Condition, error) {
+	if _, err := parseCondition(expr); err != nil {
+		return "", err
+	}
+	return Condition(expr), nil
+}
+
+// Operand is a variable or literal in a comparison.
+type Operand struct {
+	expr string
+}
+
+// Var refers to the run variable at path, e.g. "input.score".
+func Var(path string) Operand {
+	return Operand{expr: path}
+}
+
+// Literal is a constant value, encoded as JSON.
+func Literal(v interface{}) Operand {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Left unparseable so that Validate reports it.
+		return Operand{expr: fmt.Sprintf("<%T>", v)}
+	}
+	return Operand{expr: string(data)}
+}
+
+// operand returns v as an Operand, treating anything but an Operand as a
+// literal.
+func operand(v interface{}) Operand {
+	if o, ok := v.(Operand); ok {
+		return o
+	}
+	return Literal(v)
+}
+
+func (o Operand) compare(op string, v interface{}) Condition {
+	return Condition(o.expr + " " + op + " " + operand(v).expr)
+}
+
+// Eq compares the operand to v, an Operand or a literal value.
+func (o Operand) Eq(v interface{}) Condition { return o.compare("==", v) }
+
+// Ne is the negation of Eq.
+func (o Operand) Ne(v interface{}) Condition { return o.compare("!=", v) }
+
+// Lt is a less-than comparison.
+func (o Operand) Lt(v interface{}) Condition { return o.compare("<", v) }
+
+// Le is a less-than-or-equal comparison.
+func (o Operand) Le(v interface{}) Condition { return o.compare("<=", v) }
+
+// Gt is a greater-than comparison.
+func (o Operand) Gt(v interface{}) Condition { return o.compare(">", v) }
+
+// Ge is a greater-than-or-equal comparison.
+func (o Operand) Ge(v interface{}) Condition { return o.compare(">=", v) }
+
+// True tests the operand for truthiness.
+func (o Operand) True() Condition { return Condition(o.expr) }
+
+// And holds when all of conds hold.
+func And(conds ...Condition) Condition { return join("&&", precAnd, conds) }
+
+// Or holds when any of conds holds.
+func Or(conds ...Condition) Condition { return join("||", precOr, conds) }
+
+// Not holds when c does not.
+func Not(c Condition) Condition {
+	return Condition("!" + group(c, precPrimary))
+}
+
+// join joins conds with op, parenthesizing those binding less tightly.
+func join(op string, prec int, conds []Condition) Condition {
+	parts := make([]string, len(conds))
+	for i, c := range conds {
+		parts[i] = group(c, prec)
+	}
+	return Condition(strings.Join(parts, " "+op+" "))
+}
+
+// group parenthesizes c unless it binds at least as tightly as prec.
+func group(c Condition, prec int) string {
+	node, err := parseCondition(string(c))
+	if err == nil && node.prec >= prec {
+		return string(c)
+	}
+	return "(" + string(c) + ")"
+}
+
+// Precedence of condition expressions, from loosest to tightest.
+const (
+	precOr = iota + 1
+	precAnd
+	precComparison
+	precPrimary
+)
+
+// conditionNode is what the parser needs to know of a parsed expression.
+type conditionNode struct {
+	prec int
+}
+
+// conditionParser is a recursive-descent parser of the grammar:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | comparison
+//	comparison = primary [ op primary ]
+//	primary    = "(" or ")" | variable | literal
+type conditionParser struct {
+	src string
+	pos int
+}
+
+func parseCondition(src string) (conditionNode, error) {
+	p := &conditionParser{src: src}
+	p.skipSpace()
+	if p.pos == len(src) {
+		return conditionNode{}, fmt.Errorf("%w: empty expression", ErrInvalidCondition)
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return conditionNode{}, err
+	}
+	if p.pos < len(src) {
+		return conditionNode{}, p.errorf("unexpected %q", p.rest())
+	}
+	return node, nil
+}
+
+func (p *conditionParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidCondition, fmt.Sprintf(format, args...), p.pos)
+}
+
+// rest returns the start of the unparsed input, for error messages.
+func (p *conditionParser) rest() string {
+	rest := p.src[p.pos:]
+	if len(rest) > 10 {
+		rest = rest[:10]
+	}
+	return rest
+}
+
+func (p *conditionParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// accept consumes tok if it comes next.
+func (p *conditionParser) accept(tok string) bool {
+	if !strings.HasPrefix(p.src[p.pos:], tok) {
+		return false
+	}
+	p.pos += len(tok)
+	p.skipSpace()
+	return true
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	node, err := p.parseAnd()
+	for err == nil && p.accept("||") {
+		if _, err = p.parseAnd(); err == nil {
+			node.prec = precOr
+		}
+	}
+	return node, err
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	node, err := p.parseUnary()
+	for err == nil && p.accept("&&") {
+		if _, err = p.parseUnary(); err == nil {
+			node.prec = precAnd
+		}
+	}
+	return node, err
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	if p.accept("!") {
+		if _, err := p.parseUnary(); err != nil {
+			return conditionNode{}, err
+		}
+		return conditionNode{prec: precPrimary}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return node, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.accept(op) {
+			if _, err := p.parsePrimary(); err != nil {
+				return node, err
+			}
+			return conditionNode{prec: precComparison}, nil
+		}
+	}
+	return node, nil
+}
+
+func (p *conditionParser) parsePrimary() (conditionNode, error) {
+	if p.pos == len(p.src) {
+		return conditionNode{}, p.errorf("unexpected end of expression")
+	}
+	c := p.src[p.pos]
+	switch {
+	case c == '(':
+		p.accept("(")
+		if _, err := p.parseOr(); err != nil {
+			return conditionNode{}, err
+		}
+		if !p.accept(")") {
+			return conditionNode{}, p.errorf("missing )")
+		}
+		return conditionNode{prec: precPrimary}, nil
+	case c == '"' || c == '-' || c >= '0' && c <= '9':
+		// A JSON string or number; json.Decoder finds where it ends.
+		dec := json.NewDecoder(strings.NewReader(p.src[p.pos:]))
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return conditionNode{}, p.errorf("invalid literal")
+		}
+		p.pos += int(dec.InputOffset())
+	case isIdentStart(c):
+		p.parsePath()
+	default:
+		return conditionNode{}, p.errorf("unexpected %q", p.rest())
+	}
+	p.skipSpace()
+	return conditionNode{prec: precPrimary}, nil
+}
+
+// parsePath consumes a variable path of identifiers and indexes joined by
+// dots, or one of the literals true, false and null.
+func (p *conditionParser) parsePath() {
+	for {
+		for p.pos < len(p.src) && isIdentPart(p.src[p.pos]) {
+			p.pos++
+		}
+		if p.pos+1 < len(p.src) && p.src[p.pos] == '.' && isIdentPart(p.src[p.pos+1]) {
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// NewConditionStep returns a condition step that continues with ifTrue or
+// ifFalse depending on cond.
+func NewConditionStep(id string, cond Condition, ifTrue, ifFalse []string) WorkflowStep {
+	return WorkflowStep{
+		ID:   id,
+		Type: StepTypeCondition,
+		Config: map[string]interface{}{
+			ConditionExpressionKey: cond,
+			ConditionTrueStepsKey:  ifTrue,
+			ConditionFalseStepsKey: ifFalse,
+		},
+	}
+}
+
+// Condition returns the expression of a condition step.
+func (s *WorkflowStep) Condition() (Condition, bool) {
+	switch expr := s.Config[ConditionExpressionKey].(type) {
+	case Condition:
+		return expr, true
+	case string:
+		return Condition(expr), true
+	}
+	return "", false
+}
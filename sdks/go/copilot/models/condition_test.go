@@ -0,0 +1,101 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConditionBuilder(t *testing.T) {
+	tests := []struct {
+		cond Condition
+		want string
+	}{
+		{Var("input.score").Ge(0.8), `input.score >= 0.8`},
+		{Var("input.lang").Eq("en"), `input.lang == "en"`},
+		{Var("a").Ne(Var("b")), `a != b`},
+		{Var("steps.check.output.ok").True(), `steps.check.output.ok`},
+		{Var("input.note").Eq(nil), `input.note == null`},
+		{And(Var("a").Lt(1), Var("b").Gt(2)), `a < 1 && b > 2`},
+		{And(Var("a").Le(1), Or(Var("b").True(), Var("c").True())), `a <= 1 && (b || c)`},
+		{Or(And(Var("a").True(), Var("b").True()), Var("c").True()), `a && b || c`},
+		{Not(Var("a").Eq(1)), `!(a == 1)`},
+		{Not(Var("a").True()), `!a`},
+		{Var("input.name").Eq(`say "hi"`), `input.name == "say \"hi\""`},
+	}
+	for _, tt := range tests {
+		if string(tt.cond) != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, tt.cond)
+		}
+		if err := tt.cond.Validate(); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.cond, err)
+		}
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	valid := []string{
+		`input.score >= 0.8 && (input.lang == "en" || !steps.detect.output.ok)`,
+		`items.0.count > -3.5e2`,
+		`true`,
+		`!!(a)`,
+		` x == "a && b" `,
+		`(a == b) != false`,
+	}
+	for _, expr := range valid {
+		if _, err := ParseCondition(expr); err != nil {
+			t.Errorf("%s: unexpected error: %v", expr, err)
+		}
+	}
+
+	invalid := []string{
+		``,
+		`a ==`,
+		`a = 1`,
+		`a < b < c`,
+		`(a == 1`,
+		`a && || b`,
+		`a.`,
+		`1abc`,
+		`"unterminated`,
+		`a == {}`,
+		`a & b`,
+	}
+	for _, expr := range invalid {
+		if _, err := ParseCondition(expr); !errors.Is(err, ErrInvalidCondition) {
+			t.Errorf("%q: expected ErrInvalidCondition, got %v", expr, err)
+		}
+	}
+	if err := Var("a").Eq(struct{ C chan int }{}).Validate(); !errors.Is(err, ErrInvalidCondition) {
+		t.Errorf("expected an unencodable literal to be invalid, got %v", err)
+	}
+}
+
+func TestConditionStep(t *testing.T) {
+	step := NewConditionStep("gate", Var("input.score").Gt(0.5), []string{"publish"}, []string{"review"})
+	if err := step.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := json.Marshal(step)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var decoded WorkflowStep
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if cond, ok := decoded.Condition(); !ok || cond != "input.score > 0.5" {
+		t.Errorf("expected the condition to round-trip, got %q", cond)
+	}
+
+	decoded.Config[ConditionExpressionKey] = "input.score >"
+	if err := decoded.Validate(); !errors.Is(err, ErrInvalidWorkflow) || !errors.Is(err, ErrInvalidCondition) || !strings.Contains(err.Error(), `"gate"`) {
+		t.Errorf("expected an invalid condition, got %v", err)
+	}
+	delete(decoded.Config, ConditionExpressionKey)
+	if err := decoded.Validate(); !errors.Is(err, ErrInvalidWorkflow) {
+		t.Errorf("expected a missing condition to be invalid, got %v", err)
+	}
+}
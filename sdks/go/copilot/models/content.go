@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ContentPartType represents the kind of a content part.
+type ContentPartType string
+
+const (
+	ContentPartText       ContentPartType = "text"
+	ContentPartImage      ContentPartType = "image"
+	ContentPartToolUse    ContentPartType = "tool_use"
+	ContentPartToolResult ContentPartType = "tool_result"
+)
+
+// ContentPart is one piece of a message's structured content.
+type ContentPart struct {
+	Type ContentPartType `json:"type"`
+	// Text is the text of a text part or the output of a tool result.
+	Text string `json:"text,omitempty"`
+
+	// Image fields. An image is given either inline as base64 Data with
+	// its MediaType, or by URL.
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+
+	// Tool fields. ToolUseID links a tool result to its tool use.
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	ToolName  string          `json:"tool_name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+// TextParts returns the concatenated text of the text parts.
+func TextParts(parts []ContentPart) string {
+	var b strings.Builder
+	for _, part := range parts {
+		if part.Type == ContentPartText {
+			b.WriteString(part.Text)
+		}
+	}
+	return b.String()
+}
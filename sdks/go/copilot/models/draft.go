@@ -0,0 +1,20 @@
+package models
+
+// Draft is unsent user input for a conversation, synced through the API
+// so it follows the user across devices.
+type Draft struct {
+	ConversationID ConversationID `json:"conversation_id"`
+	Content        string         `json:"content"`
+	// Parts holds structured content such as attached images.
+	Parts []ContentPart `json:"parts,omitempty"`
+	// ParentMessageID is set for a draft reply in a thread.
+	ParentMessageID MessageID `json:"parent_message_id,omitempty"`
+	UpdatedAt       Timestamp `json:"updated_at"`
+}
+
+// DraftSave represents a request to save a draft.
+type DraftSave struct {
+	Content         string        `json:"content"`
+	Parts           []ContentPart `json:"parts,omitempty"`
+	ParentMessageID MessageID     `json:"parent_message_id,omitempty"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrInvalidEnvironment is returned when an environment fails client-side
+// validation.
+var ErrInvalidEnvironment = errors.New("copilot: invalid environment")
+
+// Conventional environment names.
+const (
+	EnvironmentDev     = "dev"
+	EnvironmentStaging = "staging"
+	EnvironmentProd    = "prod"
+)
+
+// Environment is a named set of variables, such as the endpoints and model
+// names of a deployment stage, that a run binds with
+// WorkflowRunCreate.Environment. Steps reference variables with EnvRef,
+// so the same definition runs against different configurations.
+type Environment struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Variables   map[string]string `json:"variables"`
+	CreatedAt   Timestamp         `json:"created_at"`
+	UpdatedAt   Timestamp         `json:"updated_at"`
+}
+
+// EnvironmentCreate represents a request to create an environment.
+type EnvironmentCreate struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+// Validate checks the environment's name and variable names.
+func (e *EnvironmentCreate) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("%w: missing name", ErrInvalidEnvironment)
+	}
+	return validateVariables(e.Variables)
+}
+
+// EnvironmentUpdate represents a partial update to an environment. Nil
+// fields are left unchanged; Variables replaces the whole set.
+type EnvironmentUpdate struct {
+	Description *string           `json:"description,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+}
+
+// Validate checks the updated variable names.
+func (e *EnvironmentUpdate) Validate() error {
+	return validateVariables(e.Variables)
+}
+
+func validateVariables(vars map[string]string) error {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	// The first invalid name is reported consistently.
+	sort.Strings(names)
+	for _, name := range names {
+		if !identifier.MatchString(name) {
+			return fmt.Errorf("%w: invalid variable name %q", ErrInvalidEnvironment, name)
+		}
+	}
+	return nil
+}
+
+// EnvRef returns the reference to an environment variable for use in step
+// configuration. The server substitutes the value from the run's
+// environment when the step runs.
+func EnvRef(name string) string {
+	return "{{env." + name + "}}"
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEnvironmentValidate(t *testing.T) {
+	env := EnvironmentCreate{Name: EnvironmentStaging, Variables: map[string]string{"MODEL": "small", "api_base": "https://staging.example.com"}}
+	if err := env.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := []EnvironmentCreate{
+		{},
+		{Name: EnvironmentProd, Variables: map[string]string{"API-BASE": "x"}},
+		{Name: EnvironmentProd, Variables: map[string]string{"1ST": "x"}},
+	}
+	for _, env := range invalid {
+		if err := env.Validate(); !errors.Is(err, ErrInvalidEnvironment) {
+			t.Errorf("%+v: expected ErrInvalidEnvironment, got %v", env, err)
+		}
+	}
+
+	update := EnvironmentUpdate{Variables: map[string]string{"": "x"}}
+	if err := update.Validate(); !errors.Is(err, ErrInvalidEnvironment) {
+		t.Errorf("expected ErrInvalidEnvironment, got %v", err)
+	}
+	if got := EnvRef("MODEL"); got != "{{env.MODEL}}" {
+		t.Errorf("unexpected reference %q", got)
+	}
+}
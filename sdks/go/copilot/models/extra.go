@@ -0,0 +1,165 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Extra holds response fields the SDK does not model yet, keyed by JSON
+// name, so forward-compatible consumers can read them.
+type Extra map[string]json.RawMessage
+
+// Decode unmarshals the extra field key into v and reports whether it was
+// present.
+func (e Extra) Decode(key string, v interface{}) (bool, error) {
+	raw, ok := e[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// knownFieldsCache maps struct types to the JSON names of their fields.
+var knownFieldsCache sync.Map
+
+// knownFields returns the JSON names of t's fields.
+func knownFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			for name := range knownFields(field.Type) {
+				known[name] = true
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = true
+	}
+
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+// extraFields returns the members of the JSON object data that do not map
+// to a field of v's struct type, or nil if there are none.
+func extraFields(data []byte, v interface{}) (Extra, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	known := knownFields(reflect.TypeOf(v).Elem())
+	var extra Extra
+	for key, raw := range all {
+		if known[key] {
+			continue
+		}
+		if extra == nil {
+			extra = make(Extra)
+		}
+		extra[key] = raw
+	}
+	return extra, nil
+}
+
+// UnmarshalJSON decodes a message, keeping unknown fields in Extra.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type plain Message
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, (*plain)(m))
+	m.Extra = extra
+	return err
+}
+
+// UnmarshalJSON decodes a conversation, keeping unknown fields in Extra.
+func (c *Conversation) UnmarshalJSON(data []byte) error {
+	type plain Conversation
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, (*plain)(c))
+	c.Extra = extra
+	return err
+}
+
+// UnmarshalJSON decodes a workflow, keeping unknown fields in Extra.
+func (w *WorkflowDefinition) UnmarshalJSON(data []byte) error {
+	type plain WorkflowDefinition
+	if err := json.Unmarshal(data, (*plain)(w)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, (*plain)(w))
+	w.Extra = extra
+	return err
+}
+
+// UnmarshalJSON decodes a workflow run, keeping unknown fields in Extra.
+func (r *WorkflowRun) UnmarshalJSON(data []byte) error {
+	type plain WorkflowRun
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, (*plain)(r))
+	r.Extra = extra
+	return err
+}
+
+// UnmarshalJSON decodes a context item, keeping unknown fields in Extra.
+func (c *ContextItem) UnmarshalJSON(data []byte) error {
+	type plain ContextItem
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, (*plain)(c))
+	c.Extra = extra
+	return err
+}
+
+// UnmarshalJSON decodes a user, keeping unknown fields in Extra.
+func (u *User) UnmarshalJSON(data []byte) error {
+	type plain User
+	if err := json.Unmarshal(data, (*plain)(u)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, (*plain)(u))
+	u.Extra = extra
+	return err
+}
+
+// UnmarshalJSON decodes a conversation with its expanded messages. The
+// embedded Conversation's decoder would otherwise consume the whole object
+// and leave the messages in Extra.
+func (c *ConversationWithMessages) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &c.Conversation); err != nil {
+		return err
+	}
+	delete(c.Extra, "messages")
+	if len(c.Extra) == 0 {
+		c.Extra = nil
+	}
+
+	var expanded struct {
+		Messages []Message `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &expanded); err != nil {
+		return err
+	}
+	c.Messages = expanded.Messages
+	return nil
+}
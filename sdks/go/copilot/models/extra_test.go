@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnknownFieldsPreserved(t *testing.T) {
+	data := []byte(`{
+		"id": "conv-1",
+		"title": "Planning",
+		"message_count": 2,
+		"sentiment": {"score": 0.8},
+		"priority": "high"
+	}`)
+
+	var conv Conversation
+	if err := json.Unmarshal(data, &conv); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conv.ID != "conv-1" || conv.MessageCount != 2 {
+		t.Errorf("expected known fields to decode, got %+v", conv)
+	}
+	if len(conv.Extra) != 2 {
+		t.Fatalf("expected 2 extra fields, got %v", conv.Extra)
+	}
+
+	var sentiment struct {
+		Score float64 `json:"score"`
+	}
+	if ok, err := conv.Extra.Decode("sentiment", &sentiment); !ok || err != nil || sentiment.Score != 0.8 {
+		t.Errorf("expected sentiment extra, got %v, %v, %+v", ok, err, sentiment)
+	}
+	if ok, _ := conv.Extra.Decode("missing", &sentiment); ok {
+		t.Error("expected missing extra to report false")
+	}
+
+	var msgs []Message
+	if err := json.Unmarshal([]byte(`[{"id":"msg-1","content":"hi"}]`), &msgs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msgs[0].Extra != nil {
+		t.Errorf("expected no extras for fully modeled message, got %v", msgs[0].Extra)
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// FeedbackRating represents a thumbs-up/down rating on a message.
+type FeedbackRating string
+
+const (
+	RatingThumbsUp   FeedbackRating = "thumbs_up"
+	RatingThumbsDown FeedbackRating = "thumbs_down"
+)
+
+// Feedback represents a request to submit feedback on an assistant message.
+type Feedback struct {
+	Rating     FeedbackRating         `json:"rating"`
+	Categories []string               `json:"categories,omitempty"`
+	Comment    string                 `json:"comment,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// MessageFeedback represents feedback recorded against a message.
+type MessageFeedback struct {
+	ID             string                 `json:"id"`
+	MessageID      MessageID              `json:"message_id"`
+	ConversationID ConversationID         `json:"conversation_id"`
+	UserID         string                 `json:"user_id"`
+	Rating         FeedbackRating         `json:"rating"`
+	Categories     []string               `json:"categories,omitempty"`
+	Comment        string                 `json:"comment,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt      Timestamp              `json:"created_at"`
+}
+
+// FeedbackQuery filters feedback listings and exports.
+type FeedbackQuery struct {
+	ConversationID ConversationID
+	Rating         FeedbackRating
+	Category       string
+	From           time.Time
+	To             time.Time
+	Limit          int
+	Offset         int
+	// Cursor resumes listing after the page that returned it; it takes
+	// precedence over Offset.
+	Cursor string
+}
+
+// FeedbackExportFormat represents the file format of a feedback export.
+type FeedbackExportFormat string
+
+const (
+	FeedbackExportJSONL FeedbackExportFormat = "jsonl"
+	FeedbackExportCSV   FeedbackExportFormat = "csv"
+)
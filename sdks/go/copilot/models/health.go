@@ -0,0 +1,43 @@
+package models
+
+import "encoding/json"
+
+// Health states reported by the server and its components.
+const (
+	HealthHealthy   = "healthy"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+// HealthStatus represents health status response.
+type HealthStatus struct {
+	Status        string                     `json:"status"`
+	Version       string                     `json:"version"`
+	UptimeSeconds float64                    `json:"uptime_seconds"`
+	Components    map[string]ComponentHealth `json:"components,omitempty"`
+}
+
+// Healthy reports whether the server considers itself healthy.
+func (s *HealthStatus) Healthy() bool {
+	return s.Status == HealthHealthy
+}
+
+// ComponentHealth represents the health of one dependency of the server,
+// such as the database or the LLM provider.
+type ComponentHealth struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// UnmarshalJSON accepts both the detailed object and the bare status
+// string sent by older servers.
+func (c *ComponentHealth) UnmarshalJSON(data []byte) error {
+	var status string
+	if err := json.Unmarshal(data, &status); err == nil {
+		*c = ComponentHealth{Status: status}
+		return nil
+	}
+	type plain ComponentHealth
+	return json.Unmarshal(data, (*plain)(c))
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidID is returned when an identifier is empty or contains
+// characters that cannot appear in a request path.
+var ErrInvalidID = errors.New("copilot: invalid id")
+
+// maxIDLength bounds identifiers accepted by the API.
+const maxIDLength = 256
+
+// ConversationID identifies a conversation.
+type ConversationID string
+
+// MessageID identifies a message within a conversation.
+type MessageID string
+
+// WorkflowID identifies a workflow definition.
+type WorkflowID string
+
+// RunID identifies a workflow run.
+type RunID string
+
+// String returns the ID as a plain string.
+func (id ConversationID) String() string { return string(id) }
+
+// Validate reports whether id is usable in a request.
+func (id ConversationID) Validate() error { return validateID("conversation", string(id)) }
+
+// MarshalJSON implements json.Marshaler.
+func (id ConversationID) MarshalJSON() ([]byte, error) { return marshalID("conversation", string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *ConversationID) UnmarshalJSON(data []byte) error {
+	return unmarshalID("conversation", data, (*string)(id))
+}
+
+// String returns the ID as a plain string.
+func (id MessageID) String() string { return string(id) }
+
+// Validate reports whether id is usable in a request.
+func (id MessageID) Validate() error { return validateID("message", string(id)) }
+
+// MarshalJSON implements json.Marshaler.
+func (id MessageID) MarshalJSON() ([]byte, error) { return marshalID("message", string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *MessageID) UnmarshalJSON(data []byte) error {
+	return unmarshalID("message", data, (*string)(id))
+}
+
+// String returns the ID as a plain string.
+func (id WorkflowID) String() string { return string(id) }
+
+// Validate reports whether id is usable in a request.
+func (id WorkflowID) Validate() error { return validateID("workflow", string(id)) }
+
+// MarshalJSON implements json.Marshaler.
+func (id WorkflowID) MarshalJSON() ([]byte, error) { return marshalID("workflow", string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *WorkflowID) UnmarshalJSON(data []byte) error {
+	return unmarshalID("workflow", data, (*string)(id))
+}
+
+// String returns the ID as a plain string.
+func (id RunID) String() string { return string(id) }
+
+// Validate reports whether id is usable in a request.
+func (id RunID) Validate() error { return validateID("run", string(id)) }
+
+// MarshalJSON implements json.Marshaler.
+func (id RunID) MarshalJSON() ([]byte, error) { return marshalID("run", string(id)) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *RunID) UnmarshalJSON(data []byte) error {
+	return unmarshalID("run", data, (*string)(id))
+}
+
+// ValidateContextItemID reports whether id, which names a context item, is
+// usable in a request.
+func ValidateContextItemID(id string) error { return validateID("context item", id) }
+
+// validateID rejects empty IDs and IDs that would change the meaning of a
+// request path.
+func validateID(kind, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: empty %s id", ErrInvalidID, kind)
+	}
+	if len(id) > maxIDLength {
+		return fmt.Errorf("%w: %s id longer than %d bytes", ErrInvalidID, kind, maxIDLength)
+	}
+	if i := strings.IndexFunc(id, func(r rune) bool {
+		return r <= ' ' || r == 0x7f || strings.ContainsRune("/?#%\\", r)
+	}); i >= 0 {
+		return fmt.Errorf("%w: %s id %q contains %q", ErrInvalidID, kind, id, id[i])
+	}
+	return nil
+}
+
+// marshalID encodes id as a JSON string. Empty IDs encode as "" so optional
+// fields keep their zero value.
+func marshalID(kind, id string) ([]byte, error) {
+	if id != "" {
+		if err := validateID(kind, id); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(id)
+}
+
+// unmarshalID decodes a JSON string or number into dst. Numeric IDs are
+// accepted because some deployments use integer keys.
+func unmarshalID(kind string, data []byte, dst *string) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("%w: %s id must be a string or number", ErrInvalidID, kind)
+		}
+		s = n.String()
+	}
+	if s != "" {
+		if err := validateID(kind, s); err != nil {
+			return err
+		}
+	}
+	*dst = s
+	return nil
+}
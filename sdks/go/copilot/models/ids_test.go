@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestIDValidate(t *testing.T) {
+	valid := []ConversationID{"conv-1", "3f2b9c1e-8a4d-4e2a-9b1c-7d6e5f4a3b2c", "ns:conv_1.2"}
+	for _, id := range valid {
+		if err := id.Validate(); err != nil {
+			t.Errorf("Validate(%q) = %v, want nil", id, err)
+		}
+	}
+
+	invalid := []ConversationID{"", "conv/1", "conv?x=1", "conv#1", "conv 1", "conv%2F1", "../admin"}
+	for _, id := range invalid {
+		if err := id.Validate(); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Validate(%q) = %v, want ErrInvalidID", id, err)
+		}
+	}
+}
+
+func TestIDJSON(t *testing.T) {
+	var run WorkflowRun
+	data := []byte(`{"id": 42, "workflow_id": "wf-1", "replay_of": null}`)
+	if err := json.Unmarshal(data, &run); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.ID != "42" || run.WorkflowID != "wf-1" || run.ReplayOf != "" {
+		t.Errorf("unexpected IDs: %+v", run)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id": "run/1"}`), &run); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID for malformed id, got %v", err)
+	}
+
+	out, err := json.Marshal(WorkflowRunCreate{WorkflowID: "wf-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"workflow_id":"wf-1"}` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+
+	if _, err := json.Marshal(WorkflowRunCreate{WorkflowID: "wf 1"}); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("expected ErrInvalidID when marshaling malformed id, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+// Label tags conversations so that large workspaces can organize them.
+// Labels with a parent act as nested folders.
+type Label struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+	// ParentID is the enclosing label when labels are used as folders.
+	ParentID  string    `json:"parent_id,omitempty"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// LabelCreate represents a request to create a label.
+type LabelCreate struct {
+	Name     string `json:"name"`
+	Color    string `json:"color,omitempty"`
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+// LabelUpdate represents a partial update to a label. Nil fields are left
+// unchanged; an empty ParentID moves the label to the top level.
+type LabelUpdate struct {
+	Name     *string `json:"name,omitempty"`
+	Color    *string `json:"color,omitempty"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
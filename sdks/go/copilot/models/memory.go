@@ -0,0 +1,24 @@
+package models
+
+// Memory is a fact an agent stored under a key in a namespace, recalled
+// across conversations.
+type Memory struct {
+	Namespace string      `json:"namespace"`
+	Key       string      `json:"key"`
+	Value     interface{} `json:"value"`
+	// Score is the relevance of the memory to a query, when queried.
+	Score     float64   `json:"score,omitempty"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// MemoryRetrieval asks the server to recall the memories most relevant to
+// a message and add them to the model's context.
+type MemoryRetrieval struct {
+	Namespaces []string `json:"namespaces"`
+	// TopK caps the number of memories recalled; zero uses the server
+	// default.
+	TopK int `json:"top_k,omitempty"`
+	// MinScore drops memories less relevant than this.
+	MinScore float64 `json:"min_score,omitempty"`
+}
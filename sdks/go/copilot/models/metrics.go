@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Metrics measures the execution of a workflow run or step.
+type Metrics struct {
+	DurationMS       int64   `json:"duration_ms"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+}
+
+// Duration returns DurationMS as a time.Duration.
+func (m Metrics) Duration() time.Duration {
+	return time.Duration(m.DurationMS) * time.Millisecond
+}
+
+// TotalTokens returns the prompt and completion tokens together.
+func (m Metrics) TotalTokens() int64 {
+	return m.PromptTokens + m.CompletionTokens
+}
+
+// Add adds other to m.
+func (m *Metrics) Add(other Metrics) {
+	m.DurationMS += other.DurationMS
+	m.PromptTokens += other.PromptTokens
+	m.CompletionTokens += other.CompletionTokens
+	m.CostUSD += other.CostUSD
+}
+
+// TotalMetrics returns the run's metrics, summing those of its steps when
+// the server does not report totals. Summed durations overcount steps that
+// ran in parallel.
+func (r *WorkflowRun) TotalMetrics() Metrics {
+	if r.Metrics != nil {
+		return *r.Metrics
+	}
+	var total Metrics
+	for _, step := range r.Steps {
+		if step.Metrics != nil {
+			total.Add(*step.Metrics)
+		}
+	}
+	return total
+}
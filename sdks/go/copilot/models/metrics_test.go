@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRunMetrics(t *testing.T) {
+	var run WorkflowRun
+	err := json.Unmarshal([]byte(`{
+		"id": "run-1",
+		"status": "completed",
+		"steps": [
+			{"step_id": "draft", "state": "completed", "metrics": {"duration_ms": 1200, "prompt_tokens": 300, "completion_tokens": 100, "cost_usd": 0.004}},
+			{"step_id": "publish", "state": "completed", "metrics": {"duration_ms": 300}},
+			{"step_id": "notify", "state": "skipped"}
+		]
+	}`), &run)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	draft := run.Steps[0].Metrics
+	if draft == nil || draft.Duration() != 1200*time.Millisecond || draft.TotalTokens() != 400 {
+		t.Fatalf("unexpected step metrics %+v", draft)
+	}
+	total := run.TotalMetrics()
+	if total.DurationMS != 1500 || total.PromptTokens != 300 || total.CompletionTokens != 100 || total.CostUSD != 0.004 {
+		t.Errorf("expected the steps to be summed, got %+v", total)
+	}
+
+	run.Metrics = &Metrics{DurationMS: 1000, CostUSD: 0.005}
+	if total := run.TotalMetrics(); total != *run.Metrics {
+		t.Errorf("expected the reported totals, got %+v", total)
+	}
+	if diff := DiffRuns(&run, &run); diff.MetricsA.CostUSD != 0.005 || !diff.Equal() {
+		t.Errorf("expected metrics in an equal diff, got %+v", diff)
+	}
+}
@@ -1,10 +1,6 @@
 // Package models provides data types for the LLM CoPilot SDK.
 package models
 
-import (
-	"time"
-)
-
 // MessageRole represents the role of a message sender.
 type MessageRole string
 
@@ -16,38 +12,102 @@ const (
 
 // Message represents a single message in a conversation.
 type Message struct {
-	ID             string                 `json:"id"`
-	ConversationID string                 `json:"conversation_id"`
+	ID             MessageID              `json:"id"`
+	ConversationID ConversationID         `json:"conversation_id"`
 	Role           MessageRole            `json:"role"`
 	Content        string                 `json:"content"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt      time.Time              `json:"created_at"`
+	CorrelationID  string                 `json:"correlation_id,omitempty"`
+	CreatedAt      Timestamp              `json:"created_at"`
+	// Parts holds the structured content of messages carrying more than
+	// text, such as images or tool calls. Content holds their text.
+	Parts []ContentPart `json:"parts,omitempty"`
+	// ParentMessageID is the message this one replies to in a thread.
+	// Thread replies are kept out of the main conversation context.
+	ParentMessageID MessageID `json:"parent_message_id,omitempty"`
+	// RetrievedContext holds the passages retrieved for the reply when
+	// the message asked for retrieval.
+	RetrievedContext []RetrievedChunk `json:"retrieved_context,omitempty"`
+	// Extra holds response fields not modeled by this struct.
+	Extra Extra `json:"-"`
 }
 
 // MessageCreate represents a request to create a new message.
 type MessageCreate struct {
-	Role     MessageRole            `json:"role,omitempty"`
-	Content  string                 `json:"content"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Role          MessageRole            `json:"role,omitempty"`
+	Content       string                 `json:"content"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	// Parts holds structured content; Content holds its text.
+	Parts []ContentPart `json:"parts,omitempty"`
+	// ParentMessageID starts or continues a thread on that message.
+	ParentMessageID MessageID `json:"parent_message_id,omitempty"`
+	// Memory recalls agent memories relevant to the message.
+	Memory *MemoryRetrieval `json:"memory,omitempty"`
+	// Retrieval attaches relevant passages of the context items.
+	Retrieval *RetrievalOptions `json:"retrieval,omitempty"`
 }
 
 // Conversation represents a conversation session.
 type Conversation struct {
-	ID           string                 `json:"id"`
-	Title        string                 `json:"title,omitempty"`
+	ID    ConversationID `json:"id"`
+	Title string         `json:"title,omitempty"`
+	// SystemPrompt is the conversation's current system prompt.
+	SystemPrompt string                 `json:"system_prompt,omitempty"`
 	UserID       string                 `json:"user_id"`
 	TenantID     string                 `json:"tenant_id,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 	MessageCount int                    `json:"message_count"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
+	// CorrelationID ties the conversation to the user action that created it.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// HandoffStatus reports whether the conversation has been escalated to a human agent.
+	HandoffStatus HandoffStatus `json:"handoff_status,omitempty"`
+	// Handoff holds the escalation details when a handoff has been requested.
+	Handoff *Handoff `json:"handoff,omitempty"`
+	// Labels holds the IDs of the labels applied to the conversation.
+	Labels    []string  `json:"labels,omitempty"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+	// Extra holds response fields not modeled by this struct.
+	Extra Extra `json:"-"`
+}
+
+// ConversationWithMessages is a conversation returned together with its
+// first messages.
+type ConversationWithMessages struct {
+	Conversation
+	Messages []Message `json:"messages"`
+}
+
+// HandoffStatus represents the human handoff state of a conversation.
+type HandoffStatus string
+
+const (
+	HandoffStatusNone      HandoffStatus = "none"
+	HandoffStatusRequested HandoffStatus = "requested"
+	HandoffStatusAssigned  HandoffStatus = "assigned"
+	HandoffStatusResolved  HandoffStatus = "resolved"
+)
+
+// Handoff represents an escalation of a conversation to a human agent.
+type Handoff struct {
+	Reason      string     `json:"reason,omitempty"`
+	AgentID     string     `json:"agent_id,omitempty"`
+	Resolution  string     `json:"resolution,omitempty"`
+	RequestedAt Timestamp  `json:"requested_at"`
+	AssignedAt  *Timestamp `json:"assigned_at,omitempty"`
+	ResolvedAt  *Timestamp `json:"resolved_at,omitempty"`
 }
 
 // ConversationCreate represents a request to create a new conversation.
 type ConversationCreate struct {
-	Title        string                 `json:"title,omitempty"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
-	SystemPrompt string                 `json:"system_prompt,omitempty"`
+	Title         string                 `json:"title,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	SystemPrompt  string                 `json:"system_prompt,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	// PromptTemplate renders the system prompt from a managed template
+	// instead of SystemPrompt.
+	PromptTemplate *PromptTemplateRef `json:"prompt_template,omitempty"`
 }
 
 // WorkflowStatus represents the status of a workflow run.
@@ -81,19 +141,30 @@ type WorkflowStep struct {
 	Config    map[string]interface{} `json:"config,omitempty"`
 	NextSteps []string               `json:"next_steps,omitempty"`
 	OnError   string                 `json:"on_error,omitempty"`
+	// RetryPolicy retries the step when it fails, before OnError applies.
+	RetryPolicy *StepRetryPolicy `json:"retry_policy,omitempty"`
+	// TimeoutSeconds bounds each attempt of the step; zero leaves it to
+	// the server's default.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
 }
 
 // WorkflowDefinition represents a workflow definition.
 type WorkflowDefinition struct {
-	ID          string                 `json:"id"`
+	ID          WorkflowID             `json:"id"`
 	Name        string                 `json:"name"`
 	Description string                 `json:"description,omitempty"`
 	Version     string                 `json:"version"`
 	Steps       []WorkflowStep         `json:"steps"`
 	EntryPoint  string                 `json:"entry_point"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	// InputSchema and OutputSchema describe a run's input and output
+	// data, when the workflow declares them.
+	InputSchema  JSONSchema `json:"input_schema,omitempty"`
+	OutputSchema JSONSchema `json:"output_schema,omitempty"`
+	CreatedAt    Timestamp  `json:"created_at"`
+	UpdatedAt    Timestamp  `json:"updated_at"`
+	// Extra holds response fields not modeled by this struct.
+	Extra Extra `json:"-"`
 }
 
 // WorkflowDefinitionCreate represents a request to create a workflow.
@@ -104,25 +175,126 @@ type WorkflowDefinitionCreate struct {
 	Steps       []WorkflowStep         `json:"steps"`
 	EntryPoint  string                 `json:"entry_point"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	// InputSchema and OutputSchema, if set, are JSON schemas that run
+	// input and output data must match.
+	InputSchema  JSONSchema `json:"input_schema,omitempty"`
+	OutputSchema JSONSchema `json:"output_schema,omitempty"`
 }
 
 // WorkflowRun represents a workflow run instance.
 type WorkflowRun struct {
-	ID          string                 `json:"id"`
-	WorkflowID  string                 `json:"workflow_id"`
+	ID          RunID                  `json:"id"`
+	WorkflowID  WorkflowID             `json:"workflow_id"`
 	Status      WorkflowStatus         `json:"status"`
 	InputData   map[string]interface{} `json:"input_data,omitempty"`
 	OutputData  map[string]interface{} `json:"output_data,omitempty"`
 	Error       string                 `json:"error,omitempty"`
 	CurrentStep string                 `json:"current_step,omitempty"`
-	StartedAt   time.Time              `json:"started_at"`
-	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	// ReplayOf is the ID of the original run when this run is a replay.
+	ReplayOf      RunID      `json:"replay_of,omitempty"`
+	CorrelationID string     `json:"correlation_id,omitempty"`
+	StartedAt     Timestamp  `json:"started_at"`
+	CompletedAt   *Timestamp `json:"completed_at,omitempty"`
+	// CancelReason is the reason given when the run was cancelled.
+	CancelReason string `json:"cancel_reason,omitempty"`
+	// ConcurrencyKey is the key the run was started with.
+	ConcurrencyKey string `json:"concurrency_key,omitempty"`
+	// Environment is the environment the run was started in.
+	Environment string `json:"environment,omitempty"`
+	// Steps records the steps executed so far, in execution order.
+	Steps []StepRun `json:"steps,omitempty"`
+	// Metrics totals the run's steps, when the server reports them.
+	Metrics *Metrics `json:"metrics,omitempty"`
+	// Extra holds response fields not modeled by this struct.
+	Extra Extra `json:"-"`
+}
+
+// StepStatus represents the state of a step in a workflow run.
+type StepStatus string
+
+const (
+	StepStatusPending         StepStatus = "pending"
+	StepStatusRunning         StepStatus = "running"
+	StepStatusCompleted       StepStatus = "completed"
+	StepStatusFailed          StepStatus = "failed"
+	StepStatusSkipped         StepStatus = "skipped"
+	StepStatusWaitingApproval StepStatus = "waiting_approval"
+	StepStatusPaused          StepStatus = "paused"
+)
+
+// StepRun records the execution of one step of a workflow run.
+type StepRun struct {
+	StepID      string                 `json:"step_id"`
+	State       StepStatus             `json:"state"`
+	Inputs      map[string]interface{} `json:"inputs,omitempty"`
+	Outputs     map[string]interface{} `json:"outputs,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	RetryCount  int                    `json:"retry_count,omitempty"`
+	StartedAt   Timestamp              `json:"started_at"`
+	CompletedAt *Timestamp             `json:"completed_at,omitempty"`
+	Metrics     *Metrics               `json:"metrics,omitempty"`
 }
 
 // WorkflowRunCreate represents a request to start a workflow run.
 type WorkflowRunCreate struct {
-	WorkflowID string                 `json:"workflow_id"`
-	InputData  map[string]interface{} `json:"input_data,omitempty"`
+	WorkflowID    WorkflowID             `json:"workflow_id"`
+	InputData     map[string]interface{} `json:"input_data,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	// TimeoutSeconds fails the run if it has not finished in time; zero
+	// leaves it to the server's default.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+	// MaxCostUSD fails the run once its LLM steps have cost more; zero
+	// means no limit beyond the server's.
+	MaxCostUSD float64 `json:"max_cost_usd,omitempty"`
+	// ConcurrencyKey limits the workflow to one active run per key, e.g.
+	// a repository name; ConcurrencyPolicy says what happens to this run
+	// when another holds the key.
+	ConcurrencyKey    string            `json:"concurrency_key,omitempty"`
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrency_policy,omitempty"`
+	// ClientToken deduplicates starts: the server returns the existing run
+	// for a token it has seen instead of starting another. RunWorkflow
+	// generates one when it is empty, so its retries are safe.
+	ClientToken string `json:"client_token,omitempty"`
+	// Environment names the environment whose variables the run binds;
+	// empty runs without one.
+	Environment string `json:"environment,omitempty"`
+}
+
+// ConcurrencyPolicy is what happens when a run is started while another
+// run of the workflow with the same concurrency key is active.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyQueue starts the run when the active one finishes. It
+	// is the server's default.
+	ConcurrencyQueue ConcurrencyPolicy = "queue"
+	// ConcurrencySkip does not start the run; the active run is returned
+	// instead.
+	ConcurrencySkip ConcurrencyPolicy = "skip"
+	// ConcurrencyCancelPrevious cancels the active run and starts this one.
+	ConcurrencyCancelPrevious ConcurrencyPolicy = "cancel_previous"
+)
+
+// ReplayOptions controls how a workflow run is re-executed.
+type ReplayOptions struct {
+	// FreezeLLMOutputs reuses the model outputs recorded in the original run
+	// and only re-executes deterministic steps.
+	FreezeLLMOutputs bool `json:"freeze_llm_outputs"`
+	// InputOverrides replaces fields of the original run's input data.
+	InputOverrides map[string]interface{} `json:"input_overrides,omitempty"`
+}
+
+// CancelOptions controls how a workflow run is cancelled.
+type CancelOptions struct {
+	// Reason is recorded on the run for audit trails.
+	Reason string `json:"reason,omitempty"`
+	// Force stops running steps immediately instead of letting them
+	// finish.
+	Force bool `json:"force,omitempty"`
+	// GraceSeconds is how long running steps may take to finish before
+	// they are stopped; zero leaves it to the server's default. It is
+	// ignored when Force is set.
+	GraceSeconds int `json:"grace_seconds,omitempty"`
 }
 
 // ContextType represents the type of a context item.
@@ -144,8 +316,11 @@ type ContextItem struct {
 	Content     string                 `json:"content,omitempty"`
 	URL         string                 `json:"url,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
 	EmbeddingID string                 `json:"embedding_id,omitempty"`
-	CreatedAt   time.Time              `json:"created_at"`
+	CreatedAt   Timestamp              `json:"created_at"`
+	// Extra holds response fields not modeled by this struct.
+	Extra Extra `json:"-"`
 }
 
 // ContextItemCreate represents a request to create a context item.
@@ -155,6 +330,7 @@ type ContextItemCreate struct {
 	Content  string                 `json:"content,omitempty"`
 	URL      string                 `json:"url,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
 }
 
 // User represents a user.
@@ -166,8 +342,10 @@ type User struct {
 	TenantID      string    `json:"tenant_id,omitempty"`
 	IsActive      bool      `json:"is_active"`
 	EmailVerified bool      `json:"email_verified"`
-	CreatedAt     time.Time `json:"created_at"`
-	LastLoginAt   time.Time `json:"last_login_at,omitempty"`
+	CreatedAt     Timestamp `json:"created_at"`
+	LastLoginAt   Timestamp `json:"last_login_at,omitempty"`
+	// Extra holds response fields not modeled by this struct.
+	Extra Extra `json:"-"`
 }
 
 // LoginRequest represents a login request.
@@ -195,6 +373,20 @@ type TokenPair struct {
 	RefreshExpiresIn int    `json:"refresh_expires_in"`
 }
 
+// Grant and token type URIs of OAuth 2.0 Token Exchange (RFC 8693).
+const (
+	TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	TokenTypeIDToken       = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+// TokenExchangeRequest represents a request to exchange an external
+// identity provider's token for a CoPilot access token.
+type TokenExchangeRequest struct {
+	GrantType        string `json:"grant_type"`
+	SubjectToken     string `json:"subject_token"`
+	SubjectTokenType string `json:"subject_token_type"`
+}
+
 // ApiKeyScope represents an API key scope.
 type ApiKeyScope string
 
@@ -221,9 +413,9 @@ type ApiKey struct {
 	Name         string        `json:"name"`
 	Prefix       string        `json:"prefix"`
 	Scopes       []ApiKeyScope `json:"scopes"`
-	CreatedAt    time.Time     `json:"created_at"`
-	ExpiresAt    *time.Time    `json:"expires_at,omitempty"`
-	LastUsedAt   *time.Time    `json:"last_used_at,omitempty"`
+	CreatedAt    Timestamp     `json:"created_at"`
+	ExpiresAt    *Timestamp    `json:"expires_at,omitempty"`
+	LastUsedAt   *Timestamp    `json:"last_used_at,omitempty"`
 	IsActive     bool          `json:"is_active"`
 	RequestCount int64         `json:"request_count"`
 }
@@ -234,14 +426,6 @@ type ApiKeyWithSecret struct {
 	Key string `json:"key"`
 }
 
-// HealthStatus represents health status response.
-type HealthStatus struct {
-	Status        string            `json:"status"`
-	Version       string            `json:"version"`
-	UptimeSeconds float64           `json:"uptime_seconds"`
-	Components    map[string]string `json:"components,omitempty"`
-}
-
 // PaginatedResponse represents a paginated API response.
 type PaginatedResponse[T any] struct {
 	Items      []T    `json:"items"`
@@ -252,12 +436,30 @@ type PaginatedResponse[T any] struct {
 	NextCursor string `json:"next_cursor,omitempty"`
 }
 
+// SortOrder represents the order of a listing.
+type SortOrder string
+
+const (
+	OrderAsc  SortOrder = "asc"
+	OrderDesc SortOrder = "desc"
+)
+
 // APIError represents an API error response.
 type APIError struct {
-	Code      string                 `json:"code"`
-	Message   string                 `json:"message"`
-	Details   map[string]interface{} `json:"details,omitempty"`
-	RequestID string                 `json:"request_id,omitempty"`
+	Code          string                 `json:"code"`
+	Message       string                 `json:"message"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+	FieldErrors   []FieldError           `json:"field_errors,omitempty"`
+	RequestID     string                 `json:"request_id,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+}
+
+// FieldError is a validation failure of one request field. Field is a
+// path into the request body such as "steps[2].type".
+type FieldError struct {
+	Field      string `json:"field"`
+	Message    string `json:"message"`
+	Constraint string `json:"constraint,omitempty"`
 }
 
 // Error implements the error interface.
@@ -68,7 +68,7 @@ func TestMessageSerialization(t *testing.T) {
 		Role:           RoleUser,
 		Content:        "Hello, world!",
 		Metadata:       map[string]interface{}{"key": "value"},
-		CreatedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:      NewTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
 	}
 
 	data, err := json.Marshal(msg)
@@ -93,7 +93,7 @@ func TestMessageSerialization(t *testing.T) {
 }
 
 func TestConversationSerialization(t *testing.T) {
-	now := time.Now().UTC().Truncate(time.Second)
+	now := NewTimestamp(time.Now().UTC().Truncate(time.Second))
 	conv := Conversation{
 		ID:           "conv-123",
 		Title:        "Test Conversation",
@@ -124,7 +124,7 @@ func TestConversationSerialization(t *testing.T) {
 }
 
 func TestWorkflowDefinitionSerialization(t *testing.T) {
-	now := time.Now().UTC().Truncate(time.Second)
+	now := NewTimestamp(time.Now().UTC().Truncate(time.Second))
 	wf := WorkflowDefinition{
 		ID:          "wf-123",
 		Name:        "Test Workflow",
@@ -180,7 +180,7 @@ func TestAPIError(t *testing.T) {
 }
 
 func TestUserSerialization(t *testing.T) {
-	now := time.Now().UTC().Truncate(time.Second)
+	now := NewTimestamp(time.Now().UTC().Truncate(time.Second))
 	user := User{
 		ID:            "user-123",
 		Username:      "testuser",
@@ -238,3 +238,33 @@ func TestApiKeyScopeSerialization(t *testing.T) {
 		t.Errorf("Scopes count mismatch")
 	}
 }
+
+func TestComponentHealthSerialization(t *testing.T) {
+	data := `{"status":"degraded","components":{"cache":"healthy","llm":{"status":"degraded","latency_ms":1250.5,"error":"slow"}}}`
+
+	var status HealthStatus
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if status.Healthy() {
+		t.Error("expected degraded status not to be healthy")
+	}
+	if status.Components["cache"].Status != HealthHealthy {
+		t.Errorf("expected bare string status, got %+v", status.Components["cache"])
+	}
+	if llm := status.Components["llm"]; llm.LatencyMS != 1250.5 || llm.Error != "slow" {
+		t.Errorf("unexpected llm health %+v", llm)
+	}
+}
+
+func TestHighlightedSnippet(t *testing.T) {
+	hit := MessageSearchHit{
+		Snippet:    "reset the password, then reset the token",
+		Highlights: []TextRange{{0, 5}, {3, 8}, {25, 30}, {40, 99}},
+	}
+	got := hit.HighlightedSnippet("<mark>", "</mark>")
+	want := "<mark>reset</mark> the password, then <mark>reset</mark> the token"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
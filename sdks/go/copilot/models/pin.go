@@ -0,0 +1,17 @@
+package models
+
+// PinnedMessage is a message pinned in a conversation.
+type PinnedMessage struct {
+	Message Message `json:"message"`
+	// IncludeInContext keeps the message in the model's context however
+	// long the conversation grows.
+	IncludeInContext bool      `json:"include_in_context"`
+	PinnedBy         string    `json:"pinned_by,omitempty"`
+	PinnedAt         Timestamp `json:"pinned_at"`
+}
+
+// PinCreate represents a request to pin a message.
+type PinCreate struct {
+	MessageID        MessageID `json:"message_id"`
+	IncludeInContext bool      `json:"include_in_context,omitempty"`
+}
@@ -0,0 +1,66 @@
+package models
+
+// PromptVariableType is the type of a prompt variable's value.
+type PromptVariableType string
+
+const (
+	PromptVariableString  PromptVariableType = "string"
+	PromptVariableNumber  PromptVariableType = "number"
+	PromptVariableBoolean PromptVariableType = "boolean"
+	// PromptVariableJSON values are substituted as JSON.
+	PromptVariableJSON PromptVariableType = "json"
+)
+
+// PromptVariable declares a variable a prompt template substitutes.
+type PromptVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Type defaults to PromptVariableString.
+	Type PromptVariableType `json:"type,omitempty"`
+	// Required variables must be supplied when the template is rendered.
+	Required bool `json:"required,omitempty"`
+	// Default is used for an optional variable that is not supplied.
+	Default string `json:"default,omitempty"`
+}
+
+// PromptTemplate is a centrally managed prompt. Each update creates a new
+// version; earlier versions stay readable so that references pinned to
+// them keep working.
+type PromptTemplate struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Content     string           `json:"content"`
+	Variables   []PromptVariable `json:"variables,omitempty"`
+	Version     int              `json:"version"`
+	CreatedBy   string           `json:"created_by,omitempty"`
+	CreatedAt   Timestamp        `json:"created_at"`
+	UpdatedAt   Timestamp        `json:"updated_at"`
+}
+
+// PromptTemplateCreate represents a request to create a prompt template.
+type PromptTemplateCreate struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Content     string           `json:"content"`
+	Variables   []PromptVariable `json:"variables,omitempty"`
+}
+
+// PromptTemplateUpdate represents a change to a prompt template, saved as
+// a new version. Nil fields carry over from the latest version.
+type PromptTemplateUpdate struct {
+	Name        *string          `json:"name,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	Content     *string          `json:"content,omitempty"`
+	Variables   []PromptVariable `json:"variables,omitempty"`
+}
+
+// PromptTemplateRef refers to a prompt template from a conversation or a
+// workflow LLM step, whose Config holds it under "prompt_template".
+type PromptTemplateRef struct {
+	ID string `json:"id"`
+	// Version pins a version; zero follows the latest.
+	Version int `json:"version,omitempty"`
+	// Variables are substituted into the template.
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
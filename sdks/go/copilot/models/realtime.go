@@ -0,0 +1,45 @@
+package models
+
+// ChangeEventType represents the type of a realtime change event.
+type ChangeEventType string
+
+const (
+	ChangeMessageCreated      ChangeEventType = "message.created"
+	ChangeConversationUpdated ChangeEventType = "conversation.updated"
+	ChangeRunStatusChanged    ChangeEventType = "run.status_changed"
+	ChangeContextUpdated      ChangeEventType = "context.updated"
+	ChangeContextDeleted      ChangeEventType = "context.deleted"
+	ChangeTyping              ChangeEventType = "conversation.typing"
+	ChangePresence            ChangeEventType = "conversation.presence"
+)
+
+// Presence describes a participant's activity in a conversation.
+type Presence struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username,omitempty"`
+	// Active reports whether the user has the conversation open.
+	Active bool `json:"active"`
+	// Typing reports whether the user is composing a message.
+	Typing     bool      `json:"typing"`
+	LastSeenAt Timestamp `json:"last_seen_at"`
+}
+
+// ChangeEvent is a change to a resource delivered on a realtime
+// subscription. The field matching Type carries the resource's new state.
+type ChangeEvent struct {
+	ID             string          `json:"id"`
+	Type           ChangeEventType `json:"type"`
+	ConversationID ConversationID  `json:"conversation_id,omitempty"`
+	RunID          RunID           `json:"run_id,omitempty"`
+	// Message is set for message.created.
+	Message *Message `json:"message,omitempty"`
+	// Conversation is set for conversation.updated.
+	Conversation *Conversation `json:"conversation,omitempty"`
+	// Run is set for run.status_changed.
+	Run *WorkflowRun `json:"run,omitempty"`
+	// ContextItem is set for context.updated and context.deleted.
+	ContextItem *ContextItem `json:"context_item,omitempty"`
+	// Presence is set for conversation.typing and conversation.presence.
+	Presence  *Presence `json:"presence,omitempty"`
+	Timestamp Timestamp `json:"timestamp"`
+}
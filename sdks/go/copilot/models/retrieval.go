@@ -0,0 +1,23 @@
+package models
+
+// RetrievalOptions asks the server to search the context items for the
+// passages most relevant to a message and attach them to the model's
+// context, as the conversation's settings scope it.
+type RetrievalOptions struct {
+	// TopK caps the number of passages attached; zero uses the server
+	// default.
+	TopK int `json:"top_k,omitempty"`
+	// Tags limits the search to context items carrying any of the tags.
+	Tags []string `json:"tags,omitempty"`
+	// MinScore drops passages less relevant than this.
+	MinScore float64 `json:"min_score,omitempty"`
+}
+
+// RetrievedChunk is a passage of a context item attached to a message by
+// retrieval.
+type RetrievedChunk struct {
+	ContextItemID string  `json:"context_item_id"`
+	Name          string  `json:"name,omitempty"`
+	Content       string  `json:"content"`
+	Score         float64 `json:"score,omitempty"`
+}
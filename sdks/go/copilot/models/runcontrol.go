@@ -0,0 +1,50 @@
+package models
+
+// RunEventType represents the type of an event on a run control channel.
+type RunEventType string
+
+const (
+	RunEventStatus        RunEventType = "run.status"
+	RunEventStepStarted   RunEventType = "step.started"
+	RunEventStepCompleted RunEventType = "step.completed"
+	RunEventStepFailed    RunEventType = "step.failed"
+	RunEventReview        RunEventType = "step.review_requested"
+	RunEventInputRequired RunEventType = "step.input_required"
+	// RunEventCommandResult acknowledges a command sent on the channel.
+	RunEventCommandResult RunEventType = "command.result"
+)
+
+// RunEvent is an event streamed on a run control channel.
+type RunEvent struct {
+	Type   RunEventType           `json:"type"`
+	RunID  RunID                  `json:"run_id"`
+	StepID string                 `json:"step_id,omitempty"`
+	Status WorkflowStatus         `json:"status,omitempty"`
+	Output map[string]interface{} `json:"output,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+	// CommandID identifies the command a command.result answers.
+	CommandID string    `json:"command_id,omitempty"`
+	Timestamp Timestamp `json:"timestamp"`
+}
+
+// RunCommandType represents the type of a command sent on a run control
+// channel.
+type RunCommandType string
+
+const (
+	RunCommandCancel      RunCommandType = "cancel"
+	RunCommandInjectInput RunCommandType = "inject_input"
+	RunCommandApprove     RunCommandType = "approve"
+	RunCommandReject      RunCommandType = "reject"
+)
+
+// RunCommand is a command sent on a run control channel.
+type RunCommand struct {
+	// ID correlates the command with its command.result event. It is
+	// assigned when empty.
+	ID      string                 `json:"id,omitempty"`
+	Type    RunCommandType         `json:"type"`
+	StepID  string                 `json:"step_id,omitempty"`
+	Input   map[string]interface{} `json:"input,omitempty"`
+	Comment string                 `json:"comment,omitempty"`
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ChangeKind is how a value differs between two runs.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// ValueChange is a difference in one field of two runs' data. Path is a
+// field path such as "summary" or "items[0].score"; A and B are the
+// decoded JSON values in each run, nil where the field is absent.
+type ValueChange struct {
+	Path string      `json:"path"`
+	Kind ChangeKind  `json:"kind"`
+	A    interface{} `json:"a,omitempty"`
+	B    interface{} `json:"b,omitempty"`
+}
+
+// RunDiff is the difference between two runs, e.g. before and after a
+// prompt or model change.
+type RunDiff struct {
+	RunA    RunID          `json:"run_a"`
+	RunB    RunID          `json:"run_b"`
+	StatusA WorkflowStatus `json:"status_a"`
+	StatusB WorkflowStatus `json:"status_b"`
+	Input   []ValueChange  `json:"input,omitempty"`
+	Output  []ValueChange  `json:"output,omitempty"`
+	// MetricsA and MetricsB are the runs' total metrics, which Equal
+	// ignores.
+	MetricsA Metrics `json:"metrics_a"`
+	MetricsB Metrics `json:"metrics_b"`
+	// Steps lists the steps that differ, in the order of run A followed
+	// by those only run B executed.
+	Steps []StepDiff `json:"steps,omitempty"`
+}
+
+// StepDiff is the difference between the executions of a step in two
+// runs. A step executed by only one run has InA or InB false.
+type StepDiff struct {
+	StepID string        `json:"step_id"`
+	InA    bool          `json:"in_a"`
+	InB    bool          `json:"in_b"`
+	StateA StepStatus    `json:"state_a,omitempty"`
+	StateB StepStatus    `json:"state_b,omitempty"`
+	ErrorA string        `json:"error_a,omitempty"`
+	ErrorB string        `json:"error_b,omitempty"`
+	Input  []ValueChange `json:"input,omitempty"`
+	Output []ValueChange `json:"output,omitempty"`
+	// MetricsA and MetricsB are the step's metrics in each run, if
+	// reported.
+	MetricsA *Metrics `json:"metrics_a,omitempty"`
+	MetricsB *Metrics `json:"metrics_b,omitempty"`
+}
+
+// Equal reports whether the runs had the same status, data and steps.
+func (d *RunDiff) Equal() bool {
+	return d.StatusA == d.StatusB && len(d.Input) == 0 && len(d.Output) == 0 && len(d.Steps) == 0
+}
+
+// DiffRuns compares two runs step by step. A step executed more than once,
+// as in a loop, is compared by its last execution.
+func DiffRuns(a, b *WorkflowRun) *RunDiff {
+	diff := &RunDiff{
+		RunA:     a.ID,
+		RunB:     b.ID,
+		StatusA:  a.Status,
+		StatusB:  b.Status,
+		Input:    diffData(a.InputData, b.InputData),
+		Output:   diffData(a.OutputData, b.OutputData),
+		MetricsA: a.TotalMetrics(),
+		MetricsB: b.TotalMetrics(),
+	}
+
+	stepsA, orderA := lastSteps(a.Steps)
+	stepsB, orderB := lastSteps(b.Steps)
+	order := orderA
+	for _, id := range orderB {
+		if _, ok := stepsA[id]; !ok {
+			order = append(order, id)
+		}
+	}
+	for _, id := range order {
+		stepA, inA := stepsA[id]
+		stepB, inB := stepsB[id]
+		step := StepDiff{StepID: id, InA: inA, InB: inB}
+		if inA {
+			step.StateA, step.ErrorA, step.MetricsA = stepA.State, stepA.Error, stepA.Metrics
+		}
+		if inB {
+			step.StateB, step.ErrorB, step.MetricsB = stepB.State, stepB.Error, stepB.Metrics
+		}
+		step.Input = diffData(stepA.Inputs, stepB.Inputs)
+		step.Output = diffData(stepA.Outputs, stepB.Outputs)
+		if inA != inB || step.StateA != step.StateB || step.ErrorA != step.ErrorB || len(step.Input) > 0 || len(step.Output) > 0 {
+			diff.Steps = append(diff.Steps, step)
+		}
+	}
+	return diff
+}
+
+// lastSteps indexes the last execution of each step, returning the step
+// IDs in order of first execution.
+func lastSteps(steps []StepRun) (map[string]StepRun, []string) {
+	byID := make(map[string]StepRun, len(steps))
+	var order []string
+	for _, step := range steps {
+		if _, ok := byID[step.StepID]; !ok {
+			order = append(order, step.StepID)
+		}
+		byID[step.StepID] = step
+	}
+	return byID, order
+}
+
+// diffData compares two data objects as JSON.
+func diffData(a, b map[string]interface{}) []ValueChange {
+	var docA, docB interface{}
+	if a != nil {
+		docA, _ = decodedJSON(a)
+	}
+	if b != nil {
+		docB, _ = decodedJSON(b)
+	}
+	var changes []ValueChange
+	diffValues(docA, docB, "", &changes)
+	return changes
+}
+
+// diffValues appends the differences between decoded JSON values a and b
+// at path to out, descending into objects and arrays.
+func diffValues(a, b interface{}, path string, out *[]ValueChange) {
+	objA, okA := a.(map[string]interface{})
+	objB, okB := b.(map[string]interface{})
+	if a == nil && okB || okA && b == nil {
+		// Absent data compares as an empty object.
+		if objA == nil {
+			objA = map[string]interface{}{}
+		}
+		if objB == nil {
+			objB = map[string]interface{}{}
+		}
+		okA, okB = true, true
+	}
+	if okA && okB {
+		keys := make([]string, 0, len(objA)+len(objB))
+		for key := range objA {
+			keys = append(keys, key)
+		}
+		for key := range objB {
+			if _, ok := objA[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			valueA, inA := objA[key]
+			valueB, inB := objB[key]
+			keyPath := joinFieldPath(path, key)
+			switch {
+			case !inA:
+				*out = append(*out, ValueChange{Path: keyPath, Kind: ChangeAdded, B: valueB})
+			case !inB:
+				*out = append(*out, ValueChange{Path: keyPath, Kind: ChangeRemoved, A: valueA})
+			default:
+				diffValues(valueA, valueB, keyPath, out)
+			}
+		}
+		return
+	}
+
+	arrA, okA := a.([]interface{})
+	arrB, okB := b.([]interface{})
+	if okA && okB {
+		for i := 0; i < len(arrA) || i < len(arrB); i++ {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(arrA):
+				*out = append(*out, ValueChange{Path: itemPath, Kind: ChangeAdded, B: arrB[i]})
+			case i >= len(arrB):
+				*out = append(*out, ValueChange{Path: itemPath, Kind: ChangeRemoved, A: arrA[i]})
+			default:
+				diffValues(arrA[i], arrB[i], itemPath, out)
+			}
+		}
+		return
+	}
+
+	if !jsonEqual(a, b) {
+		*out = append(*out, ValueChange{Path: path, Kind: ChangeChanged, A: a, B: b})
+	}
+}
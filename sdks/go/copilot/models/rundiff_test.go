@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiffRuns(t *testing.T) {
+	a := &WorkflowRun{
+		ID:         "run-a",
+		Status:     WorkflowStatusCompleted,
+		InputData:  map[string]interface{}{"topic": "tides"},
+		OutputData: map[string]interface{}{"summary": "Tides rise.", "scores": []interface{}{0.9, 0.8}},
+		Steps: []StepRun{
+			{StepID: "fetch", State: StepStatusCompleted, Outputs: map[string]interface{}{"count": 3}},
+			{StepID: "draft", State: StepStatusCompleted, Inputs: map[string]interface{}{"model": "small"}, Outputs: map[string]interface{}{"text": "old"}},
+			{StepID: "legacy", State: StepStatusCompleted},
+		},
+	}
+	b := &WorkflowRun{
+		ID:         "run-b",
+		Status:     WorkflowStatusFailed,
+		InputData:  map[string]interface{}{"topic": "tides"},
+		OutputData: map[string]interface{}{"scores": []interface{}{0.9, 0.7, 0.1}, "meta": map[string]interface{}{"v": 2}},
+		Steps: []StepRun{
+			{StepID: "fetch", State: StepStatusCompleted, Outputs: map[string]interface{}{"count": 3.0}},
+			{StepID: "draft", State: StepStatusRunning},
+			{StepID: "draft", State: StepStatusFailed, Inputs: map[string]interface{}{"model": "large"}, Error: "timeout"},
+			{StepID: "review", State: StepStatusSkipped},
+		},
+	}
+
+	diff := DiffRuns(a, b)
+	if diff.Equal() || diff.StatusA != WorkflowStatusCompleted || diff.StatusB != WorkflowStatusFailed {
+		t.Errorf("unexpected statuses %s, %s", diff.StatusA, diff.StatusB)
+	}
+	if len(diff.Input) != 0 {
+		t.Errorf("expected equal input, got %+v", diff.Input)
+	}
+	if got := changeList(diff.Output); got != "meta added, scores[1] changed, scores[2] added, summary removed" {
+		t.Errorf("unexpected output changes: %s", got)
+	}
+
+	var steps []string
+	for _, step := range diff.Steps {
+		steps = append(steps, step.StepID)
+	}
+	// fetch differs only in number representation.
+	if got := strings.Join(steps, " "); got != "draft legacy review" {
+		t.Fatalf("unexpected differing steps %s", got)
+	}
+	draft := diff.Steps[0]
+	if draft.StateA != StepStatusCompleted || draft.StateB != StepStatusFailed || draft.ErrorB != "timeout" {
+		t.Errorf("expected the last execution of draft to be compared, got %+v", draft)
+	}
+	if got := changeList(draft.Input); got != "model changed" {
+		t.Errorf("unexpected input changes: %s", got)
+	}
+	if got := changeList(draft.Output); got != "text removed" {
+		t.Errorf("unexpected output changes: %s", got)
+	}
+	if legacy := diff.Steps[1]; !legacy.InA || legacy.InB {
+		t.Errorf("expected legacy only in A, got %+v", legacy)
+	}
+	if review := diff.Steps[2]; review.InA || !review.InB || review.StateB != StepStatusSkipped {
+		t.Errorf("expected review only in B, got %+v", review)
+	}
+
+	if diff := DiffRuns(a, a); !diff.Equal() {
+		t.Errorf("expected a run to equal itself, got %+v", diff)
+	}
+}
+
+func changeList(changes []ValueChange) string {
+	parts := make([]string, len(changes))
+	for i, c := range changes {
+		parts[i] = fmt.Sprintf("%s %s", c.Path, c.Kind)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,300 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrSchemaMismatch is returned when a value does not match a JSON schema.
+var ErrSchemaMismatch = errors.New("copilot: value does not match schema")
+
+// JSONSchema is a JSON Schema document, such as the schema of a workflow's
+// input. It is kept as decoded JSON so that any keyword round-trips;
+// Validate checks the common ones: type, enum, const, properties,
+// required, additionalProperties, items, minItems, maxItems, minLength,
+// maxLength, pattern, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, allOf, anyOf, oneOf and not. Others, such as $ref and
+// format, are left to the server.
+type JSONSchema map[string]interface{}
+
+// SchemaError lists the ways a value fails to match a JSON schema, as
+// field errors whose Constraint is the failing keyword.
+type SchemaError struct {
+	Violations []FieldError
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	v := e.Violations[0]
+	msg := ErrSchemaMismatch.Error() + ": "
+	if v.Field != "" {
+		msg += v.Field + ": "
+	}
+	msg += v.Message
+	if len(e.Violations) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(e.Violations)-1)
+	}
+	return msg
+}
+
+// Unwrap lets errors.Is match ErrSchemaMismatch.
+func (e *SchemaError) Unwrap() error { return ErrSchemaMismatch }
+
+// Validate checks v, any value that encodes as JSON, against the schema,
+// returning a *SchemaError listing every violation.
+func (s JSONSchema) Validate(v interface{}) error {
+	// Both sides are normalized to decoded JSON, so that a schema built
+	// in Go, with []string or int values, reads like a parsed one.
+	schema, err := decodedJSON(s)
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+	doc, err := decodedJSON(v)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSchemaMismatch, err)
+	}
+	var violations []FieldError
+	validateSchema(schema, doc, "", &violations)
+	if len(violations) > 0 {
+		return &SchemaError{Violations: violations}
+	}
+	return nil
+}
+
+// decodedJSON returns v as encoding/json decodes it into an interface{}.
+func decodedJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// validateSchema appends the violations of doc against schema, a decoded
+// schema object or boolean, to out.
+func validateSchema(schema interface{}, doc interface{}, path string, out *[]FieldError) {
+	fail := func(keyword, format string, args ...interface{}) {
+		*out = append(*out, FieldError{Field: path, Message: fmt.Sprintf(format, args...), Constraint: keyword})
+	}
+
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		if allowed, ok := schema.(bool); ok && !allowed {
+			fail("false", "is not allowed")
+		}
+		return
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, doc) {
+		fail("type", "must be of type %s", typeList(t))
+		// Other keywords assume the type.
+		return
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if jsonEqual(e, doc) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("enum", "must be one of %s", compactJSON(enum))
+		}
+	}
+	if c, ok := s["const"]; ok && !jsonEqual(c, doc) {
+		fail("const", "must be %s", compactJSON(c))
+	}
+
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		validateObject(s, doc, path, out)
+	case []interface{}:
+		if n, ok := schemaInt(s, "minItems"); ok && len(doc) < n {
+			fail("minItems", "must have at least %d items", n)
+		}
+		if n, ok := schemaInt(s, "maxItems"); ok && len(doc) > n {
+			fail("maxItems", "must have at most %d items", n)
+		}
+		if items, ok := s["items"]; ok {
+			for i, item := range doc {
+				validateSchema(items, item, path+"["+strconv.Itoa(i)+"]", out)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(doc)
+		if n, ok := schemaInt(s, "minLength"); ok && length < n {
+			fail("minLength", "must be at least %d characters", n)
+		}
+		if n, ok := schemaInt(s, "maxLength"); ok && length > n {
+			fail("maxLength", "must be at most %d characters", n)
+		}
+		if pattern, ok := s["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(doc) {
+				fail("pattern", "must match %s", pattern)
+			}
+		}
+	case float64:
+		if n, ok := s["minimum"].(float64); ok && doc < n {
+			fail("minimum", "must be at least %v", n)
+		}
+		if n, ok := s["maximum"].(float64); ok && doc > n {
+			fail("maximum", "must be at most %v", n)
+		}
+		if n, ok := s["exclusiveMinimum"].(float64); ok && doc <= n {
+			fail("exclusiveMinimum", "must be greater than %v", n)
+		}
+		if n, ok := s["exclusiveMaximum"].(float64); ok && doc >= n {
+			fail("exclusiveMaximum", "must be less than %v", n)
+		}
+	}
+
+	if all, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			validateSchema(sub, doc, path, out)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok && matching(anyOf, doc) == 0 {
+		fail("anyOf", "must match at least one allowed schema")
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok {
+		if n := matching(oneOf, doc); n != 1 {
+			fail("oneOf", "must match exactly one allowed schema, matches %d", n)
+		}
+	}
+	if not, ok := s["not"]; ok && matching([]interface{}{not}, doc) == 1 {
+		fail("not", "must not match the excluded schema")
+	}
+}
+
+// validateObject checks the object keywords of s against doc.
+func validateObject(s map[string]interface{}, doc map[string]interface{}, path string, out *[]FieldError) {
+	props, _ := s["properties"].(map[string]interface{})
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := doc[name]; !present {
+					*out = append(*out, FieldError{Field: joinFieldPath(path, name), Message: "is required", Constraint: "required"})
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(doc))
+	for name := range doc {
+		names = append(names, name)
+	}
+	// Violations are reported in a stable order.
+	sort.Strings(names)
+	additional, hasAdditional := s["additionalProperties"]
+	for _, name := range names {
+		if prop, ok := props[name]; ok {
+			validateSchema(prop, doc[name], joinFieldPath(path, name), out)
+		} else if hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				*out = append(*out, FieldError{Field: joinFieldPath(path, name), Message: "is not an allowed property", Constraint: "additionalProperties"})
+			} else {
+				validateSchema(additional, doc[name], joinFieldPath(path, name), out)
+			}
+		}
+	}
+}
+
+// joinFieldPath appends a property to a field path.
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// matching returns how many of schemas doc matches.
+func matching(schemas []interface{}, doc interface{}) int {
+	n := 0
+	for _, sub := range schemas {
+		var violations []FieldError
+		validateSchema(sub, doc, "", &violations)
+		if len(violations) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// matchesType reports whether doc has the type t, a type name or a list
+// of them.
+func matchesType(t interface{}, doc interface{}) bool {
+	switch t := t.(type) {
+	case string:
+		return hasType(t, doc)
+	case []interface{}:
+		for _, name := range t {
+			if name, ok := name.(string); ok && hasType(name, doc) {
+				return true
+			}
+		}
+		return false
+	}
+	// An unrecognized type keyword is left to the server.
+	return true
+}
+
+func hasType(name string, doc interface{}) bool {
+	switch doc := doc.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case string:
+		return name == "string"
+	case float64:
+		return name == "number" || name == "integer" && doc == math.Trunc(doc)
+	case []interface{}:
+		return name == "array"
+	case map[string]interface{}:
+		return name == "object"
+	}
+	return false
+}
+
+// typeList formats a type keyword for messages.
+func typeList(t interface{}) string {
+	if names, ok := t.([]interface{}); ok {
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = fmt.Sprint(name)
+		}
+		return strings.Join(parts, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// schemaInt returns a non-negative integer keyword of s.
+func schemaInt(s map[string]interface{}, keyword string) (int, bool) {
+	n, ok := s[keyword].(float64)
+	if !ok || n < 0 {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// jsonEqual compares decoded JSON values.
+func jsonEqual(a, b interface{}) bool {
+	return compactJSON(a) == compactJSON(b)
+}
+
+// compactJSON encodes a decoded JSON value; maps encode with sorted keys,
+// so equal values encode alike.
+func compactJSON(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
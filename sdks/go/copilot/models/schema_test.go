@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchemaValidate(t *testing.T) {
+	var schema JSONSchema
+	err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"required": ["topic", "count"],
+		"additionalProperties": false,
+		"properties": {
+			"topic": {"type": "string", "minLength": 3, "pattern": "^[a-z ]+$"},
+			"count": {"type": "integer", "minimum": 1, "maximum": 10},
+			"tone": {"enum": ["formal", "casual"]},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+			"limit": {"anyOf": [{"type": "null"}, {"type": "number", "exclusiveMinimum": 0}]}
+		}
+	}`), &schema)
+	if err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if err := schema.Validate(map[string]interface{}{"topic": "tides", "count": 3, "tags": []string{"sea"}, "limit": nil}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = schema.Validate(map[string]interface{}{
+		"topic": "Hi",
+		"tone":  "angry",
+		"tags":  []interface{}{"a", 2, "c"},
+		"limit": -1,
+		"extra": true,
+	})
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || !errors.Is(err, ErrSchemaMismatch) {
+		t.Fatalf("expected a SchemaError, got %v", err)
+	}
+	got := make(map[string]bool)
+	for _, v := range schemaErr.Violations {
+		got[v.Field+" "+v.Constraint] = true
+	}
+	for _, want := range []string{
+		"count required",
+		"topic minLength",
+		"topic pattern",
+		"tone enum",
+		"tags maxItems",
+		"tags[1] type",
+		"limit anyOf",
+		"extra additionalProperties",
+	} {
+		if !got[want] {
+			t.Errorf("expected violation %q, got %+v", want, schemaErr.Violations)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), "copilot: value does not match schema: count: is required (and ") {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+
+	if err := schema.Validate(map[string]interface{}{"topic": "tides", "count": 2.5}); err == nil {
+		t.Error("expected a fractional count to fail")
+	}
+}
+
+func TestJSONSchemaBuiltInGo(t *testing.T) {
+	schema := JSONSchema{
+		"type":     "object",
+		"required": []string{"name"},
+		"properties": map[string]JSONSchema{
+			"age": {"type": []string{"integer", "null"}, "minimum": 0},
+		},
+	}
+	if err := schema.Validate(map[string]interface{}{"name": "x", "age": nil}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	var schemaErr *SchemaError
+	if err := schema.Validate(map[string]interface{}{"age": -1}); !errors.As(err, &schemaErr) || len(schemaErr.Violations) != 2 {
+		t.Errorf("expected two violations, got %v", err)
+	}
+}
+
+func TestWorkflowValidateInput(t *testing.T) {
+	wf := WorkflowDefinition{}
+	if err := wf.ValidateInput(nil); err != nil {
+		t.Errorf("expected no schema to accept anything, got %v", err)
+	}
+
+	wf.InputSchema = JSONSchema{"type": "object", "required": []string{"topic"}}
+	if err := wf.ValidateInput(nil); !errors.Is(err, ErrSchemaMismatch) {
+		t.Errorf("expected missing input to lack topic, got %v", err)
+	}
+	if err := wf.ValidateInput(map[string]interface{}{"topic": "tides"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+// TextRange is a byte range [Start, End) within a string.
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// MessageSearchHit is a message matching a search query.
+type MessageSearchHit struct {
+	Message Message `json:"message"`
+	// ConversationTitle is the title of the conversation holding the
+	// message, for displaying the hit without fetching the conversation.
+	ConversationTitle string `json:"conversation_title,omitempty"`
+	// Snippet is an excerpt of the message around the match.
+	Snippet string `json:"snippet"`
+	// Highlights locates the matched terms within Snippet.
+	Highlights []TextRange `json:"highlights,omitempty"`
+	// Context holds the messages surrounding the hit, in conversation
+	// order, when requested.
+	Context []Message `json:"context,omitempty"`
+	Score   float64   `json:"score,omitempty"`
+}
+
+// HighlightedSnippet returns the snippet with each highlight wrapped in
+// open and close, e.g. "<mark>" and "</mark>". Invalid ranges are skipped.
+func (h *MessageSearchHit) HighlightedSnippet(open, close string) string {
+	var b []byte
+	last := 0
+	for _, r := range h.Highlights {
+		if r.Start < last || r.End < r.Start || r.End > len(h.Snippet) {
+			continue
+		}
+		b = append(b, h.Snippet[last:r.Start]...)
+		b = append(b, open...)
+		b = append(b, h.Snippet[r.Start:r.End]...)
+		b = append(b, close...)
+		last = r.End
+	}
+	return string(append(b, h.Snippet[last:]...))
+}
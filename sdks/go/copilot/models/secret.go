@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrInvalidSecretName is returned when a workflow secret name is empty or
+// malformed.
+var ErrInvalidSecretName = errors.New("copilot: invalid secret name")
+
+// identifier matches names usable in a SecretRef or EnvRef.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// WorkflowSecret describes a credential stored for a workflow's steps.
+// Values are write-only: the API never returns them.
+type WorkflowSecret struct {
+	Name      string    `json:"name"`
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// ValidateSecretName reports whether name can name a workflow secret: a
+// letter or underscore followed by letters, digits and underscores.
+func ValidateSecretName(name string) error {
+	if !identifier.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidSecretName, name)
+	}
+	return nil
+}
+
+// SecretRef returns the reference to a workflow secret for use in step
+// configuration, e.g. as a tool's API key. The server substitutes the
+// value when the step runs, so it never appears in the definition.
+func SecretRef(name string) string {
+	return "{{secrets." + name + "}}"
+}
@@ -0,0 +1,41 @@
+package models
+
+// ContextScope selects the context items a conversation draws on.
+type ContextScope string
+
+const (
+	// ContextScopeAll searches every context item in the workspace.
+	ContextScopeAll ContextScope = "all"
+	// ContextScopeSelected searches only the conversation's ContextItemIDs.
+	ContextScopeSelected ContextScope = "selected"
+	// ContextScopeNone disables context retrieval.
+	ContextScopeNone ContextScope = "none"
+)
+
+// ConversationSettings are the generation defaults of a conversation.
+type ConversationSettings struct {
+	ConversationID ConversationID `json:"conversation_id"`
+	Model          string         `json:"model,omitempty"`
+	Temperature    *float64       `json:"temperature,omitempty"`
+	MaxTokens      int            `json:"max_tokens,omitempty"`
+	// ToolsEnabled reports whether the assistant may call tools at all.
+	ToolsEnabled bool `json:"tools_enabled"`
+	// AllowedTools restricts the callable tools; empty allows every tool.
+	AllowedTools   []string     `json:"allowed_tools,omitempty"`
+	ContextScope   ContextScope `json:"context_scope,omitempty"`
+	ContextItemIDs []string     `json:"context_item_ids,omitempty"`
+	UpdatedAt      Timestamp    `json:"updated_at"`
+}
+
+// ConversationSettingsUpdate represents a partial update to conversation
+// settings. Nil fields are left unchanged; point a slice field at an
+// empty slice to clear it.
+type ConversationSettingsUpdate struct {
+	Model          *string       `json:"model,omitempty"`
+	Temperature    *float64      `json:"temperature,omitempty"`
+	MaxTokens      *int          `json:"max_tokens,omitempty"`
+	ToolsEnabled   *bool         `json:"tools_enabled,omitempty"`
+	AllowedTools   *[]string     `json:"allowed_tools,omitempty"`
+	ContextScope   *ContextScope `json:"context_scope,omitempty"`
+	ContextItemIDs *[]string     `json:"context_item_ids,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// WorkflowSimulateRequest represents a request to simulate a workflow run.
+type WorkflowSimulateRequest struct {
+	InputData map[string]interface{} `json:"input_data,omitempty"`
+}
+
+// WorkflowSimulation is the plan of a workflow run, produced without
+// executing its LLM or tool steps.
+type WorkflowSimulation struct {
+	WorkflowID WorkflowID `json:"workflow_id"`
+	// Steps lists the steps in the order they would execute. Both
+	// branches of a condition the planner cannot decide are included.
+	Steps            []SimulatedStep `json:"steps"`
+	EstimatedTokens  int64           `json:"estimated_tokens,omitempty"`
+	EstimatedCostUSD float64         `json:"estimated_cost_usd"`
+	// Warnings describe problems found while planning, such as steps that
+	// cannot be reached.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// SimulatedStep is a step of a simulated run.
+type SimulatedStep struct {
+	StepID string           `json:"step_id"`
+	Name   string           `json:"name,omitempty"`
+	Type   WorkflowStepType `json:"type"`
+	// Order is the step's position in the run, from 1. Steps that would
+	// run in parallel share an order.
+	Order int `json:"order"`
+	// Config is the step's configuration with run variables substituted.
+	Config           map[string]interface{} `json:"config,omitempty"`
+	EstimatedTokens  int64                  `json:"estimated_tokens,omitempty"`
+	EstimatedCostUSD float64                `json:"estimated_cost_usd"`
+}
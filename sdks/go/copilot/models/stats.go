@@ -0,0 +1,27 @@
+package models
+
+// LatencyStats holds latency percentiles in milliseconds.
+type LatencyStats struct {
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+	P99Ms float64 `json:"p99_ms"`
+}
+
+// ComponentStats holds request metrics for a single system component.
+type ComponentStats struct {
+	Latency      LatencyStats `json:"latency"`
+	RequestCount int64        `json:"request_count"`
+	ErrorCount   int64        `json:"error_count"`
+	ErrorRate    float64      `json:"error_rate"`
+}
+
+// SystemStats represents operational metrics for the whole deployment.
+type SystemStats struct {
+	ActiveConversations int64                     `json:"active_conversations"`
+	QueuedWorkflowRuns  int64                     `json:"queued_workflow_runs"`
+	RunningWorkflowRuns int64                     `json:"running_workflow_runs"`
+	RequestsPerSecond   float64                   `json:"requests_per_second"`
+	ErrorRate           float64                   `json:"error_rate"`
+	Components          map[string]ComponentStats `json:"components,omitempty"`
+	CollectedAt         Timestamp                 `json:"collected_at"`
+}
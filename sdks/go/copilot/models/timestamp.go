@@ -0,0 +1,86 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time.Time that decodes the timestamp formats the API has
+// been seen to return: RFC 3339 at any precision, RFC 3339 without a zone
+// (read as UTC), and Unix epoch seconds or milliseconds as a number or
+// numeric string. It encodes as RFC 3339 like time.Time.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps t as a Timestamp.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// timestampLayouts are tried in order for string timestamps.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// epochMillisThreshold separates epoch seconds from epoch milliseconds;
+// as seconds it lies in the year 33658.
+const epochMillisThreshold = 1e12
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] != '"' {
+		return t.parseEpoch(string(data))
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	if err := t.parseEpoch(s); err == nil {
+		return nil
+	}
+	return fmt.Errorf("models: unrecognized timestamp %q", s)
+}
+
+// parseEpoch interprets s as Unix seconds or milliseconds.
+func (t *Timestamp) parseEpoch(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return fmt.Errorf("models: unrecognized timestamp %s", s)
+	}
+	if math.Abs(f) >= epochMillisThreshold {
+		t.Time = time.UnixMilli(int64(f)).UTC()
+		return nil
+	}
+	sec, frac := math.Modf(f)
+	t.Time = time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return t.Time.MarshalJSON()
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	base := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{"rfc3339", `"2024-03-15T10:30:00Z"`, base},
+		{"rfc3339 offset", `"2024-03-15T12:30:00+02:00"`, base},
+		{"rfc3339 nano", `"2024-03-15T10:30:00.123456789Z"`, base.Add(123456789)},
+		{"no timezone", `"2024-03-15T10:30:00"`, base},
+		{"no timezone micros", `"2024-03-15T10:30:00.250000"`, base.Add(250 * time.Millisecond)},
+		{"space separator", `"2024-03-15 10:30:00"`, base},
+		{"epoch seconds", `1710498600`, base},
+		{"epoch fractional", `1710498600.5`, base.Add(500 * time.Millisecond)},
+		{"epoch millis", `1710498600000`, base},
+		{"epoch string", `"1710498600"`, base},
+		{"null", `null`, time.Time{}},
+		{"empty", `""`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts Timestamp
+			if err := json.Unmarshal([]byte(tt.input), &ts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !ts.Equal(tt.want) {
+				t.Errorf("got %v, want %v", ts.Time, tt.want)
+			}
+		})
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Error("expected error for unrecognized timestamp")
+	}
+}
+
+func TestTimestampInModels(t *testing.T) {
+	var run WorkflowRun
+	data := []byte(`{"id": "run-1", "started_at": "2024-03-15T10:30:00", "completed_at": 1710498660}`)
+	if err := json.Unmarshal(data, &run); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.CompletedAt == nil || run.CompletedAt.Sub(run.StartedAt.Time) != time.Minute {
+		t.Errorf("unexpected timestamps: started %v, completed %v", run.StartedAt, run.CompletedAt)
+	}
+
+	out, err := json.Marshal(run.StartedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `"2024-03-15T10:30:00Z"` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTrigger is returned when a trigger fails client-side
+// validation.
+var ErrInvalidTrigger = errors.New("copilot: invalid trigger")
+
+// TriggerEventType is the kind of event that fires a workflow trigger.
+type TriggerEventType string
+
+const (
+	TriggerEventConversationCreated TriggerEventType = "conversation.created"
+	TriggerEventMessageCreated      TriggerEventType = "message.created"
+	TriggerEventWebhookReceived     TriggerEventType = "webhook.received"
+	TriggerEventContextCreated      TriggerEventType = "context.created"
+	TriggerEventContextUpdated      TriggerEventType = "context.updated"
+	TriggerEventContextDeleted      TriggerEventType = "context.deleted"
+)
+
+// Trigger starts a run of a workflow whenever an event of its type occurs
+// and matches its filter. The event's data is the run's input.
+type Trigger struct {
+	ID         string           `json:"id"`
+	WorkflowID WorkflowID       `json:"workflow_id"`
+	EventType  TriggerEventType `json:"event_type"`
+	// Filter is a condition over the event, such as
+	// event.data.type == "file"; an empty filter matches every event.
+	Filter      Condition `json:"filter,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   Timestamp `json:"created_at"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+}
+
+// TriggerCreate represents a request to create a trigger.
+type TriggerCreate struct {
+	WorkflowID  WorkflowID       `json:"workflow_id"`
+	EventType   TriggerEventType `json:"event_type"`
+	Filter      Condition        `json:"filter,omitempty"`
+	Description string           `json:"description,omitempty"`
+}
+
+// Validate checks the trigger's workflow, event type and filter.
+func (t *TriggerCreate) Validate() error {
+	if err := t.WorkflowID.Validate(); err != nil {
+		return err
+	}
+	if t.EventType == "" {
+		return fmt.Errorf("%w: missing event type", ErrInvalidTrigger)
+	}
+	return validateFilter(t.Filter)
+}
+
+// TriggerUpdate represents a partial update to a trigger. Nil fields are
+// left unchanged; an empty Filter matches every event.
+type TriggerUpdate struct {
+	EventType   *TriggerEventType `json:"event_type,omitempty"`
+	Filter      *Condition        `json:"filter,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Active      *bool             `json:"active,omitempty"`
+}
+
+// Validate checks the updated event type and filter.
+func (t *TriggerUpdate) Validate() error {
+	if t.EventType != nil && *t.EventType == "" {
+		return fmt.Errorf("%w: missing event type", ErrInvalidTrigger)
+	}
+	if t.Filter != nil {
+		return validateFilter(*t.Filter)
+	}
+	return nil
+}
+
+func validateFilter(filter Condition) error {
+	if filter == "" {
+		return nil
+	}
+	if err := filter.Validate(); err != nil {
+		return fmt.Errorf("%w: filter: %w", ErrInvalidTrigger, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// PresignedURL is a time-limited URL for moving content directly to or
+// from object storage, bypassing the API.
+type PresignedURL struct {
+	URL string `json:"url"`
+	// Method is the HTTP method the URL is signed for.
+	Method string `json:"method"`
+	// Headers must be sent with the request for the signature to match.
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt Timestamp         `json:"expires_at"`
+}
+
+// Expired reports whether the URL has expired.
+func (u *PresignedURL) Expired() bool {
+	return !u.ExpiresAt.IsZero() && !time.Now().Before(u.ExpiresAt.Time)
+}
+
+// UploadURLRequest represents a request for a presigned URL to upload the
+// content of a new context item.
+type UploadURLRequest struct {
+	Type ContextType `json:"type"`
+	Name string      `json:"name"`
+	// Size is the content's length in bytes, which the upload must match.
+	Size        int64                  `json:"size"`
+	ContentType string                 `json:"content_type,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+}
+
+// PresignedUpload is a presigned URL for uploading a context item's
+// content. The item is created once the upload is completed.
+type PresignedUpload struct {
+	UploadID string `json:"upload_id"`
+	PresignedURL
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// UsageGroupBy selects the dimension a usage report is broken down by.
+type UsageGroupBy string
+
+const (
+	UsageGroupByModel  UsageGroupBy = "model"
+	UsageGroupByUser   UsageGroupBy = "user"
+	UsageGroupByAPIKey UsageGroupBy = "api_key"
+)
+
+// UsageQuery selects the time range and breakdown of a usage report.
+type UsageQuery struct {
+	From    time.Time
+	To      time.Time
+	GroupBy UsageGroupBy
+}
+
+// UsageTotals holds token, request, and cost counters.
+type UsageTotals struct {
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	Requests         int64   `json:"requests"`
+	Cost             float64 `json:"cost"`
+}
+
+// UsageBreakdown holds usage for a single model, user, or API key.
+type UsageBreakdown struct {
+	// Key is the model name, user ID, or API key ID depending on the grouping.
+	Key string `json:"key"`
+	// Label is a human-readable name for the key, if available.
+	Label string `json:"label,omitempty"`
+	UsageTotals
+}
+
+// UsageReport represents token, request, and cost usage over a time range.
+type UsageReport struct {
+	From      Timestamp        `json:"from"`
+	To        Timestamp        `json:"to"`
+	GroupBy   UsageGroupBy     `json:"group_by,omitempty"`
+	Currency  string           `json:"currency"`
+	Totals    UsageTotals      `json:"totals"`
+	Breakdown []UsageBreakdown `json:"breakdown,omitempty"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import "encoding/json"
+
+// WebhookEventType represents the name of an event delivered to webhooks.
+type WebhookEventType string
+
+const (
+	WebhookEventConversationCreated   WebhookEventType = "conversation.created"
+	WebhookEventConversationDeleted   WebhookEventType = "conversation.deleted"
+	WebhookEventMessageCreated        WebhookEventType = "message.created"
+	WebhookEventHandoffRequested      WebhookEventType = "conversation.handoff.requested"
+	WebhookEventHandoffResolved       WebhookEventType = "conversation.handoff.resolved"
+	WebhookEventWorkflowRunStarted    WebhookEventType = "workflow.run.started"
+	WebhookEventWorkflowRunCompleted  WebhookEventType = "workflow.run.completed"
+	WebhookEventWorkflowRunFailed     WebhookEventType = "workflow.run.failed"
+	WebhookEventWorkflowRunCancelled  WebhookEventType = "workflow.run.cancelled"
+	WebhookEventWorkflowStepCompleted WebhookEventType = "workflow.step.completed"
+	WebhookEventPing                  WebhookEventType = "ping"
+)
+
+// Webhook represents a webhook subscription.
+type Webhook struct {
+	ID          string             `json:"id"`
+	URL         string             `json:"url"`
+	Events      []WebhookEventType `json:"events"`
+	Description string             `json:"description,omitempty"`
+	Active      bool               `json:"active"`
+	CreatedAt   Timestamp          `json:"created_at"`
+	UpdatedAt   Timestamp          `json:"updated_at"`
+}
+
+// WebhookCreate represents a request to create a webhook subscription.
+type WebhookCreate struct {
+	URL         string             `json:"url"`
+	Events      []WebhookEventType `json:"events"`
+	Secret      string             `json:"secret,omitempty"`
+	Description string             `json:"description,omitempty"`
+}
+
+// WebhookUpdate represents a partial update to a webhook subscription.
+// Zero-valued fields are left unchanged.
+type WebhookUpdate struct {
+	URL         string             `json:"url,omitempty"`
+	Events      []WebhookEventType `json:"events,omitempty"`
+	Secret      string             `json:"secret,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Active      *bool              `json:"active,omitempty"`
+}
+
+// WebhookDelivery represents the outcome of delivering an event to a webhook.
+type WebhookDelivery struct {
+	ID          string           `json:"id"`
+	WebhookID   string           `json:"webhook_id"`
+	EventType   WebhookEventType `json:"event_type"`
+	StatusCode  int              `json:"status_code"`
+	Success     bool             `json:"success"`
+	DurationMs  int64            `json:"duration_ms"`
+	Error       string           `json:"error,omitempty"`
+	DeliveredAt Timestamp        `json:"delivered_at"`
+}
+
+// WebhookEvent is the envelope of every payload delivered to a webhook.
+type WebhookEvent struct {
+	ID            string           `json:"id"`
+	Type          WebhookEventType `json:"type"`
+	TenantID      string           `json:"tenant_id,omitempty"`
+	CorrelationID string           `json:"correlation_id,omitempty"`
+	CreatedAt     Timestamp        `json:"created_at"`
+	Data          json.RawMessage  `json:"data"`
+}
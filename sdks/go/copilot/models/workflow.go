@@ -0,0 +1,134 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidWorkflow is returned when a workflow definition fails
+// client-side validation.
+var ErrInvalidWorkflow = errors.New("copilot: invalid workflow")
+
+// ErrInvalidRun is returned when a run request fails client-side
+// validation.
+var ErrInvalidRun = errors.New("copilot: invalid run")
+
+// BackoffStrategy is how the delay between attempts of a step grows.
+type BackoffStrategy string
+
+const (
+	BackoffFixed       BackoffStrategy = "fixed"
+	BackoffLinear      BackoffStrategy = "linear"
+	BackoffExponential BackoffStrategy = "exponential"
+)
+
+// MaxStepAttempts bounds StepRetryPolicy.MaxAttempts.
+const MaxStepAttempts = 10
+
+// StepRetryPolicy retries a failed workflow step.
+type StepRetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first, from 1
+	// to MaxStepAttempts.
+	MaxAttempts int          `json:"max_attempts"`
+	Backoff     *StepBackoff `json:"backoff,omitempty"`
+}
+
+// StepBackoff spaces the attempts of a step. The zero value retries
+// immediately.
+type StepBackoff struct {
+	Strategy BackoffStrategy `json:"strategy"`
+	// InitialSeconds is the delay before the first retry.
+	InitialSeconds float64 `json:"initial_seconds"`
+	// MaxSeconds caps the delay; zero means no cap.
+	MaxSeconds float64 `json:"max_seconds,omitempty"`
+}
+
+// Validate reports whether the step's condition expression, retry policy
+// and timeout are acceptable to the API.
+func (s *WorkflowStep) Validate() error {
+	if s.Type == StepTypeCondition {
+		cond, ok := s.Condition()
+		if !ok {
+			return fmt.Errorf("%w: step %q: missing condition expression", ErrInvalidWorkflow, s.ID)
+		}
+		if err := cond.Validate(); err != nil {
+			return fmt.Errorf("%w: step %q: %w", ErrInvalidWorkflow, s.ID, err)
+		}
+	}
+	if s.TimeoutSeconds < 0 {
+		return fmt.Errorf("%w: step %q: negative timeout", ErrInvalidWorkflow, s.ID)
+	}
+	if p := s.RetryPolicy; p != nil {
+		if p.MaxAttempts < 1 || p.MaxAttempts > MaxStepAttempts {
+			return fmt.Errorf("%w: step %q: max attempts must be between 1 and %d", ErrInvalidWorkflow, s.ID, MaxStepAttempts)
+		}
+		if b := p.Backoff; b != nil {
+			switch b.Strategy {
+			case BackoffFixed, BackoffLinear, BackoffExponential:
+			default:
+				return fmt.Errorf("%w: step %q: unknown backoff strategy %q", ErrInvalidWorkflow, s.ID, b.Strategy)
+			}
+			if b.InitialSeconds < 0 || b.MaxSeconds < 0 {
+				return fmt.Errorf("%w: step %q: negative backoff", ErrInvalidWorkflow, s.ID)
+			}
+			if b.MaxSeconds > 0 && b.MaxSeconds < b.InitialSeconds {
+				return fmt.Errorf("%w: step %q: backoff max is below its initial delay", ErrInvalidWorkflow, s.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the steps of a workflow before it is created.
+func (w *WorkflowDefinitionCreate) Validate() error {
+	for i := range w.Steps {
+		if err := w.Steps[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate reports whether the run's limits and concurrency controls are
+// acceptable to the API.
+func (r *WorkflowRunCreate) Validate() error {
+	if r.TimeoutSeconds < 0 {
+		return fmt.Errorf("%w: negative timeout", ErrInvalidRun)
+	}
+	if r.MaxCostUSD < 0 {
+		return fmt.Errorf("%w: negative max cost", ErrInvalidRun)
+	}
+	switch r.ConcurrencyPolicy {
+	case "":
+	case ConcurrencyQueue, ConcurrencySkip, ConcurrencyCancelPrevious:
+		if r.ConcurrencyKey == "" {
+			return fmt.Errorf("%w: concurrency policy without a key", ErrInvalidRun)
+		}
+	default:
+		return fmt.Errorf("%w: unknown concurrency policy %q", ErrInvalidRun, r.ConcurrencyPolicy)
+	}
+	return nil
+}
+
+// ValidateInput checks run input data against the workflow's InputSchema,
+// returning a *SchemaError if it does not match. Missing input is checked
+// as an empty object.
+func (w *WorkflowDefinition) ValidateInput(input map[string]interface{}) error {
+	return validateData(w.InputSchema, input)
+}
+
+// ValidateOutput checks run output data against the workflow's
+// OutputSchema, returning a *SchemaError if it does not match.
+func (w *WorkflowDefinition) ValidateOutput(output map[string]interface{}) error {
+	return validateData(w.OutputSchema, output)
+}
+
+func validateData(schema JSONSchema, data map[string]interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return schema.Validate(data)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWorkflowStepValidate(t *testing.T) {
+	valid := []WorkflowStep{
+		{ID: "plain"},
+		{ID: "timeout", TimeoutSeconds: 30},
+		{ID: "retry", RetryPolicy: &StepRetryPolicy{MaxAttempts: 3}},
+		{ID: "backoff", RetryPolicy: &StepRetryPolicy{MaxAttempts: MaxStepAttempts, Backoff: &StepBackoff{Strategy: BackoffExponential, InitialSeconds: 1, MaxSeconds: 30}}},
+	}
+	for _, step := range valid {
+		if err := step.Validate(); err != nil {
+			t.Errorf("%s: unexpected error: %v", step.ID, err)
+		}
+	}
+
+	invalid := []WorkflowStep{
+		{ID: "timeout", TimeoutSeconds: -1},
+		{ID: "zero", RetryPolicy: &StepRetryPolicy{}},
+		{ID: "many", RetryPolicy: &StepRetryPolicy{MaxAttempts: MaxStepAttempts + 1}},
+		{ID: "strategy", RetryPolicy: &StepRetryPolicy{MaxAttempts: 2, Backoff: &StepBackoff{Strategy: "random"}}},
+		{ID: "negative", RetryPolicy: &StepRetryPolicy{MaxAttempts: 2, Backoff: &StepBackoff{Strategy: BackoffFixed, InitialSeconds: -1}}},
+		{ID: "cap", RetryPolicy: &StepRetryPolicy{MaxAttempts: 2, Backoff: &StepBackoff{Strategy: BackoffLinear, InitialSeconds: 10, MaxSeconds: 5}}},
+	}
+	for _, step := range invalid {
+		err := step.Validate()
+		if !errors.Is(err, ErrInvalidWorkflow) || !strings.Contains(err.Error(), `"`+step.ID+`"`) {
+			t.Errorf("%s: expected ErrInvalidWorkflow naming the step, got %v", step.ID, err)
+		}
+	}
+
+	def := WorkflowDefinitionCreate{Steps: append(valid, invalid[0])}
+	if err := def.Validate(); !errors.Is(err, ErrInvalidWorkflow) {
+		t.Errorf("expected the definition to be invalid, got %v", err)
+	}
+}
+
+func TestWorkflowStepPolicySerialization(t *testing.T) {
+	step := WorkflowStep{
+		ID:             "fetch",
+		Type:           StepTypeTool,
+		RetryPolicy:    &StepRetryPolicy{MaxAttempts: 3, Backoff: &StepBackoff{Strategy: BackoffExponential, InitialSeconds: 0.5}},
+		TimeoutSeconds: 20,
+	}
+	data, err := json.Marshal(step)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	want := `"retry_policy":{"max_attempts":3,"backoff":{"strategy":"exponential","initial_seconds":0.5}},"timeout_seconds":20`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("expected %s in %s", want, data)
+	}
+
+	data, _ = json.Marshal(WorkflowStep{ID: "plain"})
+	if strings.Contains(string(data), "retry_policy") || strings.Contains(string(data), "timeout_seconds") {
+		t.Errorf("expected no policy fields, got %s", data)
+	}
+}
+
+func TestWorkflowRunCreateValidate(t *testing.T) {
+	req := WorkflowRunCreate{WorkflowID: "wf-1", TimeoutSeconds: 600, MaxCostUSD: 2.5}
+	if err := req.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(req)
+	if want := `"timeout_seconds":600,"max_cost_usd":2.5`; !strings.Contains(string(data), want) {
+		t.Errorf("expected %s in %s", want, data)
+	}
+
+	req = WorkflowRunCreate{WorkflowID: "wf-1", ConcurrencyKey: "repo/sdk", ConcurrencyPolicy: ConcurrencyCancelPrevious}
+	if err := req.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := []WorkflowRunCreate{
+		{TimeoutSeconds: -1},
+		{MaxCostUSD: -0.01},
+		{ConcurrencyPolicy: ConcurrencySkip},
+		{ConcurrencyKey: "repo/sdk", ConcurrencyPolicy: "replace"},
+	}
+	for _, req := range invalid {
+		if err := req.Validate(); !errors.Is(err, ErrInvalidRun) {
+			t.Errorf("%+v: expected ErrInvalidRun, got %v", req, err)
+		}
+	}
+}
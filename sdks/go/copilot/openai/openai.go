@@ -0,0 +1,236 @@
+// Package openai exposes the CoPilot API through the request and response
+// shapes of OpenAI's chat completions API, so that code written against an
+// OpenAI client can migrate by swapping imports.
+//
+// OpenAI requests are stateless and carry the whole history, while CoPilot
+// keeps history in conversations. A Client maps each request onto a
+// conversation: the first request creates one, with any system messages as
+// its system prompt, and a later request whose history matches an earlier
+// exchange continues the same conversation by sending only its final user
+// message.
+package openai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/client"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// Chat message roles.
+const (
+	ChatMessageRoleSystem    = "system"
+	ChatMessageRoleDeveloper = "developer"
+	ChatMessageRoleUser      = "user"
+	ChatMessageRoleAssistant = "assistant"
+)
+
+// Finish reasons.
+const (
+	FinishReasonStop = "stop"
+)
+
+// DefaultModel is reported when a request names no model. CoPilot selects
+// the model server-side, so ChatCompletionRequest.Model is only echoed.
+const DefaultModel = "copilot"
+
+// ErrNoUserMessage is returned when a request does not end with a user
+// message.
+var ErrNoUserMessage = errors.New("openai: last message must have role user")
+
+// ChatCompletionMessage is a message of a chat completion.
+type ChatCompletionMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	Name    string `json:"name,omitempty"`
+}
+
+// ChatCompletionRequest is a chat completion request. Sampling parameters
+// are accepted for compatibility; CoPilot applies its own configuration.
+type ChatCompletionRequest struct {
+	Model       string                  `json:"model"`
+	Messages    []ChatCompletionMessage `json:"messages"`
+	Temperature float32                 `json:"temperature,omitempty"`
+	TopP        float32                 `json:"top_p,omitempty"`
+	MaxTokens   int                     `json:"max_tokens,omitempty"`
+	Stream      bool                    `json:"stream,omitempty"`
+	User        string                  `json:"user,omitempty"`
+	Metadata    map[string]string       `json:"metadata,omitempty"`
+}
+
+// Usage reports token counts. CoPilot does not report them per message,
+// so they are zero.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// ChatCompletionChoice is a choice of a chat completion response.
+type ChatCompletionChoice struct {
+	Index        int                   `json:"index"`
+	Message      ChatCompletionMessage `json:"message"`
+	FinishReason string                `json:"finish_reason"`
+}
+
+// ChatCompletionResponse is a chat completion response.
+type ChatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   Usage                  `json:"usage"`
+}
+
+// maxConversations bounds the history-to-conversation index.
+const maxConversations = 1024
+
+// Client serves chat completions from a CoPilot client. It is safe for
+// concurrent use.
+type Client struct {
+	client *client.Client
+
+	mu            sync.Mutex
+	conversations map[string]models.ConversationID
+	order         []string
+}
+
+// NewClient returns a Client backed by c.
+func NewClient(c *client.Client) *Client {
+	return &Client{client: c, conversations: make(map[string]models.ConversationID)}
+}
+
+// CreateChatCompletion sends the request's final user message and returns
+// the assistant's reply.
+func (c *Client) CreateChatCompletion(ctx context.Context, req ChatCompletionRequest) (ChatCompletionResponse, error) {
+	conversationID, content, err := c.prepare(ctx, req)
+	if err != nil {
+		return ChatCompletionResponse{}, err
+	}
+
+	msg, err := c.client.SendMessage(ctx, conversationID, content)
+	if err != nil {
+		return ChatCompletionResponse{}, err
+	}
+	c.remember(req.Messages, msg.Content, conversationID)
+
+	return ChatCompletionResponse{
+		ID:      completionID(msg.ID.String()),
+		Object:  "chat.completion",
+		Created: created(msg.CreatedAt.Time),
+		Model:   model(req),
+		Choices: []ChatCompletionChoice{{
+			Message:      ChatCompletionMessage{Role: ChatMessageRoleAssistant, Content: msg.Content},
+			FinishReason: FinishReasonStop,
+		}},
+	}, nil
+}
+
+// prepare resolves the conversation a request continues, creating one if
+// needed, and returns the content to send.
+func (c *Client) prepare(ctx context.Context, req ChatCompletionRequest) (models.ConversationID, string, error) {
+	if len(req.Messages) == 0 || req.Messages[len(req.Messages)-1].Role != ChatMessageRoleUser {
+		return "", "", ErrNoUserMessage
+	}
+	last := req.Messages[len(req.Messages)-1]
+	history := req.Messages[:len(req.Messages)-1]
+
+	c.mu.Lock()
+	conversationID, ok := c.conversations[historyKey(history)]
+	c.mu.Unlock()
+	if ok {
+		return conversationID, last.Content, nil
+	}
+
+	var system []string
+	var turns []ChatCompletionMessage
+	for _, m := range history {
+		switch m.Role {
+		case ChatMessageRoleSystem, ChatMessageRoleDeveloper:
+			system = append(system, m.Content)
+		case ChatMessageRoleUser, ChatMessageRoleAssistant:
+			turns = append(turns, m)
+		default:
+			return "", "", fmt.Errorf("openai: unsupported message role %q", m.Role)
+		}
+	}
+
+	conv, err := c.client.CreateConversation(ctx, &models.ConversationCreate{
+		SystemPrompt: strings.Join(system, "\n\n"),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	// History the conversation has not seen is passed along as a
+	// transcript ahead of the new message.
+	content := last.Content
+	if len(turns) > 0 {
+		var b strings.Builder
+		b.WriteString("Conversation so far:\n")
+		for _, m := range turns {
+			fmt.Fprintf(&b, "%s: %s\n", m.Role, m.Content)
+		}
+		b.WriteString("\n")
+		b.WriteString(last.Content)
+		content = b.String()
+	}
+	return conv.ID, content, nil
+}
+
+// remember records that the conversation now holds messages followed by
+// the assistant's reply, so a request extending that history continues it.
+func (c *Client) remember(messages []ChatCompletionMessage, reply string, conversationID models.ConversationID) {
+	history := append(append([]ChatCompletionMessage(nil), messages...),
+		ChatCompletionMessage{Role: ChatMessageRoleAssistant, Content: reply})
+	key := historyKey(history)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.conversations[key]; !ok {
+		c.order = append(c.order, key)
+	}
+	c.conversations[key] = conversationID
+	for len(c.order) > maxConversations {
+		delete(c.conversations, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// historyKey identifies a message history by the hash of its roles and
+// contents.
+func historyKey(messages []ChatCompletionMessage) string {
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	for _, m := range messages {
+		enc.Encode([2]string{m.Role, m.Content})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func completionID(messageID string) string {
+	return "chatcmpl-" + messageID
+}
+
+func created(t time.Time) int64 {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.Unix()
+}
+
+func model(req ChatCompletionRequest) string {
+	if req.Model == "" {
+		return DefaultModel
+	}
+	return req.Model
+}
@@ -0,0 +1,114 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/copilottest"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestCreateChatCompletion(t *testing.T) {
+	server := copilottest.NewFakeServer()
+	defer server.Close()
+	var conversations []models.ConversationID
+	server.Responder = func(id models.ConversationID, content string) string {
+		conversations = append(conversations, id)
+		return "reply to " + content
+	}
+
+	c := NewClient(server.Client())
+	ctx := context.Background()
+
+	messages := []ChatCompletionMessage{
+		{Role: ChatMessageRoleSystem, Content: "Be brief."},
+		{Role: ChatMessageRoleUser, Content: "hello"},
+	}
+	resp, err := c.CreateChatCompletion(ctx, ChatCompletionRequest{Model: "gpt-4o", Messages: messages})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Object != "chat.completion" || resp.Model != "gpt-4o" || len(resp.Choices) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	reply := resp.Choices[0].Message
+	if reply.Role != ChatMessageRoleAssistant || reply.Content != "reply to hello" || resp.Choices[0].FinishReason != FinishReasonStop {
+		t.Errorf("unexpected choice %+v", resp.Choices[0])
+	}
+
+	// Extending the history continues the same conversation.
+	messages = append(messages, reply, ChatCompletionMessage{Role: ChatMessageRoleUser, Content: "again"})
+	resp, err = c.CreateChatCompletion(ctx, ChatCompletionRequest{Messages: messages})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "reply to again" || resp.Model != DefaultModel {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if len(conversations) != 2 || conversations[0] != conversations[1] {
+		t.Errorf("expected one conversation, got %v", conversations)
+	}
+
+	// Unknown history starts a new conversation with a transcript.
+	resp, err = c.CreateChatCompletion(ctx, ChatCompletionRequest{Messages: []ChatCompletionMessage{
+		{Role: ChatMessageRoleUser, Content: "hi"},
+		{Role: ChatMessageRoleAssistant, Content: "hey"},
+		{Role: ChatMessageRoleUser, Content: "bye"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Choices[0].Message.Content; !strings.Contains(got, "assistant: hey") || !strings.HasSuffix(got, "bye") {
+		t.Errorf("expected transcript in message, got %q", got)
+	}
+	if conversations[2] == conversations[0] {
+		t.Error("expected a new conversation")
+	}
+
+	if _, err := c.CreateChatCompletion(ctx, ChatCompletionRequest{Messages: messages[:1]}); !errors.Is(err, ErrNoUserMessage) {
+		t.Errorf("expected ErrNoUserMessage, got %v", err)
+	}
+}
+
+func TestCreateChatCompletionStream(t *testing.T) {
+	server := copilottest.NewFakeServer()
+	defer server.Close()
+
+	c := NewClient(server.Client())
+	stream, err := c.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "hello there"}},
+		Stream:   true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	var content strings.Builder
+	var chunks []ChatCompletionStreamResponse
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		chunks = append(chunks, chunk)
+		content.WriteString(chunk.Choices[0].Delta.Content)
+	}
+
+	if content.String() != "You said: hello there" {
+		t.Errorf("unexpected content %q", content.String())
+	}
+	first, last := chunks[0], chunks[len(chunks)-1]
+	if first.Choices[0].Delta.Role != ChatMessageRoleAssistant || first.Object != "chat.completion.chunk" {
+		t.Errorf("unexpected first chunk %+v", first)
+	}
+	if last.Choices[0].FinishReason != FinishReasonStop || !strings.HasPrefix(last.ID, "chatcmpl-") {
+		t.Errorf("unexpected last chunk %+v", last)
+	}
+}
@@ -0,0 +1,129 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+	"github.com/llm-copilot-agent/sdk-go/copilot/streaming"
+)
+
+// ChatCompletionStreamChoiceDelta is the incremental content of a chunk.
+type ChatCompletionStreamChoiceDelta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// ChatCompletionStreamChoice is a choice of a streamed chunk.
+type ChatCompletionStreamChoice struct {
+	Index        int                             `json:"index"`
+	Delta        ChatCompletionStreamChoiceDelta `json:"delta"`
+	FinishReason string                          `json:"finish_reason,omitempty"`
+}
+
+// ChatCompletionStreamResponse is one chunk of a streamed chat completion.
+type ChatCompletionStreamResponse struct {
+	ID      string                       `json:"id"`
+	Object  string                       `json:"object"`
+	Created int64                        `json:"created"`
+	Model   string                       `json:"model"`
+	Choices []ChatCompletionStreamChoice `json:"choices"`
+}
+
+// ChatCompletionStream reads a streamed chat completion chunk by chunk.
+type ChatCompletionStream struct {
+	client         *Client
+	stream         *streaming.Stream
+	cancel         context.CancelFunc
+	request        ChatCompletionRequest
+	conversationID models.ConversationID
+	created        int64
+	id             string
+	sentRole       bool
+	finished       bool
+}
+
+// CreateChatCompletionStream sends the request's final user message and
+// streams the assistant's reply. Close the stream when done.
+func (c *Client) CreateChatCompletionStream(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionStream, error) {
+	conversationID, content, err := c.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancelled by Close so the processing goroutine exits even when the
+	// caller stops reading early.
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.client.StreamMessage(ctx, conversationID, content)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stream.Start(ctx)
+
+	return &ChatCompletionStream{
+		client:         c,
+		stream:         stream,
+		cancel:         cancel,
+		request:        req,
+		conversationID: conversationID,
+		created:        time.Now().Unix(),
+	}, nil
+}
+
+// Recv returns the next chunk. The first chunk carries the assistant role
+// and the last one the finish reason; after it Recv returns io.EOF.
+func (s *ChatCompletionStream) Recv() (ChatCompletionStreamResponse, error) {
+	for !s.finished {
+		event, ok := <-s.stream.Events()
+		if !ok {
+			if err := s.stream.Err(); err != nil {
+				return ChatCompletionStreamResponse{}, err
+			}
+			return s.finish(), nil
+		}
+		if event.MessageID != "" {
+			s.id = completionID(event.MessageID)
+		}
+
+		switch event.Type {
+		case streaming.EventContentDelta:
+			delta := ChatCompletionStreamChoiceDelta{Content: event.Content()}
+			if !s.sentRole {
+				delta.Role = ChatMessageRoleAssistant
+				s.sentRole = true
+			}
+			return s.chunk(delta, ""), nil
+		case streaming.EventMessageEnd:
+			return s.finish(), nil
+		case streaming.EventError:
+			return ChatCompletionStreamResponse{}, fmt.Errorf("stream error: %s", event.Error)
+		}
+	}
+	return ChatCompletionStreamResponse{}, io.EOF
+}
+
+// finish returns the final chunk and records the completed exchange.
+func (s *ChatCompletionStream) finish() ChatCompletionStreamResponse {
+	s.finished = true
+	s.client.remember(s.request.Messages, s.stream.AccumulatedContent(), s.conversationID)
+	return s.chunk(ChatCompletionStreamChoiceDelta{}, FinishReasonStop)
+}
+
+func (s *ChatCompletionStream) chunk(delta ChatCompletionStreamChoiceDelta, finishReason string) ChatCompletionStreamResponse {
+	return ChatCompletionStreamResponse{
+		ID:      s.id,
+		Object:  "chat.completion.chunk",
+		Created: s.created,
+		Model:   model(s.request),
+		Choices: []ChatCompletionStreamChoice{{Delta: delta, FinishReason: finishReason}},
+	}
+}
+
+// Close stops the stream.
+func (s *ChatCompletionStream) Close() error {
+	s.cancel()
+	return s.stream.Close()
+}
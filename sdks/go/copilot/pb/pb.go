@@ -0,0 +1,336 @@
+// Package pb encodes CoPilot models in the Protocol Buffers binary format
+// described by models.proto, for services that persist or ship CoPilot
+// objects on internal buses. Data written here can be read by bindings
+// generated from models.proto in any language, and vice versa.
+//
+// The codec is hand-written against the wire format so that the SDK keeps
+// no dependencies; fields unknown to it are skipped.
+package pb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// MarshalMessage encodes msg as a copilot.sdk.v1.Message.
+func MarshalMessage(msg *models.Message) ([]byte, error) {
+	var e encoder
+	if err := encodeMessage(&e, msg); err != nil {
+		return nil, err
+	}
+	return e.b, nil
+}
+
+// UnmarshalMessage decodes a copilot.sdk.v1.Message.
+func UnmarshalMessage(data []byte) (*models.Message, error) {
+	var msg models.Message
+	if err := decodeMessage(data, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// MarshalConversation encodes conv as a copilot.sdk.v1.Conversation.
+func MarshalConversation(conv *models.Conversation) ([]byte, error) {
+	var e encoder
+	e.string(1, conv.ID.String())
+	e.string(2, conv.Title)
+	e.string(3, conv.UserID)
+	e.string(4, conv.TenantID)
+	if err := e.json(5, conv.Metadata); err != nil {
+		return nil, err
+	}
+	e.int64(6, int64(conv.MessageCount))
+	e.string(7, conv.CorrelationID)
+	e.string(8, string(conv.HandoffStatus))
+	if h := conv.Handoff; h != nil {
+		e.message(9, func(sub *encoder) {
+			sub.string(1, h.Reason)
+			sub.string(2, h.AgentID)
+			sub.string(3, h.Resolution)
+			sub.timestamp(4, h.RequestedAt.Time)
+			if h.AssignedAt != nil {
+				sub.timestamp(5, h.AssignedAt.Time)
+			}
+			if h.ResolvedAt != nil {
+				sub.timestamp(6, h.ResolvedAt.Time)
+			}
+		})
+	}
+	e.timestamp(10, conv.CreatedAt.Time)
+	e.timestamp(11, conv.UpdatedAt.Time)
+	if err := e.json(12, conv.Extra); err != nil {
+		return nil, err
+	}
+	e.strings(13, conv.Labels)
+	e.string(14, conv.SystemPrompt)
+	return e.b, nil
+}
+
+// UnmarshalConversation decodes a copilot.sdk.v1.Conversation.
+func UnmarshalConversation(data []byte) (*models.Conversation, error) {
+	var conv models.Conversation
+	err := fields(data, func(d *decoder, field int) (bool, error) {
+		var err error
+		switch field {
+		case 1:
+			var id string
+			id, err = d.string()
+			conv.ID = models.ConversationID(id)
+		case 2:
+			conv.Title, err = d.string()
+		case 3:
+			conv.UserID, err = d.string()
+		case 4:
+			conv.TenantID, err = d.string()
+		case 5:
+			err = d.json(&conv.Metadata)
+		case 6:
+			var n int64
+			n, err = d.int64()
+			conv.MessageCount = int(n)
+		case 7:
+			conv.CorrelationID, err = d.string()
+		case 8:
+			var status string
+			status, err = d.string()
+			conv.HandoffStatus = models.HandoffStatus(status)
+		case 9:
+			conv.Handoff = &models.Handoff{}
+			err = decodeHandoff(d, conv.Handoff)
+		case 10:
+			conv.CreatedAt, err = d.timestamp()
+		case 11:
+			conv.UpdatedAt, err = d.timestamp()
+		case 12:
+			err = d.json(&conv.Extra)
+		case 13:
+			var label string
+			label, err = d.string()
+			conv.Labels = append(conv.Labels, label)
+		case 14:
+			conv.SystemPrompt, err = d.string()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
+
+func encodeMessage(e *encoder, msg *models.Message) error {
+	e.string(1, msg.ID.String())
+	e.string(2, msg.ConversationID.String())
+	e.string(3, string(msg.Role))
+	e.string(4, msg.Content)
+	if err := e.json(5, msg.Metadata); err != nil {
+		return err
+	}
+	e.string(6, msg.CorrelationID)
+	e.timestamp(7, msg.CreatedAt.Time)
+	for _, part := range msg.Parts {
+		part := part
+		e.message(8, func(sub *encoder) { encodeContentPart(sub, &part) })
+	}
+	if err := e.json(9, msg.Extra); err != nil {
+		return err
+	}
+	e.string(10, msg.ParentMessageID.String())
+	for _, chunk := range msg.RetrievedContext {
+		chunk := chunk
+		e.message(11, func(sub *encoder) { encodeRetrievedChunk(sub, &chunk) })
+	}
+	return nil
+}
+
+func decodeMessage(data []byte, msg *models.Message) error {
+	return fields(data, func(d *decoder, field int) (bool, error) {
+		var err error
+		var s string
+		switch field {
+		case 1:
+			s, err = d.string()
+			msg.ID = models.MessageID(s)
+		case 2:
+			s, err = d.string()
+			msg.ConversationID = models.ConversationID(s)
+		case 3:
+			s, err = d.string()
+			msg.Role = models.MessageRole(s)
+		case 4:
+			msg.Content, err = d.string()
+		case 5:
+			err = d.json(&msg.Metadata)
+		case 6:
+			msg.CorrelationID, err = d.string()
+		case 7:
+			msg.CreatedAt, err = d.timestamp()
+		case 8:
+			var part models.ContentPart
+			if err = decodeContentPart(d, &part); err == nil {
+				msg.Parts = append(msg.Parts, part)
+			}
+		case 9:
+			err = d.json(&msg.Extra)
+		case 10:
+			s, err = d.string()
+			msg.ParentMessageID = models.MessageID(s)
+		case 11:
+			var chunk models.RetrievedChunk
+			if err = decodeRetrievedChunk(d, &chunk); err == nil {
+				msg.RetrievedContext = append(msg.RetrievedContext, chunk)
+			}
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+func encodeContentPart(e *encoder, part *models.ContentPart) {
+	e.string(1, string(part.Type))
+	e.string(2, part.Text)
+	e.string(3, part.MediaType)
+	e.string(4, part.Data)
+	e.string(5, part.URL)
+	e.string(6, part.ToolUseID)
+	e.string(7, part.ToolName)
+	e.bytes(8, part.Input)
+	e.bool(9, part.IsError)
+}
+
+func decodeContentPart(d *decoder, part *models.ContentPart) error {
+	data, err := d.bytes()
+	if err != nil {
+		return err
+	}
+	return fields(data, func(d *decoder, field int) (bool, error) {
+		var err error
+		var s string
+		switch field {
+		case 1:
+			s, err = d.string()
+			part.Type = models.ContentPartType(s)
+		case 2:
+			part.Text, err = d.string()
+		case 3:
+			part.MediaType, err = d.string()
+		case 4:
+			part.Data, err = d.string()
+		case 5:
+			part.URL, err = d.string()
+		case 6:
+			part.ToolUseID, err = d.string()
+		case 7:
+			part.ToolName, err = d.string()
+		case 8:
+			var b []byte
+			b, err = d.bytes()
+			part.Input = append(json.RawMessage(nil), b...)
+		case 9:
+			part.IsError, err = d.bool()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+func encodeRetrievedChunk(e *encoder, chunk *models.RetrievedChunk) {
+	e.string(1, chunk.ContextItemID)
+	e.string(2, chunk.Name)
+	e.string(3, chunk.Content)
+	e.double(4, chunk.Score)
+}
+
+func decodeRetrievedChunk(d *decoder, chunk *models.RetrievedChunk) error {
+	data, err := d.bytes()
+	if err != nil {
+		return err
+	}
+	return fields(data, func(d *decoder, field int) (bool, error) {
+		var err error
+		switch field {
+		case 1:
+			chunk.ContextItemID, err = d.string()
+		case 2:
+			chunk.Name, err = d.string()
+		case 3:
+			chunk.Content, err = d.string()
+		case 4:
+			chunk.Score, err = d.double()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+func decodeHandoff(d *decoder, h *models.Handoff) error {
+	data, err := d.bytes()
+	if err != nil {
+		return err
+	}
+	return fields(data, func(d *decoder, field int) (bool, error) {
+		var err error
+		switch field {
+		case 1:
+			h.Reason, err = d.string()
+		case 2:
+			h.AgentID, err = d.string()
+		case 3:
+			h.Resolution, err = d.string()
+		case 4:
+			h.RequestedAt, err = d.timestamp()
+		case 5:
+			var t models.Timestamp
+			t, err = d.timestamp()
+			h.AssignedAt = &t
+		case 6:
+			var t models.Timestamp
+			t, err = d.timestamp()
+			h.ResolvedAt = &t
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+}
+
+// json encodes v, a map, as a JSON object in a bytes field, omitting an
+// empty map.
+func (e *encoder) json(field int, v interface{}) error {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		if len(m) == 0 {
+			return nil
+		}
+	case models.Extra:
+		if len(m) == 0 {
+			return nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("pb: failed to encode field %d: %w", field, err)
+	}
+	e.bytes(field, data)
+	return nil
+}
+
+// json decodes a JSON object held in a bytes field into v.
+func (d *decoder) json(v interface{}) error {
+	data, err := d.bytes()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	return nil
+}
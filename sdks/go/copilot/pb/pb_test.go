@@ -0,0 +1,108 @@
+package pb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	msg := &models.Message{
+		ID:             "msg-1",
+		ConversationID: "conv-1",
+		Role:           models.RoleAssistant,
+		Content:        "Checking the weather.",
+		Metadata:       map[string]interface{}{"model": "large", "score": 0.5},
+		CorrelationID:  "corr-1",
+		CreatedAt:      models.NewTimestamp(time.Date(2024, 3, 15, 10, 30, 0, 123456789, time.UTC)),
+		Parts: []models.ContentPart{
+			{Type: models.ContentPartText, Text: "Checking the weather."},
+			{Type: models.ContentPartToolUse, ToolUseID: "tool-1", ToolName: "weather", Input: json.RawMessage(`{"city":"Paris"}`)},
+			{Type: models.ContentPartToolResult, ToolUseID: "tool-1", IsError: true},
+		},
+		ParentMessageID: "msg-0",
+		RetrievedContext: []models.RetrievedChunk{
+			{ContextItemID: "ctx-1", Name: "forecast.md", Content: "Sunny in Paris.", Score: 0.75},
+		},
+		Extra: models.Extra{"rating": json.RawMessage(`5`)},
+	}
+
+	data, err := MarshalMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := UnmarshalMessage(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(msg, got) {
+		t.Errorf("round trip changed message:\n%+v\n%+v", msg, got)
+	}
+}
+
+func TestConversationRoundTrip(t *testing.T) {
+	requested := models.NewTimestamp(time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC))
+	assigned := models.NewTimestamp(requested.Add(time.Minute))
+	conv := &models.Conversation{
+		ID:            "conv-1",
+		Title:         "Support",
+		SystemPrompt:  "Be kind.",
+		UserID:        "user-1",
+		TenantID:      "acme",
+		MessageCount:  12,
+		HandoffStatus: models.HandoffStatusAssigned,
+		Handoff:       &models.Handoff{Reason: "billing", AgentID: "agent-7", RequestedAt: requested, AssignedAt: &assigned},
+		Labels:        []string{"label-1", "label-2"},
+		CreatedAt:     requested,
+		UpdatedAt:     assigned,
+	}
+
+	data, err := MarshalConversation(conv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := UnmarshalConversation(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(conv, got) {
+		t.Errorf("round trip changed conversation:\n%+v\n%+v", conv, got)
+	}
+}
+
+func TestWireFormat(t *testing.T) {
+	data, err := MarshalMessage(&models.Message{
+		ID:        "m",
+		CreatedAt: models.NewTimestamp(time.Unix(1, 2)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// id (field 1, string) and created_at (field 7, Timestamp{1, 2}).
+	want := []byte{0x0a, 0x01, 'm', 0x3a, 0x04, 0x08, 0x01, 0x10, 0x02}
+	if !bytes.Equal(data, want) {
+		t.Errorf("expected % x, got % x", want, data)
+	}
+
+	// Fields from a newer schema are skipped.
+	withUnknown := append([]byte{0xa0, 0x06, 0x07, 0xaa, 0x06, 0x02, 'h', 'i'}, data...)
+	msg, err := UnmarshalMessage(withUnknown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.ID != "m" || msg.CreatedAt.Unix() != 1 {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if _, err := UnmarshalMessage([]byte{0x0a, 0x05, 'm'}); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("expected ErrInvalidEncoding for truncated data, got %v", err)
+	}
+	if _, err := UnmarshalMessage([]byte{0x08, 0x01}); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("expected ErrInvalidEncoding for wrong wire type, got %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package pb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// Wire types of the Protocol Buffers encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// ErrInvalidEncoding is returned when data is not valid Protocol Buffers
+// wire format.
+var ErrInvalidEncoding = errors.New("pb: invalid encoding")
+
+// encoder appends fields in wire format. Fields holding their zero value
+// are omitted, as proto3 does.
+type encoder struct {
+	b []byte
+}
+
+func (e *encoder) tag(field int, wireType int) {
+	e.b = binary.AppendUvarint(e.b, uint64(field)<<3|uint64(wireType))
+}
+
+func (e *encoder) string(field int, s string) {
+	if s != "" {
+		e.bytes(field, []byte(s))
+	}
+}
+
+// strings encodes a repeated string field. Unlike singular fields, empty
+// elements are kept.
+func (e *encoder) strings(field int, ss []string) {
+	for _, s := range ss {
+		e.tag(field, wireBytes)
+		e.b = binary.AppendUvarint(e.b, uint64(len(s)))
+		e.b = append(e.b, s...)
+	}
+}
+
+func (e *encoder) bytes(field int, b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	e.tag(field, wireBytes)
+	e.b = binary.AppendUvarint(e.b, uint64(len(b)))
+	e.b = append(e.b, b...)
+}
+
+func (e *encoder) int64(field int, v int64) {
+	if v != 0 {
+		e.tag(field, wireVarint)
+		e.b = binary.AppendUvarint(e.b, uint64(v))
+	}
+}
+
+func (e *encoder) double(field int, v float64) {
+	if v != 0 {
+		e.tag(field, wireFixed64)
+		e.b = binary.LittleEndian.AppendUint64(e.b, math.Float64bits(v))
+	}
+}
+
+func (e *encoder) bool(field int, v bool) {
+	if v {
+		e.tag(field, wireVarint)
+		e.b = append(e.b, 1)
+	}
+}
+
+// message encodes a nested message. Unlike scalars, an empty message is
+// still written when present.
+func (e *encoder) message(field int, encode func(*encoder)) {
+	var sub encoder
+	encode(&sub)
+	e.tag(field, wireBytes)
+	e.b = binary.AppendUvarint(e.b, uint64(len(sub.b)))
+	e.b = append(e.b, sub.b...)
+}
+
+// timestamp encodes t as a google.protobuf.Timestamp, omitting the zero
+// time.
+func (e *encoder) timestamp(field int, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	e.message(field, func(sub *encoder) {
+		sub.int64(1, t.Unix())
+		sub.int64(2, int64(t.Nanosecond()))
+	})
+}
+
+// decoder reads fields in wire format.
+type decoder struct {
+	b []byte
+	// wireType is the wire type of the current field.
+	wireType int
+}
+
+// next advances to the next field and returns its number, or ok false at
+// the end of the data.
+func (d *decoder) next() (field int, ok bool, err error) {
+	if len(d.b) == 0 {
+		return 0, false, nil
+	}
+	key, err := d.uvarint()
+	if err != nil {
+		return 0, false, err
+	}
+	if key>>3 == 0 || key>>3 > math.MaxInt32 {
+		return 0, false, fmt.Errorf("%w: field number %d", ErrInvalidEncoding, key>>3)
+	}
+	d.wireType = int(key & 7)
+	return int(key >> 3), true, nil
+}
+
+func (d *decoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		return 0, fmt.Errorf("%w: bad varint", ErrInvalidEncoding)
+	}
+	d.b = d.b[n:]
+	return v, nil
+}
+
+// expect checks the current field's wire type.
+func (d *decoder) expect(wireType int) error {
+	if d.wireType != wireType {
+		return fmt.Errorf("%w: unexpected wire type %d", ErrInvalidEncoding, d.wireType)
+	}
+	return nil
+}
+
+func (d *decoder) varint() (uint64, error) {
+	if err := d.expect(wireVarint); err != nil {
+		return 0, err
+	}
+	return d.uvarint()
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	if err := d.expect(wireBytes); err != nil {
+		return nil, err
+	}
+	return d.lengthDelimited()
+}
+
+func (d *decoder) lengthDelimited() ([]byte, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(d.b)) {
+		return nil, fmt.Errorf("%w: truncated field", ErrInvalidEncoding)
+	}
+	b := d.b[:n:n]
+	d.b = d.b[n:]
+	return b, nil
+}
+
+func (d *decoder) string() (string, error) {
+	b, err := d.bytes()
+	return string(b), err
+}
+
+func (d *decoder) int64() (int64, error) {
+	v, err := d.varint()
+	return int64(v), err
+}
+
+func (d *decoder) double() (float64, error) {
+	if err := d.expect(wireFixed64); err != nil {
+		return 0, err
+	}
+	if len(d.b) < 8 {
+		return 0, fmt.Errorf("%w: truncated field", ErrInvalidEncoding)
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.b))
+	d.b = d.b[8:]
+	return v, nil
+}
+
+func (d *decoder) bool() (bool, error) {
+	v, err := d.varint()
+	return v != 0, err
+}
+
+// skip discards the current field, so that data written by a
+// newer schema can be read.
+func (d *decoder) skip() error {
+	var n int
+	switch d.wireType {
+	case wireVarint:
+		_, err := d.uvarint()
+		return err
+	case wireBytes:
+		_, err := d.lengthDelimited()
+		return err
+	case wireFixed64:
+		n = 8
+	case wireFixed32:
+		n = 4
+	default:
+		return fmt.Errorf("%w: wire type %d", ErrInvalidEncoding, d.wireType)
+	}
+	if len(d.b) < n {
+		return fmt.Errorf("%w: truncated field", ErrInvalidEncoding)
+	}
+	d.b = d.b[n:]
+	return nil
+}
+
+// fields calls fn for each field of data. fn returns false for fields it
+// does not know, which are skipped.
+func fields(data []byte, fn func(d *decoder, field int) (bool, error)) error {
+	d := &decoder{b: data}
+	for {
+		field, ok, err := d.next()
+		if err != nil || !ok {
+			return err
+		}
+		known, err := fn(d, field)
+		if err != nil {
+			return err
+		}
+		if !known {
+			if err := d.skip(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// timestamp decodes a google.protobuf.Timestamp.
+func (d *decoder) timestamp() (models.Timestamp, error) {
+	data, err := d.bytes()
+	if err != nil {
+		return models.Timestamp{}, err
+	}
+	var seconds, nanos int64
+	err = fields(data, func(d *decoder, field int) (bool, error) {
+		var err error
+		switch field {
+		case 1:
+			seconds, err = d.int64()
+		case 2:
+			nanos, err = d.int64()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return models.NewTimestamp(time.Unix(seconds, nanos).UTC()), err
+}
@@ -0,0 +1,278 @@
+// Package prompts renders prompt templates on the client, with the same
+// rules the server applies to templates referenced by conversations and
+// workflow steps.
+//
+// A template substitutes variables written as {{name}}; spaces inside the
+// braces are ignored. Write \{{ for a literal "{{". Values are inserted
+// verbatim and never expanded again.
+//
+//	text, err := prompts.Render("Summarize {{doc}} in {{words}} words.", map[string]interface{}{
+//	    "doc":   doc,
+//	    "words": 50,
+//	})
+package prompts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+// Template delimiters.
+const (
+	openDelim   = "{{"
+	closeDelim  = "}}"
+	escapeDelim = `\` + openDelim
+)
+
+// ErrSyntax is wrapped by the errors returned for malformed templates.
+var ErrSyntax = errors.New("prompts: syntax error")
+
+// MissingVariablesError is returned when a template refers to variables
+// that were not supplied.
+type MissingVariablesError struct {
+	Names []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return "prompts: missing variables: " + strings.Join(e.Names, ", ")
+}
+
+// segment is literal text, or a variable reference when name is set.
+type segment struct {
+	text string
+	name string
+}
+
+// parse splits a template into segments.
+func parse(template string) ([]segment, error) {
+	var segs []segment
+	var text strings.Builder
+	for i := 0; i < len(template); {
+		switch {
+		case strings.HasPrefix(template[i:], escapeDelim):
+			text.WriteString(openDelim)
+			i += len(escapeDelim)
+		case strings.HasPrefix(template[i:], openDelim):
+			end := strings.Index(template[i+len(openDelim):], closeDelim)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated %q at offset %d", ErrSyntax, openDelim, i)
+			}
+			name := strings.TrimSpace(template[i+len(openDelim) : i+len(openDelim)+end])
+			if !validName(name) {
+				return nil, fmt.Errorf("%w: invalid variable name %q at offset %d", ErrSyntax, name, i)
+			}
+			if text.Len() > 0 {
+				segs = append(segs, segment{text: text.String()})
+				text.Reset()
+			}
+			segs = append(segs, segment{name: name})
+			i += len(openDelim) + end + len(closeDelim)
+		default:
+			text.WriteByte(template[i])
+			i++
+		}
+	}
+	if text.Len() > 0 {
+		segs = append(segs, segment{text: text.String()})
+	}
+	return segs, nil
+}
+
+// validName reports whether name is made of letters, digits, '_', '-'
+// and '.'.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '-' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Variables returns the names of the variables a template refers to, in
+// order of first use.
+func Variables(template string) ([]string, error) {
+	segs, err := parse(template)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, seg := range segs {
+		if seg.name != "" && !seen[seg.name] {
+			seen[seg.name] = true
+			names = append(names, seg.name)
+		}
+	}
+	return names, nil
+}
+
+// Render substitutes vars into template. Every variable the template
+// refers to must be supplied and not nil; strings are inserted as is,
+// numbers and booleans in their Go form and other values as JSON.
+func Render(template string, vars map[string]interface{}) (string, error) {
+	segs, err := parse(template)
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	var b strings.Builder
+	for _, seg := range segs {
+		if seg.name == "" {
+			b.WriteString(seg.text)
+			continue
+		}
+		v, ok := vars[seg.name]
+		if !ok || v == nil {
+			missing = append(missing, seg.name)
+			continue
+		}
+		s, err := format(v)
+		if err != nil {
+			return "", fmt.Errorf("prompts: variable %q: %w", seg.name, err)
+		}
+		b.WriteString(s)
+	}
+	if len(missing) > 0 {
+		return "", &MissingVariablesError{Names: uniqueSorted(missing)}
+	}
+	return b.String(), nil
+}
+
+// RenderTemplate renders a managed template. Declared variables are
+// checked against their types, and optional ones that are not supplied
+// take their defaults.
+func RenderTemplate(tmpl *models.PromptTemplate, vars map[string]interface{}) (string, error) {
+	merged := make(map[string]interface{}, len(vars))
+	for name, v := range vars {
+		merged[name] = v
+	}
+
+	var missing []string
+	for _, decl := range tmpl.Variables {
+		v, ok := merged[decl.Name]
+		switch {
+		case ok && v != nil:
+			if err := checkType(decl, v); err != nil {
+				return "", err
+			}
+		case decl.Required:
+			missing = append(missing, decl.Name)
+		case decl.Default != "":
+			v, err := ParseValue(decl, decl.Default)
+			if err != nil {
+				return "", fmt.Errorf("prompts: default of %q: %w", decl.Name, err)
+			}
+			merged[decl.Name] = v
+		default:
+			merged[decl.Name] = ""
+		}
+	}
+	if len(missing) > 0 {
+		return "", &MissingVariablesError{Names: uniqueSorted(missing)}
+	}
+	return Render(tmpl.Content, merged)
+}
+
+// ParseValue converts s, such as a command-line argument, to a value of
+// the variable's type.
+func ParseValue(decl models.PromptVariable, s string) (interface{}, error) {
+	switch decl.Type {
+	case models.PromptVariableNumber:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return n, nil
+	case models.PromptVariableBoolean:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", s)
+		}
+		return b, nil
+	case models.PromptVariableJSON:
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return v, nil
+	default:
+		return s, nil
+	}
+}
+
+// checkType reports whether v suits the declared variable.
+func checkType(decl models.PromptVariable, v interface{}) error {
+	ok := true
+	switch decl.Type {
+	case models.PromptVariableNumber:
+		switch v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		default:
+			ok = false
+		}
+	case models.PromptVariableBoolean:
+		_, ok = v.(bool)
+	case models.PromptVariableJSON:
+	default:
+		_, ok = v.(string)
+	}
+	if !ok {
+		typ := decl.Type
+		if typ == "" {
+			typ = models.PromptVariableString
+		}
+		return fmt.Errorf("prompts: variable %q must be a %s, got %T", decl.Name, typ, v)
+	}
+	return nil
+}
+
+// format renders a value for substitution.
+func format(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Escape returns text with every "{{" escaped, so that it reads as
+// literal text when embedded in a template.
+func Escape(text string) string {
+	return strings.ReplaceAll(text, openDelim, escapeDelim)
+}
+
+func uniqueSorted(names []string) []string {
+	sort.Strings(names)
+	out := names[:0]
+	for i, name := range names {
+		if i == 0 || name != names[i-1] {
+			out = append(out, name)
+		}
+	}
+	return out
+}
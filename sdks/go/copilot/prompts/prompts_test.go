@@ -0,0 +1,108 @@
+package prompts
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		template string
+		vars     map[string]interface{}
+		want     string
+	}{
+		{"Hello {{name}}!", map[string]interface{}{"name": "Ada"}, "Hello Ada!"},
+		{"{{ a }}+{{b}}={{a}}", map[string]interface{}{"a": 1, "b": 2.5}, "1+2.5=1"},
+		{"flag={{on}} tags={{tags}}", map[string]interface{}{"on": true, "tags": []string{"x"}}, `flag=true tags=["x"]`},
+		{`literal \{{name}}`, nil, "literal {{name}}"},
+		{"{{v}}", map[string]interface{}{"v": "{{v}}"}, "{{v}}"},
+	}
+	for _, tt := range tests {
+		got, err := Render(tt.template, tt.vars)
+		if err != nil {
+			t.Errorf("Render(%q): unexpected error: %v", tt.template, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Render(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	_, err := Render("{{b}} {{a}} {{b}}", map[string]interface{}{"c": 1})
+	var missing *MissingVariablesError
+	if !errors.As(err, &missing) || !reflect.DeepEqual(missing.Names, []string{"a", "b"}) {
+		t.Errorf("expected missing a and b, got %v", err)
+	}
+
+	for _, template := range []string{"open {{name", "bad {{na me}}", "empty {{ }}"} {
+		if _, err := Render(template, nil); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Render(%q): expected ErrSyntax, got %v", template, err)
+		}
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl := &models.PromptTemplate{
+		Content: "{{greeting}}, {{name}}. Limit: {{limit}}.{{note}}",
+		Variables: []models.PromptVariable{
+			{Name: "name", Required: true},
+			{Name: "greeting", Default: "Hi"},
+			{Name: "limit", Type: models.PromptVariableNumber, Default: "10"},
+			{Name: "note"},
+		},
+	}
+
+	got, err := RenderTemplate(tmpl, map[string]interface{}{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Hi, Ada. Limit: 10."; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	var missing *MissingVariablesError
+	if _, err := RenderTemplate(tmpl, nil); !errors.As(err, &missing) || missing.Names[0] != "name" {
+		t.Errorf("expected name to be required, got %v", err)
+	}
+	if _, err := RenderTemplate(tmpl, map[string]interface{}{"name": "Ada", "limit": "ten"}); err == nil {
+		t.Error("expected a string limit to be rejected")
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		typ  models.PromptVariableType
+		in   string
+		want interface{}
+	}{
+		{models.PromptVariableString, "42", "42"},
+		{models.PromptVariableNumber, "42", 42.0},
+		{models.PromptVariableBoolean, "true", true},
+		{models.PromptVariableJSON, `{"a":1}`, map[string]interface{}{"a": 1.0}},
+	}
+	for _, tt := range tests {
+		got, err := ParseValue(models.PromptVariable{Name: "v", Type: tt.typ}, tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseValue(%s, %q) = %v, %v; want %v", tt.typ, tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseValue(models.PromptVariable{Name: "v", Type: models.PromptVariableNumber}, "x"); err == nil {
+		t.Error("expected an invalid number to fail")
+	}
+}
+
+func TestEscape(t *testing.T) {
+	text := `use {{braces}} and \{{ as is`
+	got, err := Render(Escape(text)+" {{x}}", map[string]interface{}{"x": "ok"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := text + " ok"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
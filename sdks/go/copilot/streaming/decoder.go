@@ -0,0 +1,74 @@
+package streaming
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Media types of the supported streaming formats.
+const (
+	ContentTypeSSE    = "text/event-stream"
+	ContentTypeNDJSON = "application/x-ndjson"
+)
+
+// eventDecoder reads raw events from a streaming response body.
+type eventDecoder interface {
+	next() (*sseMessage, error)
+	state() *decoderState
+}
+
+// decoderState is the state shared by decoders and read by the stream.
+type decoderState struct {
+	// onLine is called for every line read, including comments.
+	onLine func()
+
+	lastEventID string
+	retry       time.Duration
+}
+
+// newDecoder returns a decoder for the format of resp, detected from its
+// Content-Type. Anything other than NDJSON is read as server-sent events.
+func newDecoder(resp *http.Response, lastEventID string) eventDecoder {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case ContentTypeNDJSON, "application/ndjson", "application/jsonl":
+		return newNDJSONDecoder(resp.Body)
+	}
+	return newSSEDecoder(resp.Body, lastEventID)
+}
+
+// ndjsonDecoder reads newline-delimited JSON, one event per line, for
+// deployments whose proxies mangle server-sent events. NDJSON carries no
+// event IDs, so dropped NDJSON streams are not resumed.
+type ndjsonDecoder struct {
+	reader *bufio.Reader
+	decoderState
+}
+
+func newNDJSONDecoder(r io.Reader) *ndjsonDecoder {
+	return &ndjsonDecoder{reader: bufio.NewReader(r)}
+}
+
+func (d *ndjsonDecoder) state() *decoderState {
+	return &d.decoderState
+}
+
+// next returns the next non-blank line as a message.
+func (d *ndjsonDecoder) next() (*sseMessage, error) {
+	for {
+		line, err := d.reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return nil, err
+		}
+		if d.onLine != nil {
+			d.onLine()
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			return &sseMessage{Data: line}, nil
+		}
+	}
+}
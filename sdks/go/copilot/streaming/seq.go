@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package streaming
+
+import (
+	"context"
+	"iter"
+)
+
+// Seq returns an iterator over the stream's events for use with range:
+//
+//	for event, err := range stream.Seq(ctx) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Print(event.Content())
+//	}
+//
+// A stream error is yielded once, after the last event, with a nil event.
+// Breaking out of the loop stops processing and closes the stream.
+func (s *Stream) Seq(ctx context.Context) iter.Seq2[*Event, error] {
+	return func(yield func(*Event, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		s.Start(ctx)
+
+		for event := range s.events {
+			if !yield(event, nil) {
+				cancel()
+				// Drain so the processing goroutine can exit and close the body.
+				for range s.events {
+				}
+				return
+			}
+		}
+
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
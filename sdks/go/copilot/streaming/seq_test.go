@@ -0,0 +1,126 @@
+//go:build go1.23
+
+package streaming
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const deltaEvent = "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"x\"}}\n\n"
+
+// closeTracker records whether the stream closed its body.
+type closeTracker struct {
+	io.Reader
+	closed atomic.Bool
+	close  func() error
+}
+
+func (c *closeTracker) Close() error {
+	c.closed.Store(true)
+	return c.close()
+}
+
+// failingReader returns err once the data is exhausted.
+type failingReader struct {
+	data io.Reader
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if n, err := r.data.Read(p); err != io.EOF {
+		return n, err
+	}
+	return 0, r.err
+}
+
+func TestSeqBreakClosesStream(t *testing.T) {
+	pr, pw := io.Pipe()
+	body := &closeTracker{Reader: pr, close: pr.Close}
+	stream := NewStream(&http.Response{Body: body})
+
+	// The server keeps sending until the stream is closed.
+	go func() {
+		for {
+			if _, err := io.WriteString(pw, deltaEvent); err != nil {
+				return
+			}
+		}
+	}()
+
+	n := 0
+	for _, err := range stream.Seq(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+	if !body.closed.Load() {
+		t.Error("expected breaking out of the loop to close the body")
+	}
+	if n != 3 {
+		t.Errorf("expected 3 events, got %d", n)
+	}
+}
+
+func TestSeqYieldsErrorOnce(t *testing.T) {
+	reset := errors.New("connection reset")
+	stream := NewStream(&http.Response{Body: io.NopCloser(&failingReader{
+		data: strings.NewReader(deltaEvent + deltaEvent),
+		err:  reset,
+	})})
+
+	var events int
+	var errs []error
+	for event, err := range stream.Seq(context.Background()) {
+		if len(errs) > 0 {
+			t.Fatalf("unexpected yield after the error: %v, %v", event, err)
+		}
+		if err != nil {
+			if event != nil {
+				t.Errorf("expected a nil event with the error, got %+v", event)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		events++
+	}
+	if events != 2 {
+		t.Errorf("expected 2 events before the error, got %d", events)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], reset) {
+		t.Errorf("expected the read error once, got %v", errs)
+	}
+}
+
+func TestSeqStopsAfterFinish(t *testing.T) {
+	stream := newTestStream(deltaEvent +
+		"data: {\"type\":\"message_end\"}\n\n" +
+		deltaEvent)
+
+	var types []EventType
+	for event, err := range stream.Seq(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		types = append(types, event.Type)
+	}
+	if len(types) != 2 || types[1] != EventMessageEnd {
+		t.Errorf("expected the stream to end at message_end, got %v", types)
+	}
+	if !stream.Done() {
+		t.Error("expected stream to be done")
+	}
+
+	// A finished stream yields nothing more.
+	for event, err := range stream.Seq(context.Background()) {
+		t.Errorf("unexpected yield after the stream finished: %v, %v", event, err)
+	}
+}
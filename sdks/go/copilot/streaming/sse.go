@@ -0,0 +1,167 @@
+package streaming
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sseMessage is a dispatched server-sent event.
+type sseMessage struct {
+	// Event is the value of the event field, empty for the default "message".
+	Event string
+	// Data is the concatenated data lines, joined with newlines.
+	Data string
+	// ID is the last event ID in effect when the message was dispatched.
+	ID string
+}
+
+// sseDecoder parses the text/event-stream format as specified by the
+// WHATWG HTML standard: event, data, id, and retry fields, comment lines,
+// multi-line data, and LF, CRLF, or CR line endings.
+type sseDecoder struct {
+	reader *bufio.Reader
+	decoderState
+
+	eventType string
+	data      strings.Builder
+	hasData   bool
+	skipLF    bool
+}
+
+// newSSEDecoder creates a decoder that resumes from lastEventID.
+func newSSEDecoder(r io.Reader, lastEventID string) *sseDecoder {
+	return &sseDecoder{
+		reader:       bufio.NewReader(r),
+		decoderState: decoderState{lastEventID: lastEventID},
+	}
+}
+
+func (d *sseDecoder) state() *decoderState {
+	return &d.decoderState
+}
+
+// next returns the next dispatched message. A trailing event that is not
+// terminated by a blank line is still dispatched at end of input.
+func (d *sseDecoder) next() (*sseMessage, error) {
+	for {
+		line, err := d.readLine()
+		if err != nil {
+			if err == io.EOF {
+				if msg := d.dispatch(); msg != nil {
+					return msg, nil
+				}
+			}
+			return nil, err
+		}
+		if d.onLine != nil {
+			d.onLine()
+		}
+
+		if line == "" {
+			if msg := d.dispatch(); msg != nil {
+				return msg, nil
+			}
+			continue
+		}
+		d.processField(line)
+	}
+}
+
+// readLine reads a line terminated by LF, CRLF, or CR.
+func (d *sseDecoder) readLine() (string, error) {
+	var buf []byte
+	for {
+		b, err := d.reader.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(buf) > 0 {
+				return string(buf), nil
+			}
+			return "", err
+		}
+
+		// A CR ends the line; swallow an LF that immediately follows it.
+		if d.skipLF {
+			d.skipLF = false
+			if b == '\n' {
+				continue
+			}
+		}
+
+		switch b {
+		case '\n':
+			return string(buf), nil
+		case '\r':
+			d.skipLF = true
+			return string(buf), nil
+		}
+		buf = append(buf, b)
+	}
+}
+
+// processField applies a single non-blank line to the pending event.
+func (d *sseDecoder) processField(line string) {
+	field, value, _ := strings.Cut(line, ":")
+	if field == "" {
+		// Comment line, typically used as a keep-alive.
+		return
+	}
+	value = strings.TrimPrefix(value, " ")
+
+	switch field {
+	case "event":
+		d.eventType = value
+	case "data":
+		d.data.WriteString(value)
+		d.data.WriteByte('\n')
+		d.hasData = true
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			d.lastEventID = value
+		}
+	case "retry":
+		if isDigits(value) {
+			if ms, err := strconv.Atoi(value); err == nil {
+				d.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// dispatch returns the pending event and resets the buffers. It returns nil
+// if no data lines were received.
+func (d *sseDecoder) dispatch() *sseMessage {
+	defer func() {
+		d.eventType = ""
+		d.data.Reset()
+		d.hasData = false
+	}()
+
+	if !d.hasData {
+		return nil
+	}
+	event := d.eventType
+	if event == "message" {
+		event = ""
+	}
+	return &sseMessage{
+		Event: event,
+		Data:  strings.TrimSuffix(d.data.String(), "\n"),
+		ID:    d.lastEventID,
+	}
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
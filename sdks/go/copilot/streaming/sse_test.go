@@ -0,0 +1,72 @@
+package streaming
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSSEDecoder(t *testing.T) {
+	input := ": keep-alive\r\n" +
+		"event: content_delta\r\n" +
+		"id: 7\r\n" +
+		"retry: 2500\r\n" +
+		"data: {\"delta\":\r\n" +
+		"data:{\"text\":\"hi\"}}\r\n" +
+		"\r\n" +
+		"data: second\r" +
+		"\r" +
+		"id\n" +
+		"data: third"
+
+	d := newSSEDecoder(strings.NewReader(input), "")
+
+	msg, err := d.next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Event != "content_delta" || msg.ID != "7" {
+		t.Errorf("unexpected event %q id %q", msg.Event, msg.ID)
+	}
+	if msg.Data != "{\"delta\":\n{\"text\":\"hi\"}}" {
+		t.Errorf("unexpected data %q", msg.Data)
+	}
+	if d.retry != 2500*time.Millisecond {
+		t.Errorf("expected retry 2.5s, got %v", d.retry)
+	}
+
+	msg, err = d.next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Event != "" || msg.Data != "second" || msg.ID != "7" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	// An empty id field resets the last event ID, and a trailing event
+	// without a blank line is dispatched at end of input.
+	msg, err = d.next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Data != "third" || msg.ID != "" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if _, err := d.next(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestSSEDecoderIgnoresEventsWithoutData(t *testing.T) {
+	d := newSSEDecoder(strings.NewReader("event: ping\n\nid: 3\n\ndata: x\n\n"), "")
+
+	msg, err := d.next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Event != "" || msg.Data != "x" || msg.ID != "3" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+}
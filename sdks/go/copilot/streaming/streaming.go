@@ -2,13 +2,16 @@
 package streaming
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // EventType represents the type of a streaming event.
@@ -22,15 +25,23 @@ const (
 	EventToolResult   EventType = "tool_result"
 	EventError        EventType = "error"
 	EventPing         EventType = "ping"
+
+	// EventToolCall is emitted by the SDK, not the server, once all
+	// tool_use deltas for a call have been assembled into Event.ToolCall.
+	EventToolCall EventType = "tool_call"
 )
 
 // Event represents a streaming event.
 type Event struct {
-	Type      EventType              `json:"type"`
-	Data      map[string]interface{} `json:"data,omitempty"`
-	MessageID string                 `json:"message_id,omitempty"`
-	Delta     *Delta                 `json:"delta,omitempty"`
-	Error     string                 `json:"error,omitempty"`
+	// ID is the server-sent event ID, used to resume the stream.
+	ID            string                 `json:"id,omitempty"`
+	Type          EventType              `json:"type"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+	MessageID     string                 `json:"message_id,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Delta         *Delta                 `json:"delta,omitempty"`
+	ToolCall      *ToolCall              `json:"tool_call,omitempty"`
+	Error         string                 `json:"error,omitempty"`
 }
 
 // Delta represents the content delta in a streaming event.
@@ -38,6 +49,11 @@ type Delta struct {
 	Type  string `json:"type,omitempty"`
 	Text  string `json:"text,omitempty"`
 	Index int    `json:"index,omitempty"`
+
+	// Tool-use fields, set on EventToolUse deltas.
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
 }
 
 // Content returns the text content from a content delta event.
@@ -53,22 +69,88 @@ func (e *Event) IsFinal() bool {
 	return e.Type == EventMessageEnd || e.Type == EventError
 }
 
+// DefaultRetryDelay is the wait before reconnecting a dropped stream.
+const DefaultRetryDelay = time.Second
+
+// ErrStreamStalled is returned when no data, including pings, arrives within
+// the stream's idle timeout.
+var ErrStreamStalled = errors.New("stream stalled: idle timeout exceeded")
+
+// ReconnectFunc reopens a dropped stream. lastEventID is the ID of the last
+// event received and should be sent as the Last-Event-ID header so the server
+// resumes after it.
+type ReconnectFunc func(ctx context.Context, lastEventID string) (*http.Response, error)
+
+// Option configures a Stream.
+type Option func(*Stream)
+
+// WithReconnect enables automatic reconnection when the connection drops
+// before the final event. At most maxAttempts reconnects are made.
+func WithReconnect(reconnect ReconnectFunc, maxAttempts int) Option {
+	return func(s *Stream) {
+		s.reconnect = reconnect
+		s.maxReconnects = maxAttempts
+	}
+}
+
+// WithRetryDelay sets the wait before each reconnect attempt.
+func WithRetryDelay(delay time.Duration) Option {
+	return func(s *Stream) {
+		s.retryDelay = delay
+	}
+}
+
+// WithIdleTimeout fails the stream with ErrStreamStalled when nothing is
+// received for the given duration. Pings reset the timer, and time spent
+// waiting for the consumer to take events does not count. Zero disables it.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(s *Stream) {
+		s.idleTimeout = timeout
+	}
+}
+
 // Stream represents a streaming response.
+//
+// Concurrency: a Stream is consumed by a single processing goroutine, started
+// once by Start or by any of the consuming methods (Collect, ForEach, Seq,
+// ...). Later calls to Start are no-ops, so a stream can only be consumed
+// once. Events must be received by one consumer, but the accessors Err, Done,
+// LastEventID, ToolCalls, AccumulatedContent, and Close are safe to call from
+// any goroutine at any time. Err is final once the Events channel is closed.
 type Stream struct {
-	response *http.Response
-	reader   *bufio.Reader
-	events   chan *Event
-	err      error
-	done     bool
-	content  strings.Builder
+	events    chan *Event
+	startOnce sync.Once
+
+	// mu guards the fields below, which the processing goroutine writes
+	// and callers read.
+	mu          sync.Mutex
+	response    *http.Response
+	err         error
+	done        bool
+	closed      bool
+	content     strings.Builder
+	tools       toolCallAccumulator
+	lastEventID string
+
+	// Owned by the processing goroutine.
+	decoder eventDecoder
+
+	reconnect     ReconnectFunc
+	maxReconnects int
+	retryDelay    time.Duration
+	idleTimeout   time.Duration
 }
 
 // NewStream creates a new stream from an HTTP response.
-func NewStream(resp *http.Response) *Stream {
+func NewStream(resp *http.Response, opts ...Option) *Stream {
 	s := &Stream{
-		response: resp,
-		reader:   bufio.NewReader(resp.Body),
-		events:   make(chan *Event, 100),
+		response:   resp,
+		decoder:    newDecoder(resp, ""),
+		events:     make(chan *Event, 100),
+		retryDelay: DefaultRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
 	return s
 }
@@ -78,86 +160,255 @@ func (s *Stream) Events() <-chan *Event {
 	return s.events
 }
 
-// Start begins processing the stream in a goroutine.
+// Start begins processing the stream in a goroutine. Only the first call
+// has an effect.
 func (s *Stream) Start(ctx context.Context) {
-	go s.process(ctx)
+	s.startOnce.Do(func() {
+		go s.process(ctx)
+	})
+}
+
+// LastEventID returns the ID of the last event received.
+func (s *Stream) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastEventID
+}
+
+// setErr records the terminal error of the stream.
+func (s *Stream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// setDone marks the stream as completed.
+func (s *Stream) setDone() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
 }
 
-// process reads and parses events from the stream.
+// body returns the current response body.
+func (s *Stream) body() io.ReadCloser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.response.Body
+}
+
+// process reads events from the stream, reconnecting if the connection drops.
 func (s *Stream) process(ctx context.Context) {
 	defer close(s.events)
-	defer s.response.Body.Close()
+	defer func() { s.body().Close() }()
+
+	// Reads do not observe the context, so cancellation closes the body to
+	// unblock them.
+	stop := context.AfterFunc(ctx, func() { s.body().Close() })
+	defer stop()
+
+	for attempt := 0; ; attempt++ {
+		err := s.readEvents(ctx)
+
+		s.mu.Lock()
+		finished := s.done || s.closed
+		lastEventID := s.lastEventID
+		s.mu.Unlock()
+
+		if finished {
+			return
+		}
+		if ctx.Err() != nil {
+			s.setErr(ctx.Err())
+			return
+		}
+
+		// Only resume when the server has told us where we are; otherwise
+		// the replayed events would duplicate accumulated content.
+		if s.reconnect == nil || lastEventID == "" || attempt >= s.maxReconnects {
+			if err != io.EOF {
+				s.setErr(err)
+			}
+			return
+		}
 
-	for {
 		select {
 		case <-ctx.Done():
-			s.err = ctx.Err()
+			s.setErr(ctx.Err())
+			return
+		case <-time.After(s.retryDelay):
+		}
+
+		resp, rerr := s.reconnect(ctx, lastEventID)
+		if rerr != nil {
+			s.setErr(fmt.Errorf("stream reconnect failed: %w", rerr))
+			return
+		}
+
+		s.mu.Lock()
+		old := s.response
+		s.response = resp
+		closed := s.closed
+		s.mu.Unlock()
+
+		old.Body.Close()
+		if closed {
 			return
-		default:
+		}
+		s.decoder = newDecoder(resp, lastEventID)
+	}
+}
+
+// readEvents reads and dispatches events until the stream completes or the
+// connection ends. It returns io.EOF if the connection closed early.
+func (s *Stream) readEvents(ctx context.Context) error {
+	// A stalled connection blocks in the read, so the watchdog closes the
+	// body to unblock it. A slow consumer is not a stalled connection, so
+	// the watchdog is paused while events are delivered.
+	var stalled atomic.Bool
+	state := s.decoder.state()
+	state.onLine = func() {}
+	pause, resume := func() {}, func() {}
+	if s.idleTimeout > 0 {
+		body := s.body()
+		watchdog := time.AfterFunc(s.idleTimeout, func() {
+			stalled.Store(true)
+			body.Close()
+		})
+		defer watchdog.Stop()
+		state.onLine = func() { watchdog.Reset(s.idleTimeout) }
+		pause = func() { watchdog.Stop() }
+		resume = state.onLine
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msg, err := s.decoder.next()
+
+		// Track event IDs and server-requested retry delays for resumption
+		s.mu.Lock()
+		s.lastEventID = state.lastEventID
+		s.mu.Unlock()
+		if state.retry > 0 {
+			s.retryDelay = state.retry
 		}
 
-		line, err := s.reader.ReadString('\n')
 		if err != nil {
-			if err != io.EOF {
-				s.err = err
+			if stalled.Load() {
+				return ErrStreamStalled
 			}
-			return
+			return err
 		}
 
-		line = strings.TrimSpace(line)
-		if line == "" {
+		// Check for [DONE] marker
+		if msg.Data == "[DONE]" {
+			s.setDone()
+			return nil
+		}
+
+		event, err := s.parseEvent(msg)
+		if err != nil {
 			continue
 		}
 
-		// Parse SSE format
-		if strings.HasPrefix(line, "data: ") {
-			data := strings.TrimPrefix(line, "data: ")
+		// Accumulate content
+		if event.Type == EventContentDelta {
+			s.mu.Lock()
+			s.content.WriteString(event.Content())
+			s.mu.Unlock()
+		}
 
-			// Check for [DONE] marker
-			if data == "[DONE]" {
-				s.done = true
-				return
-			}
+		pause()
+		err = s.deliver(ctx, event)
+		resume()
+		if err != nil {
+			return err
+		}
 
-			event, err := s.parseEvent(data)
-			if err != nil {
-				continue
-			}
+		if event.IsFinal() {
+			s.setDone()
+			return nil
+		}
+	}
+}
 
-			// Accumulate content
-			if event.Type == EventContentDelta {
-				s.content.WriteString(event.Content())
-			}
+// deliver emits an event along with the tool calls it completes.
+func (s *Stream) deliver(ctx context.Context, event *Event) error {
+	// Complete any pending tool calls before the message ends
+	if event.Type == EventMessageEnd {
+		if err := s.emitToolCalls(ctx, event); err != nil {
+			return err
+		}
+	}
 
-			select {
-			case s.events <- event:
-			case <-ctx.Done():
-				s.err = ctx.Err()
-				return
-			}
+	if err := s.emit(ctx, event); err != nil {
+		return err
+	}
 
-			if event.IsFinal() {
-				s.done = true
-				return
-			}
+	// Emit tool calls as their arguments complete
+	if event.Type == EventToolUse {
+		return s.emitToolCalls(ctx, event)
+	}
+	return nil
+}
+
+// emit sends an event to the consumer.
+func (s *Stream) emit(ctx context.Context, event *Event) error {
+	select {
+	case s.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// emitToolCalls feeds an event to the tool call accumulator and emits an
+// EventToolCall for each call it completes.
+func (s *Stream) emitToolCalls(ctx context.Context, event *Event) error {
+	var calls []*ToolCall
+	var err error
+	s.mu.Lock()
+	if event.Type == EventMessageEnd {
+		calls, err = s.tools.flush()
+	} else {
+		var call *ToolCall
+		call, err = s.tools.add(event)
+		if call != nil {
+			calls = append(calls, call)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, call := range calls {
+		if err := s.emit(ctx, &Event{Type: EventToolCall, MessageID: event.MessageID, ToolCall: call}); err != nil {
+			return err
 		}
 	}
+	if err != nil {
+		return s.emit(ctx, &Event{Type: EventError, MessageID: event.MessageID, Error: err.Error()})
+	}
+	return nil
 }
 
-// parseEvent parses a JSON event from the stream.
-func (s *Stream) parseEvent(data string) (*Event, error) {
+// parseEvent parses a JSON event from a server-sent event.
+func (s *Stream) parseEvent(msg *sseMessage) (*Event, error) {
 	var raw map[string]interface{}
-	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+	if err := json.Unmarshal([]byte(msg.Data), &raw); err != nil {
 		return nil, err
 	}
 
 	event := &Event{
+		ID:   msg.ID,
 		Data: raw,
 	}
 
-	// Extract type
+	// Extract type, preferring the payload over the SSE event field
 	if typeVal, ok := raw["type"].(string); ok {
 		event.Type = EventType(typeVal)
+	} else if msg.Event != "" {
+		event.Type = EventType(msg.Event)
 	} else {
 		event.Type = EventContentDelta
 	}
@@ -169,6 +420,11 @@ func (s *Stream) parseEvent(data string) (*Event, error) {
 		event.MessageID = id
 	}
 
+	// Extract correlation ID
+	if id, ok := raw["correlation_id"].(string); ok {
+		event.CorrelationID = id
+	}
+
 	// Extract delta
 	if deltaVal, ok := raw["delta"].(map[string]interface{}); ok {
 		event.Delta = &Delta{}
@@ -181,6 +437,15 @@ func (s *Stream) parseEvent(data string) (*Event, error) {
 		if idx, ok := deltaVal["index"].(float64); ok {
 			event.Delta.Index = int(idx)
 		}
+		if id, ok := deltaVal["id"].(string); ok {
+			event.Delta.ID = id
+		}
+		if name, ok := deltaVal["name"].(string); ok {
+			event.Delta.Name = name
+		}
+		if partial, ok := deltaVal["partial_json"].(string); ok {
+			event.Delta.PartialJSON = partial
+		}
 	}
 
 	// Extract error
@@ -193,21 +458,38 @@ func (s *Stream) parseEvent(data string) (*Event, error) {
 
 // Err returns any error that occurred during streaming.
 func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.err
 }
 
 // Done returns true if the stream has completed.
 func (s *Stream) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.done
 }
 
+// ToolCalls returns the tool calls completed so far.
+func (s *Stream) ToolCalls() []*ToolCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*ToolCall(nil), s.tools.completed...)
+}
+
 // AccumulatedContent returns all content received so far.
 func (s *Stream) AccumulatedContent() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return s.content.String()
 }
 
-// Close closes the stream.
+// Close closes the stream. A stream closed while being processed ends
+// without an error and is not reconnected.
 func (s *Stream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
 	return s.response.Body.Close()
 }
 
@@ -221,8 +503,8 @@ func (s *Stream) Collect(ctx context.Context) ([]*Event, error) {
 		events = append(events, event)
 	}
 
-	if s.err != nil {
-		return events, s.err
+	if err := s.Err(); err != nil {
+		return events, err
 	}
 
 	return events, nil
@@ -236,8 +518,8 @@ func (s *Stream) CollectContent(ctx context.Context) (string, error) {
 		// Consume all events
 	}
 
-	if s.err != nil {
-		return "", s.err
+	if err := s.Err(); err != nil {
+		return "", err
 	}
 
 	return s.AccumulatedContent(), nil
@@ -248,6 +530,10 @@ type StreamCallback func(event *Event) error
 
 // ForEach processes each event with a callback.
 func (s *Stream) ForEach(ctx context.Context, callback StreamCallback) error {
+	// Cancel processing if the callback stops early so the goroutine exits.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	s.Start(ctx)
 
 	for event := range s.events {
@@ -256,16 +542,67 @@ func (s *Stream) ForEach(ctx context.Context, callback StreamCallback) error {
 		}
 	}
 
-	return s.err
+	return s.Err()
+}
+
+// WriteTo writes content deltas to w as they arrive. It implements
+// io.WriterTo; use WriteContent to bound the stream with a context.
+func (s *Stream) WriteTo(w io.Writer) (int64, error) {
+	return s.WriteContent(context.Background(), w)
+}
+
+// WriteContent writes content deltas to w as they arrive and returns the
+// number of bytes written.
+func (s *Stream) WriteContent(ctx context.Context, w io.Writer) (int64, error) {
+	return s.writeContent(ctx, w, func() {})
+}
+
+// WriteToResponse proxies content deltas to an HTTP response, flushing after
+// each delta so end users see tokens as they are generated.
+func (s *Stream) WriteToResponse(ctx context.Context, w http.ResponseWriter) (int64, error) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	// Ask reverse proxies such as nginx not to buffer the response.
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	flush := func() {}
+	if f, ok := w.(http.Flusher); ok {
+		flush = f.Flush
+	}
+	return s.writeContent(ctx, w, flush)
+}
+
+// writeContent writes content deltas to w, calling flush after each write.
+func (s *Stream) writeContent(ctx context.Context, w io.Writer, flush func()) (int64, error) {
+	var written int64
+	err := s.ForEach(ctx, func(event *Event) error {
+		if event.Type == EventError {
+			return fmt.Errorf("stream error: %s", event.Error)
+		}
+		content := event.Content()
+		if content == "" {
+			return nil
+		}
+		n, err := io.WriteString(w, content)
+		written += int64(n)
+		if err != nil {
+			return err
+		}
+		flush()
+		return nil
+	})
+	return written, err
 }
 
 // Handler is a convenience type for handling stream events.
 type Handler struct {
-	OnStart   func(messageID string)
-	OnContent func(content string)
-	OnEnd     func(messageID string)
-	OnError   func(err string)
-	OnEvent   func(event *Event)
+	OnStart    func(messageID string)
+	OnContent  func(content string)
+	OnEnd      func(messageID string)
+	OnError    func(err string)
+	OnToolCall func(call *ToolCall)
+	OnEvent    func(event *Event)
 }
 
 // Handle processes a stream with the configured handlers.
@@ -285,6 +622,10 @@ func (h *Handler) Handle(ctx context.Context, stream *Stream) error {
 			if h.OnEnd != nil {
 				h.OnEnd(event.MessageID)
 			}
+		case EventToolCall:
+			if h.OnToolCall != nil {
+				h.OnToolCall(event.ToolCall)
+			}
 		case EventError:
 			if h.OnError != nil {
 				h.OnError(event.Error)
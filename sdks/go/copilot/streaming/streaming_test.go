@@ -0,0 +1,129 @@
+package streaming
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStreamConcurrentAccess(t *testing.T) {
+	var body strings.Builder
+	for i := 0; i < 200; i++ {
+		body.WriteString("data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"x\"}}\n\n")
+	}
+	body.WriteString("data: {\"type\":\"message_end\"}\n\n")
+	stream := newTestStream(body.String())
+
+	// Accessors may be polled from other goroutines while events flow.
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = stream.AccumulatedContent()
+				_ = stream.Done()
+				_ = stream.Err()
+				_ = stream.LastEventID()
+				_ = stream.ToolCalls()
+			}
+		}
+	}()
+
+	// A second Start must not spawn another reader.
+	stream.Start(context.Background())
+	content, err := stream.CollectContent(context.Background())
+	close(stop)
+	wg.Wait()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(content) != 200 {
+		t.Errorf("expected 200 bytes of content, got %d", len(content))
+	}
+	if !stream.Done() {
+		t.Error("expected stream to be done")
+	}
+}
+
+func TestStreamCloseWhileReading(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	stream := NewStream(&http.Response{Body: pr})
+
+	stream.Start(context.Background())
+	go func() {
+		io.WriteString(pw, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"hi\"}}\n\n")
+		time.Sleep(10 * time.Millisecond)
+		stream.Close()
+	}()
+
+	for range stream.Events() {
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("expected no error after Close, got %v", err)
+	}
+	if stream.AccumulatedContent() != "hi" {
+		t.Errorf("expected content 'hi', got %q", stream.AccumulatedContent())
+	}
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	body := `{"type":"message_start","message_id":"msg-1"}
+{"type":"content_delta","delta":{"text":"Hello"}}
+
+{"type":"content_delta","delta":{"text":", world"}}
+{"type":"message_end"}
+`
+	stream := NewStream(&http.Response{
+		Header: http.Header{"Content-Type": {"application/x-ndjson; charset=utf-8"}},
+		Body:   io.NopCloser(strings.NewReader(body)),
+	})
+
+	content, err := stream.CollectContent(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "Hello, world" {
+		t.Errorf("expected content 'Hello, world', got %q", content)
+	}
+	if !stream.Done() {
+		t.Error("expected stream to be done")
+	}
+}
+
+func TestStreamIdleTimeoutSlowConsumer(t *testing.T) {
+	pr, pw := io.Pipe()
+	stream := NewStream(&http.Response{Body: pr}, WithIdleTimeout(20*time.Millisecond))
+	go func() {
+		defer pw.Close()
+		for i := 0; i < 150; i++ {
+			io.WriteString(pw, "data: {\"type\":\"content_delta\",\"delta\":{\"text\":\"x\"}}\n\n")
+		}
+		io.WriteString(pw, "data: {\"type\":\"message_end\"}\n\n")
+	}()
+
+	// The consumer falls behind until the event buffer fills and the
+	// reader blocks delivering; that must not count as a stall.
+	stream.Start(context.Background())
+	<-stream.Events()
+	time.Sleep(100 * time.Millisecond)
+	for range stream.Events() {
+	}
+
+	if err := stream.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stream.AccumulatedContent()) != 150 || !stream.Done() {
+		t.Errorf("expected the whole stream, got %d bytes, done %v", len(stream.AccumulatedContent()), stream.Done())
+	}
+}
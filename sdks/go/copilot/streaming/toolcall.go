@@ -0,0 +1,114 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Tool-use delta types carried in EventToolUse events.
+const (
+	DeltaToolUseStart   = "tool_use_start"
+	DeltaInputJSONDelta = "input_json_delta"
+	DeltaToolUseStop    = "tool_use_stop"
+)
+
+// ToolCall is a complete tool invocation assembled from tool_use deltas.
+type ToolCall struct {
+	// Index identifies the call among concurrent calls in the same message.
+	Index int             `json:"index"`
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+// DecodeInput unmarshals the tool call arguments into v.
+func (tc *ToolCall) DecodeInput(v interface{}) error {
+	return json.Unmarshal(tc.Input, v)
+}
+
+// pendingToolCall is a tool call whose arguments are still arriving.
+type pendingToolCall struct {
+	id    string
+	name  string
+	input strings.Builder
+}
+
+// toolCallAccumulator assembles partial JSON fragments into tool calls.
+type toolCallAccumulator struct {
+	pending   map[int]*pendingToolCall
+	completed []*ToolCall
+}
+
+// add applies a tool_use event and returns the call it completes, if any.
+func (a *toolCallAccumulator) add(event *Event) (*ToolCall, error) {
+	if event.Type != EventToolUse || event.Delta == nil {
+		return nil, nil
+	}
+	if a.pending == nil {
+		a.pending = make(map[int]*pendingToolCall)
+	}
+
+	d := event.Delta
+	switch d.Type {
+	case DeltaToolUseStart:
+		a.pending[d.Index] = &pendingToolCall{id: d.ID, name: d.Name}
+	case DeltaInputJSONDelta:
+		call, ok := a.pending[d.Index]
+		if !ok {
+			call = &pendingToolCall{id: d.ID, name: d.Name}
+			a.pending[d.Index] = call
+		}
+		call.input.WriteString(d.PartialJSON)
+	case DeltaToolUseStop:
+		return a.complete(d.Index)
+	}
+	return nil, nil
+}
+
+// complete finishes the pending call at index.
+func (a *toolCallAccumulator) complete(index int) (*ToolCall, error) {
+	call, ok := a.pending[index]
+	if !ok {
+		return nil, nil
+	}
+	delete(a.pending, index)
+
+	input := strings.TrimSpace(call.input.String())
+	if input == "" {
+		input = "{}"
+	}
+	if !json.Valid([]byte(input)) {
+		return nil, fmt.Errorf("tool call %s (%s): incomplete input JSON", call.id, call.name)
+	}
+
+	tc := &ToolCall{
+		Index: index,
+		ID:    call.id,
+		Name:  call.name,
+		Input: json.RawMessage(input),
+	}
+	a.completed = append(a.completed, tc)
+	return tc, nil
+}
+
+// flush completes every pending call in index order, for servers that end
+// the message without sending tool_use_stop.
+func (a *toolCallAccumulator) flush() ([]*ToolCall, error) {
+	indexes := make([]int, 0, len(a.pending))
+	for index := range a.pending {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	var calls []*ToolCall
+	for _, index := range indexes {
+		tc, err := a.complete(index)
+		if err != nil {
+			return calls, err
+		}
+		calls = append(calls, tc)
+	}
+	return calls, nil
+}
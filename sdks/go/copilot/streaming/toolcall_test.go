@@ -0,0 +1,74 @@
+package streaming
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newTestStream(body string) *Stream {
+	return NewStream(&http.Response{Body: io.NopCloser(strings.NewReader(body))})
+}
+
+func TestToolCallAccumulation(t *testing.T) {
+	body := `data: {"type":"message_start","message_id":"msg-1"}
+
+data: {"type":"tool_use","delta":{"type":"tool_use_start","index":0,"id":"call-1","name":"search"}}
+
+data: {"type":"tool_use","delta":{"type":"tool_use_start","index":1,"id":"call-2","name":"lookup"}}
+
+data: {"type":"tool_use","delta":{"type":"input_json_delta","index":0,"partial_json":"{\"query\":\"go"}}
+
+data: {"type":"tool_use","delta":{"type":"input_json_delta","index":1,"partial_json":"{\"id\":"}}
+
+data: {"type":"tool_use","delta":{"type":"input_json_delta","index":0,"partial_json":" iterators\"}"}}
+
+data: {"type":"tool_use","delta":{"type":"tool_use_stop","index":0}}
+
+data: {"type":"tool_use","delta":{"type":"input_json_delta","index":1,"partial_json":"42}"}}
+
+data: {"type":"message_end","message_id":"msg-1"}
+
+`
+	stream := newTestStream(body)
+
+	var calls []*ToolCall
+	var types []EventType
+	err := stream.ForEach(context.Background(), func(event *Event) error {
+		types = append(types, event.Type)
+		if event.Type == EventToolCall {
+			calls = append(calls, event.ToolCall)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(calls))
+	}
+
+	var args struct {
+		Query string `json:"query"`
+	}
+	if err := calls[0].DecodeInput(&args); err != nil {
+		t.Fatalf("failed to decode input: %v", err)
+	}
+	if calls[0].Name != "search" || args.Query != "go iterators" {
+		t.Errorf("unexpected first call %s %s", calls[0].Name, calls[0].Input)
+	}
+
+	// The unterminated second call completes before message_end.
+	if calls[1].ID != "call-2" || string(calls[1].Input) != `{"id":42}` {
+		t.Errorf("unexpected second call %s %s", calls[1].ID, calls[1].Input)
+	}
+	if types[len(types)-1] != EventMessageEnd || types[len(types)-2] != EventToolCall {
+		t.Errorf("expected tool call before message_end, got %v", types)
+	}
+	if len(stream.ToolCalls()) != 2 {
+		t.Errorf("expected ToolCalls to return 2 calls, got %d", len(stream.ToolCalls()))
+	}
+}
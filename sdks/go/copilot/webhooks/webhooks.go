@@ -0,0 +1,221 @@
+// Package webhooks verifies and parses webhook deliveries from the LLM CoPilot API.
+//
+// Example usage:
+//
+//	http.HandleFunc("/hooks", func(w http.ResponseWriter, r *http.Request) {
+//	    event, err := webhooks.ParseEvent(r, os.Getenv("COPILOT_WEBHOOK_SECRET"))
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusBadRequest)
+//	        return
+//	    }
+//	    switch payload := event.Payload.(type) {
+//	    case *webhooks.WorkflowRunEvent:
+//	        log.Printf("run %s is %s", payload.Run.ID, payload.Run.Status)
+//	    }
+//	    w.WriteHeader(http.StatusNoContent)
+//	})
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+const (
+	// SignatureHeader carries one or more comma-separated "v1=<hex>" signatures.
+	SignatureHeader = "X-CoPilot-Signature"
+	// TimestampHeader carries the Unix time at which the delivery was signed.
+	TimestampHeader = "X-CoPilot-Timestamp"
+
+	// DefaultTolerance is the maximum accepted age of a delivery.
+	DefaultTolerance = 5 * time.Minute
+	// DefaultMaxBodyBytes is the maximum accepted payload size.
+	DefaultMaxBodyBytes = 1 << 20
+
+	signatureScheme = "v1"
+)
+
+var (
+	// ErrMissingSignature is returned when the signature or timestamp header is absent.
+	ErrMissingSignature = errors.New("webhooks: missing signature")
+	// ErrInvalidSignature is returned when no signature matches the payload.
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+	// ErrTimestampOutOfRange is returned when the delivery is too old or too far in the future.
+	ErrTimestampOutOfRange = errors.New("webhooks: timestamp outside tolerance")
+)
+
+// Event is a verified webhook delivery.
+type Event struct {
+	models.WebhookEvent
+	// Payload is the decoded Data: one of *ConversationEvent, *MessageEvent,
+	// *WorkflowRunEvent, *WorkflowStepEvent, or *PingEvent. It is nil for
+	// event types unknown to this SDK version; use Data instead.
+	Payload interface{}
+}
+
+// ConversationEvent is the payload of conversation.* events.
+type ConversationEvent struct {
+	Conversation models.Conversation `json:"conversation"`
+}
+
+// MessageEvent is the payload of message.* events.
+type MessageEvent struct {
+	Message models.Message `json:"message"`
+}
+
+// WorkflowRunEvent is the payload of workflow.run.* events.
+type WorkflowRunEvent struct {
+	Run models.WorkflowRun `json:"run"`
+}
+
+// WorkflowStepEvent is the payload of workflow.step.* events.
+type WorkflowStepEvent struct {
+	RunID  string                 `json:"run_id"`
+	StepID string                 `json:"step_id"`
+	Output map[string]interface{} `json:"output,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// PingEvent is the payload of test deliveries.
+type PingEvent struct {
+	WebhookID string `json:"webhook_id"`
+}
+
+// Verifier verifies webhook signatures.
+type Verifier struct {
+	// Secrets are the accepted signing secrets. More than one may be set
+	// while rotating secrets.
+	Secrets []string
+	// Tolerance is the maximum clock difference accepted. Defaults to DefaultTolerance.
+	Tolerance time.Duration
+	// MaxBodyBytes limits the payload size. Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// ParseEvent verifies the request against secret and decodes the event.
+func ParseEvent(r *http.Request, secret string) (*Event, error) {
+	v := &Verifier{Secrets: []string{secret}}
+	return v.Parse(r)
+}
+
+// Parse reads the request body, verifies its signature, and decodes the event.
+func (v *Verifier) Parse(r *http.Request) (*Event, error) {
+	maxBytes := v.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBodyBytes
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: failed to read body: %w", err)
+	}
+	if int64(len(payload)) > maxBytes {
+		return nil, fmt.Errorf("webhooks: body exceeds %d bytes", maxBytes)
+	}
+
+	if err := v.Verify(payload, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader)); err != nil {
+		return nil, err
+	}
+	return Decode(payload)
+}
+
+// Verify checks that signature is a valid signature of payload at timestamp.
+func (v *Verifier) Verify(payload []byte, timestamp, signature string) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	tolerance := v.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	if age := now().Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrTimestampOutOfRange
+	}
+
+	for _, part := range strings.Split(signature, ",") {
+		scheme, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || scheme != signatureScheme {
+			continue
+		}
+		sig, err := hex.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		for _, secret := range v.Secrets {
+			if hmac.Equal(sig, computeMAC(payload, timestamp, secret)) {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// Sign returns the signature header value for payload signed at t. It is
+// useful for testing webhook handlers.
+func Sign(payload []byte, secret string, t time.Time) (timestamp, signature string) {
+	timestamp = strconv.FormatInt(t.Unix(), 10)
+	signature = signatureScheme + "=" + hex.EncodeToString(computeMAC(payload, timestamp, secret))
+	return timestamp, signature
+}
+
+// computeMAC computes HMAC-SHA256 over "<timestamp>.<payload>".
+func computeMAC(payload []byte, timestamp, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Decode decodes an already verified payload into an Event.
+func Decode(payload []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(payload, &event.WebhookEvent); err != nil {
+		return nil, fmt.Errorf("webhooks: failed to parse event: %w", err)
+	}
+
+	switch {
+	case event.Type == models.WebhookEventPing:
+		event.Payload = &PingEvent{}
+	case event.Type == models.WebhookEventMessageCreated:
+		event.Payload = &MessageEvent{}
+	case strings.HasPrefix(string(event.Type), "conversation."):
+		event.Payload = &ConversationEvent{}
+	case strings.HasPrefix(string(event.Type), "workflow.run."):
+		event.Payload = &WorkflowRunEvent{}
+	case strings.HasPrefix(string(event.Type), "workflow.step."):
+		event.Payload = &WorkflowStepEvent{}
+	default:
+		return &event, nil
+	}
+
+	if len(event.Data) > 0 {
+		if err := json.Unmarshal(event.Data, event.Payload); err != nil {
+			return nil, fmt.Errorf("webhooks: failed to parse %s payload: %w", event.Type, err)
+		}
+	}
+	return &event, nil
+}
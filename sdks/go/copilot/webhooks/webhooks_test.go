@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+const testPayload = `{"id":"evt-1","type":"workflow.run.completed","correlation_id":"corr-1","data":{"run":{"id":"run-1","status":"completed"}}}`
+
+func newSignedRequest(payload, secret string, t time.Time) *http.Request {
+	req := httptest.NewRequest("POST", "/hooks", bytes.NewBufferString(payload))
+	ts, sig := Sign([]byte(payload), secret, t)
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, sig)
+	return req
+}
+
+func TestParseEvent(t *testing.T) {
+	req := newSignedRequest(testPayload, "s3cret", time.Now())
+
+	event, err := ParseEvent(req, "s3cret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Type != models.WebhookEventWorkflowRunCompleted {
+		t.Errorf("expected workflow.run.completed, got %s", event.Type)
+	}
+	if event.CorrelationID != "corr-1" {
+		t.Errorf("expected correlation ID 'corr-1', got %s", event.CorrelationID)
+	}
+
+	payload, ok := event.Payload.(*WorkflowRunEvent)
+	if !ok {
+		t.Fatalf("expected *WorkflowRunEvent, got %T", event.Payload)
+	}
+	if payload.Run.ID != "run-1" || payload.Run.Status != models.WorkflowStatusCompleted {
+		t.Errorf("unexpected run: %+v", payload.Run)
+	}
+}
+
+func TestParseEventRejects(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		req     *http.Request
+		secret  string
+		wantErr error
+	}{
+		{
+			name:    "wrong secret",
+			req:     newSignedRequest(testPayload, "other", now),
+			secret:  "s3cret",
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "stale timestamp",
+			req:     newSignedRequest(testPayload, "s3cret", now.Add(-10*time.Minute)),
+			secret:  "s3cret",
+			wantErr: ErrTimestampOutOfRange,
+		},
+		{
+			name:    "missing headers",
+			req:     httptest.NewRequest("POST", "/hooks", bytes.NewBufferString(testPayload)),
+			secret:  "s3cret",
+			wantErr: ErrMissingSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEvent(tt.req, tt.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifierSecretRotation(t *testing.T) {
+	req := newSignedRequest(testPayload, "old-secret", time.Now())
+
+	v := &Verifier{Secrets: []string{"new-secret", "old-secret"}}
+	if _, err := v.Parse(req); err != nil {
+		t.Fatalf("expected old secret to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/llm-copilot-agent/sdk-go/langchaingo
+
+go 1.22.0
+
+require (
+	github.com/llm-copilot-agent/sdk-go v0.0.0
+	github.com/tmc/langchaingo v0.1.13
+)
+
+require (
+	github.com/dlclark/regexp2 v1.10.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pkoukk/tiktoken-go v0.1.6 // indirect
+)
+
+replace github.com/llm-copilot-agent/sdk-go => ../
@@ -0,0 +1,340 @@
+// Package langchaingo implements langchaingo's llms.Model on top of the
+// CoPilot API, so that CoPilot can be used in langchaingo chains and
+// agents.
+//
+// It is a separate module so that the SDK itself stays free of third-party
+// dependencies.
+//
+// langchaingo passes the whole history with every call, while CoPilot
+// keeps history in conversations. As in the openai package, an LLM maps
+// each call onto a conversation: the first call creates one, with any
+// system messages as its system prompt, and a later call whose history
+// extends an earlier exchange continues the same conversation by sending
+// only its final message.
+//
+// Tools are configured on the server through the assistant settings, so
+// CallOptions.Tools are not forwarded. Tool calls the assistant makes are
+// returned in ContentChoice.ToolCalls, and a final tool message answers
+// them with tool_result parts.
+package langchaingo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/client"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+	"github.com/llm-copilot-agent/sdk-go/copilot/streaming"
+)
+
+// Stop reasons reported in ContentChoice.StopReason.
+const (
+	StopReasonStop      = "stop"
+	StopReasonToolCalls = "tool_calls"
+)
+
+// ErrNoUserMessage is returned when a call does not end with a human or
+// tool message.
+var ErrNoUserMessage = errors.New("langchaingo: last message must be a human or tool message")
+
+// maxConversations bounds the history-to-conversation index.
+const maxConversations = 1024
+
+var _ llms.Model = (*LLM)(nil)
+
+// LLM is an llms.Model backed by a CoPilot client. It is safe for
+// concurrent use.
+type LLM struct {
+	client *client.Client
+
+	mu            sync.Mutex
+	conversations map[string]models.ConversationID
+	order         []string
+}
+
+// New returns an LLM backed by c.
+func New(c *client.Client) *LLM {
+	return &LLM{client: c, conversations: make(map[string]models.ConversationID)}
+}
+
+// Call sends prompt as a single human message and returns the reply.
+func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+// GenerateContent sends the final message of messages and returns the
+// assistant's reply as a single choice. When CallOptions.StreamingFunc is
+// set, the reply is streamed to it as it arrives; messages carrying more
+// than text, and clients with streaming disabled, report the complete
+// reply in a single call.
+func (l *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	conversationID, req, err := l.prepare(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	var choice *llms.ContentChoice
+	if opts.StreamingFunc != nil && req.Parts == nil && l.client.FeatureEnabled(client.FeatureStreaming) {
+		choice, err = l.stream(ctx, conversationID, req.Content, opts.StreamingFunc)
+	} else {
+		choice, err = l.send(ctx, conversationID, req, opts.StreamingFunc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	choice.GenerationInfo = map[string]any{"ConversationID": conversationID.String()}
+
+	l.remember(messages, choice, conversationID)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{choice}}, nil
+}
+
+// send creates the message and reports its reply.
+func (l *LLM) send(ctx context.Context, conversationID models.ConversationID, req *models.MessageCreate, onChunk func(context.Context, []byte) error) (*llms.ContentChoice, error) {
+	msg, err := l.client.CreateMessage(ctx, conversationID, req)
+	if err != nil {
+		return nil, err
+	}
+	if onChunk != nil && msg.Content != "" {
+		if err := onChunk(ctx, []byte(msg.Content)); err != nil {
+			return nil, err
+		}
+	}
+
+	choice := &llms.ContentChoice{Content: msg.Content}
+	for _, part := range msg.Parts {
+		if part.Type == models.ContentPartToolUse {
+			choice.ToolCalls = append(choice.ToolCalls, toolCall(part.ToolUseID, part.ToolName, part.Input))
+		}
+	}
+	choice.StopReason = stopReason(choice)
+	return choice, nil
+}
+
+// stream streams the reply to a text message, passing content deltas to
+// onChunk and collecting the assembled tool calls.
+func (l *LLM) stream(ctx context.Context, conversationID models.ConversationID, content string, onChunk func(context.Context, []byte) error) (*llms.ContentChoice, error) {
+	stream, err := l.client.StreamMessage(ctx, conversationID, content)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	choice := &llms.ContentChoice{}
+	err = stream.ForEach(ctx, func(event *streaming.Event) error {
+		switch event.Type {
+		case streaming.EventContentDelta:
+			return onChunk(ctx, []byte(event.Content()))
+		case streaming.EventToolCall:
+			choice.ToolCalls = append(choice.ToolCalls, toolCall(event.ToolCall.ID, event.ToolCall.Name, event.ToolCall.Input))
+		case streaming.EventError:
+			return fmt.Errorf("stream error: %s", event.Error)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	choice.Content = stream.AccumulatedContent()
+	choice.StopReason = stopReason(choice)
+	return choice, nil
+}
+
+// prepare resolves the conversation a call continues, creating one if
+// needed, and returns the message to send.
+func (l *LLM) prepare(ctx context.Context, messages []llms.MessageContent) (models.ConversationID, *models.MessageCreate, error) {
+	if len(messages) == 0 {
+		return "", nil, ErrNoUserMessage
+	}
+	last := messages[len(messages)-1]
+	history := messages[:len(messages)-1]
+
+	req, err := messageCreate(last)
+	if err != nil {
+		return "", nil, err
+	}
+
+	l.mu.Lock()
+	conversationID, ok := l.conversations[historyKey(history)]
+	l.mu.Unlock()
+	if ok {
+		return conversationID, req, nil
+	}
+
+	var system []string
+	var transcript strings.Builder
+	for _, m := range history {
+		if m.Role == llms.ChatMessageTypeSystem {
+			system = append(system, render(m.Parts))
+			continue
+		}
+		role, err := roleName(m.Role)
+		if err != nil {
+			return "", nil, err
+		}
+		fmt.Fprintf(&transcript, "%s: %s\n", role, render(m.Parts))
+	}
+
+	conv, err := l.client.CreateConversation(ctx, &models.ConversationCreate{
+		SystemPrompt: strings.Join(system, "\n\n"),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	// History the conversation has not seen is passed along as a
+	// transcript ahead of the new message.
+	if transcript.Len() > 0 {
+		req.Content = "Conversation so far:\n" + transcript.String() + "\n" + req.Content
+	}
+	return conv.ID, req, nil
+}
+
+// messageCreate converts the final message of a call. Text-only messages
+// are sent as content alone; any other part is sent as a content part.
+func messageCreate(m llms.MessageContent) (*models.MessageCreate, error) {
+	switch m.Role {
+	case llms.ChatMessageTypeHuman, llms.ChatMessageTypeGeneric, llms.ChatMessageTypeTool:
+	default:
+		return nil, ErrNoUserMessage
+	}
+
+	req := &models.MessageCreate{Role: models.RoleUser}
+	var text []string
+	textOnly := true
+	for _, part := range m.Parts {
+		switch p := part.(type) {
+		case llms.TextContent:
+			text = append(text, p.Text)
+			req.Parts = append(req.Parts, models.ContentPart{Type: models.ContentPartText, Text: p.Text})
+		case llms.ImageURLContent:
+			textOnly = false
+			req.Parts = append(req.Parts, models.ContentPart{Type: models.ContentPartImage, URL: p.URL})
+		case llms.BinaryContent:
+			textOnly = false
+			req.Parts = append(req.Parts, models.ContentPart{
+				Type:      models.ContentPartImage,
+				MediaType: p.MIMEType,
+				Data:      base64.StdEncoding.EncodeToString(p.Data),
+			})
+		case llms.ToolCallResponse:
+			textOnly = false
+			req.Parts = append(req.Parts, models.ContentPart{
+				Type:      models.ContentPartToolResult,
+				ToolUseID: p.ToolCallID,
+				ToolName:  p.Name,
+				Text:      p.Content,
+			})
+		default:
+			return nil, fmt.Errorf("langchaingo: unsupported content part %T", part)
+		}
+	}
+	req.Content = strings.Join(text, "\n")
+	if textOnly {
+		req.Parts = nil
+	}
+	return req, nil
+}
+
+// roleName names a history message's role in a transcript.
+func roleName(role llms.ChatMessageType) (string, error) {
+	switch role {
+	case llms.ChatMessageTypeHuman, llms.ChatMessageTypeGeneric:
+		return string(models.RoleUser), nil
+	case llms.ChatMessageTypeAI:
+		return string(models.RoleAssistant), nil
+	case llms.ChatMessageTypeTool:
+		return "tool", nil
+	}
+	return "", fmt.Errorf("langchaingo: unsupported message role %q", role)
+}
+
+// render returns the text of a history message, describing the parts that
+// are not text.
+func render(parts []llms.ContentPart) string {
+	var out []string
+	for _, part := range parts {
+		switch p := part.(type) {
+		case llms.TextContent:
+			out = append(out, p.Text)
+		case llms.ImageURLContent, llms.BinaryContent:
+			out = append(out, "[image]")
+		case llms.ToolCall:
+			if p.FunctionCall != nil {
+				out = append(out, fmt.Sprintf("[tool call %s: %s(%s)]", p.ID, p.FunctionCall.Name, p.FunctionCall.Arguments))
+			}
+		case llms.ToolCallResponse:
+			out = append(out, fmt.Sprintf("[tool result %s: %s]", p.ToolCallID, p.Content))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// remember records that the conversation now holds messages followed by
+// the assistant's reply, so a call extending that history continues it.
+func (l *LLM) remember(messages []llms.MessageContent, choice *llms.ContentChoice, conversationID models.ConversationID) {
+	reply := llms.MessageContent{Role: llms.ChatMessageTypeAI}
+	if choice.Content != "" {
+		reply.Parts = append(reply.Parts, llms.TextContent{Text: choice.Content})
+	}
+	for _, call := range choice.ToolCalls {
+		reply.Parts = append(reply.Parts, call)
+	}
+	history := append(append([]llms.MessageContent(nil), messages...), reply)
+	key := historyKey(history)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.conversations[key]; !ok {
+		l.order = append(l.order, key)
+	}
+	l.conversations[key] = conversationID
+	for len(l.order) > maxConversations {
+		delete(l.conversations, l.order[0])
+		l.order = l.order[1:]
+	}
+}
+
+// historyKey identifies a message history by the hash of its roles and
+// rendered contents.
+func historyKey(messages []llms.MessageContent) string {
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	for _, m := range messages {
+		enc.Encode([2]string{string(m.Role), render(m.Parts)})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func toolCall(id, name string, input json.RawMessage) llms.ToolCall {
+	arguments := string(input)
+	if arguments == "" {
+		arguments = "{}"
+	}
+	return llms.ToolCall{
+		ID:           id,
+		Type:         "function",
+		FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments},
+	}
+}
+
+func stopReason(choice *llms.ContentChoice) string {
+	if len(choice.ToolCalls) > 0 {
+		return StopReasonToolCalls
+	}
+	return StopReasonStop
+}
@@ -0,0 +1,154 @@
+package langchaingo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/llm-copilot-agent/sdk-go/copilot/client"
+	"github.com/llm-copilot-agent/sdk-go/copilot/copilottest"
+	"github.com/llm-copilot-agent/sdk-go/copilot/models"
+)
+
+func TestGenerateContent(t *testing.T) {
+	server := copilottest.NewFakeServer()
+	defer server.Close()
+	var conversations []models.ConversationID
+	server.Responder = func(id models.ConversationID, content string) string {
+		conversations = append(conversations, id)
+		return "reply to " + content
+	}
+
+	llm := New(server.Client())
+	ctx := context.Background()
+
+	messages := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "Be brief."),
+		llms.TextParts(llms.ChatMessageTypeHuman, "hello"),
+	}
+	resp, err := llm.GenerateContent(ctx, messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Choices) != 1 || resp.Choices[0].Content != "reply to hello" || resp.Choices[0].StopReason != StopReasonStop {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	// Extending the history continues the same conversation.
+	messages = append(messages,
+		llms.TextParts(llms.ChatMessageTypeAI, resp.Choices[0].Content),
+		llms.TextParts(llms.ChatMessageTypeHuman, "again"))
+	resp, err = llm.GenerateContent(ctx, messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Choices[0].Content != "reply to again" {
+		t.Errorf("unexpected response %+v", resp.Choices[0])
+	}
+	if len(conversations) != 2 || conversations[0] != conversations[1] {
+		t.Errorf("expected one conversation, got %v", conversations)
+	}
+
+	reply, err := llm.Call(ctx, "bye")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reply != "reply to bye" || conversations[2] == conversations[0] {
+		t.Errorf("expected a new conversation, got %q in %v", reply, conversations)
+	}
+
+	if _, err := llm.GenerateContent(ctx, messages[:1]); !errors.Is(err, ErrNoUserMessage) {
+		t.Errorf("expected ErrNoUserMessage, got %v", err)
+	}
+}
+
+func TestGenerateContentStreaming(t *testing.T) {
+	server := copilottest.NewFakeServer()
+	defer server.Close()
+
+	var chunks []string
+	resp, err := New(server.Client()).GenerateContent(context.Background(),
+		[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "hello there")},
+		llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(chunks, ""); got != "You said: hello there" || resp.Choices[0].Content != got {
+		t.Errorf("unexpected content %q, chunks %q", resp.Choices[0].Content, chunks)
+	}
+	if len(chunks) < 2 {
+		t.Errorf("expected the reply in several chunks, got %q", chunks)
+	}
+}
+
+func TestGenerateContentToolCalls(t *testing.T) {
+	var sent models.MessageCreate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/conversations":
+			json.NewEncoder(w).Encode(models.Conversation{ID: "conv-1"})
+		case "/api/v1/conversations/conv-1/messages/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte(`data: {"type":"message_start","message_id":"msg-1"}
+
+data: {"type":"tool_use","delta":{"type":"tool_use_start","index":0,"id":"call-1","name":"weather"}}
+
+data: {"type":"tool_use","delta":{"type":"input_json_delta","index":0,"partial_json":"{\"city\":\"Oslo\"}"}}
+
+data: {"type":"tool_use","delta":{"type":"tool_use_stop","index":0}}
+
+data: {"type":"message_end","message_id":"msg-1"}
+
+`))
+		case "/api/v1/conversations/conv-1/messages":
+			json.NewDecoder(r.Body).Decode(&sent)
+			json.NewEncoder(w).Encode(models.Message{ID: "msg-2", Role: models.RoleAssistant, Content: "It is sunny."})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	llm := New(client.NewWithAPIKey(server.URL, "test-key"))
+	ctx := context.Background()
+
+	messages := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Weather in Oslo?")}
+	resp, err := llm.GenerateContent(ctx, messages, llms.WithStreamingFunc(func(context.Context, []byte) error { return nil }))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	choice := resp.Choices[0]
+	if choice.StopReason != StopReasonToolCalls || len(choice.ToolCalls) != 1 {
+		t.Fatalf("expected a tool call, got %+v", choice)
+	}
+	call := choice.ToolCalls[0]
+	if call.ID != "call-1" || call.FunctionCall.Name != "weather" || call.FunctionCall.Arguments != `{"city":"Oslo"}` {
+		t.Errorf("unexpected tool call %+v", call)
+	}
+
+	// The tool's answer continues the conversation as a tool_result part.
+	messages = append(messages,
+		llms.MessageContent{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{call}},
+		llms.MessageContent{Role: llms.ChatMessageTypeTool, Parts: []llms.ContentPart{
+			llms.ToolCallResponse{ToolCallID: "call-1", Name: "weather", Content: "sunny"},
+		}})
+	resp, err = llm.GenerateContent(ctx, messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Choices[0].Content != "It is sunny." || resp.Choices[0].StopReason != StopReasonStop {
+		t.Errorf("unexpected response %+v", resp.Choices[0])
+	}
+	if len(sent.Parts) != 1 || sent.Parts[0].Type != models.ContentPartToolResult || sent.Parts[0].ToolUseID != "call-1" || sent.Parts[0].Text != "sunny" {
+		t.Errorf("unexpected tool result %+v", sent.Parts)
+	}
+}